@@ -7,13 +7,18 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/application/event"
 	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/api"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/fxrates"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/kafka"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
@@ -38,6 +43,22 @@ func main() {
 		log.Fatalf("Error running migrations: %v", err)
 	}
 
+	// READ_DB_NAME aponta para o banco dos modelos de leitura mantidos pelo
+	// worker; por padrão, eles ficam no schema read_model do próprio banco
+	readDB := db
+	if readDBName := getEnv("READ_DB_NAME", dbName); readDBName != dbName {
+		readDB, err = sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			dbHost, dbPort, dbUser, dbPassword, readDBName))
+		if err != nil {
+			log.Fatalf("Error connecting to read database: %v", err)
+		}
+		defer readDB.Close()
+	}
+
+	if err := persistence.RunReadModelMigrations(readDB); err != nil {
+		log.Fatalf("Error running read model migrations: %v", err)
+	}
+
 	kafkaBrokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:29092"), ",")
 	eventPublisher := kafka.NewEventPublisher(kafkaBrokers)
 	defer eventPublisher.Close()
@@ -47,9 +68,34 @@ func main() {
 		log.Printf("Os tópicos serão criados automaticamente quando o Kafka estiver disponível")
 	}
 
-	accountRepo, err := persistence.NewPostgresRepository(connStr)
-	if err != nil {
-		log.Fatalf("Error creating repository: %v", err)
+	// ACCOUNT_STORE seleciona a persistência das contas: "postgres" grava o estado
+	// na tabela accounts; "eventstore" grava os eventos no fluxo account_events
+	var accountRepo account.Repository
+	var uowOptions []persistence.UnitOfWorkOption
+	switch accountStore := getEnv("ACCOUNT_STORE", "postgres"); accountStore {
+	case "postgres":
+		postgresRepo, err := persistence.NewPostgresRepository(connStr)
+		if err != nil {
+			log.Fatalf("Error creating repository: %v", err)
+		}
+		accountRepo = postgresRepo
+	case "eventstore":
+		snapshotEvery, err := strconv.ParseInt(getEnv("ACCOUNT_SNAPSHOT_EVERY", strconv.Itoa(persistence.DefaultSnapshotEvery)), 10, 64)
+		if err != nil || snapshotEvery < 0 {
+			log.Fatalf("Invalid ACCOUNT_SNAPSHOT_EVERY: expected a non-negative number of events")
+		}
+
+		imported, err := persistence.ImportAccountStreams(db)
+		if err != nil {
+			log.Fatalf("Error importing account streams: %v", err)
+		}
+		if imported > 0 {
+			log.Printf("%d contas existentes importadas para o event store", imported)
+		}
+		accountRepo = persistence.NewEventSourcedRepository(db, snapshotEvery)
+		uowOptions = append(uowOptions, persistence.WithEventSourcedAccounts(snapshotEvery))
+	default:
+		log.Fatalf("Invalid ACCOUNT_STORE %q: expected postgres or eventstore", accountStore)
 	}
 
 	outboxRepo := persistence.NewOutboxRepository(db)
@@ -64,20 +110,98 @@ func main() {
 	outboxProcessor.Start()
 	defer outboxProcessor.Stop()
 
-	createAccountHandler := command.NewCreateAccountHandler(accountRepo, eventPublisher, outboxRepo)
-	depositHandler := command.NewDepositHandler(accountRepo, eventPublisher)
-	withdrawHandler := command.NewWithdrawHandler(accountRepo, eventPublisher)
+	uow := persistence.NewPostgresUnitOfWork(db, uowOptions...)
 
-	accountQuery := query.NewAccountQueryHandler(accountRepo)
+	// O outbox é o registro oficial dos eventos; a publicação direta após o commit
+	// é apenas um caminho rápido opcional para reduzir a latência de entrega
+	var fastPathPublisher event.Publisher
+	if getEnv("EVENT_FAST_PATH", "true") == "true" {
+		fastPathPublisher = eventPublisher
+	}
+
+	// FX_RATES_FILE aponta para o arquivo de taxas de câmbio; sem ele, as
+	// transferências entre moedas ficam indisponíveis
+	var fxRates command.FXRateProvider
+	if path := getEnv("FX_RATES_FILE", ""); path != "" {
+		fileRates, err := fxrates.NewFileProvider(path)
+		if err != nil {
+			log.Fatalf("Error loading exchange rates: %v", err)
+		}
+		fxRates = fileRates
+	}
+	quoteTTL, err := time.ParseDuration(getEnv("FX_QUOTE_TTL", command.DefaultQuoteTTL.String()))
+	if err != nil {
+		log.Fatalf("Invalid FX_QUOTE_TTL: %v", err)
+	}
+
+	// Os juros pendentes das contas poupança são creditados no encerramento, à
+	// mesma taxa usada pelo worker
+	savingsRate, err := interest.ParseAnnualRate(getEnv("SAVINGS_ANNUAL_RATE", "0.05"))
+	if err != nil {
+		log.Fatalf("Invalid SAVINGS_ANNUAL_RATE: %v", err)
+	}
+	interestRates := interest.Rates{account.TypeSavings: savingsRate}
+
+	createAccountHandler := command.NewCreateAccountHandler(uow, fastPathPublisher)
+	depositHandler := command.NewDepositHandler(uow, fastPathPublisher)
+	withdrawHandler := command.NewWithdrawHandler(uow, fastPathPublisher)
+	blockAccountHandler := command.NewBlockAccountHandler(uow, fastPathPublisher)
+	activateAccountHandler := command.NewActivateAccountHandler(uow, fastPathPublisher)
+	closeAccountHandler := command.NewCloseAccountHandler(uow, fastPathPublisher, interestRates, time.Now)
+	overdraftLimitHandler := command.NewSetOverdraftLimitHandler(uow, fastPathPublisher)
+	placeHoldHandler := command.NewPlaceHoldHandler(uow, fastPathPublisher)
+	captureHoldHandler := command.NewCaptureHoldHandler(uow, fastPathPublisher)
+	releaseHoldHandler := command.NewReleaseHoldHandler(uow, fastPathPublisher)
+	processTransferHandler := command.NewProcessTransferHandler(uow, fastPathPublisher)
+	transferHandler := command.NewTransferHandler(uow, fastPathPublisher, processTransferHandler, fxRates)
+	createQuoteHandler := command.NewCreateQuoteHandler(uow, fxRates, quoteTTL)
+	setFeeWaiverHandler := command.NewSetFeeWaiverHandler(uow)
+	removeFeeWaiverHandler := command.NewRemoveFeeWaiverHandler(uow)
+	createScheduleHandler := command.NewCreateScheduleHandler(uow)
+	updateScheduleHandler := command.NewUpdateScheduleHandler(uow)
+	cancelScheduleHandler := command.NewCancelScheduleHandler(uow)
+	reverseTransactionHandler := command.NewReverseTransactionHandler(uow, fastPathPublisher)
+
+	ledgerRepo := persistence.NewPostgresLedgerRepository(db)
+	accountQuery := query.NewAccountQueryHandler(persistence.NewPostgresAccountViewRepository(readDB), accountRepo, ledgerRepo)
+	transferQuery := query.NewTransferQueryHandler(persistence.NewPostgresTransferRepository(db))
+	scheduleQuery := query.NewScheduleQueryHandler(persistence.NewPostgresScheduleRepository(db))
+	transactionHistoryRepo := persistence.NewPostgresTransactionHistoryRepository(db)
+	ledgerQuery := query.NewLedgerQueryHandler(ledgerRepo)
+	transactionQuery := query.NewTransactionHistoryQueryHandler(accountRepo, transactionHistoryRepo)
+	statementQuery := query.NewStatementQueryHandler(accountRepo, ledgerRepo, transactionHistoryRepo, persistence.NewPostgresStatementRepository(db))
 
 	accountHandler := api.NewAccountHandler(
 		createAccountHandler,
 		depositHandler,
 		withdrawHandler,
+		blockAccountHandler,
+		activateAccountHandler,
+		closeAccountHandler,
+		overdraftLimitHandler,
 		accountQuery,
 	)
 
-	e := api.SetupRoutes(accountHandler)
+	holdHandler := api.NewHoldHandler(placeHoldHandler, captureHoldHandler, releaseHoldHandler, accountQuery)
+	transferAPIHandler := api.NewTransferHandler(transferHandler, transferQuery)
+	fxHandler := api.NewFXHandler(createQuoteHandler)
+	feeHandler := api.NewFeeHandler(setFeeWaiverHandler, removeFeeWaiverHandler)
+	scheduleHandler := api.NewScheduleHandler(createScheduleHandler, updateScheduleHandler, cancelScheduleHandler, scheduleQuery)
+
+	transactionHandler := api.NewTransactionHandler(transactionQuery)
+	statementHandler := api.NewStatementHandler(statementQuery)
+	ledgerHandler := api.NewLedgerHandler(ledgerQuery)
+	adminHandler := api.NewAdminHandler(reverseTransactionHandler)
+
+	idempotencyRepo := persistence.NewIdempotencyRepository(db)
+
+	// ADMIN_API_TOKEN é o token exigido nas rotas /admin; sem ele, elas ficam indisponíveis
+	adminToken := getEnv("ADMIN_API_TOKEN", "")
+	if adminToken == "" {
+		log.Printf("Aviso: ADMIN_API_TOKEN não definido; as rotas administrativas recusarão todas as requisições")
+	}
+
+	e := api.SetupRoutes(accountHandler, transactionHandler, statementHandler, holdHandler, feeHandler, scheduleHandler, transferAPIHandler, fxHandler, ledgerHandler, adminHandler, idempotencyRepo, adminToken)
 
 	port := getEnv("PORT", "8080")
 	go func() {
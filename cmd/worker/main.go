@@ -2,17 +2,83 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
+	_ "github.com/lib/pq" // Driver PostgreSQL
+
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/application/event"
 	"github.com/viniciuslima/account-EDA/internal/application/event/handlers"
+	"github.com/viniciuslima/account-EDA/internal/application/projection"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/fxrates"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/kafka"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/scheduler"
 )
 
 func main() {
+	// Configuração do banco de dados (usado pelos handlers que executam comandos)
+	dbName := getEnv("DB_NAME", "account")
+	connStr := func(name string) string {
+		return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			getEnv("DB_HOST", "localhost"),
+			getEnv("DB_PORT", "5432"),
+			getEnv("DB_USER", "postgres"),
+			getEnv("DB_PASSWORD", "postgres"),
+			name)
+	}
+
+	db, err := sql.Open("postgres", connStr(dbName))
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+	}
+	defer db.Close()
+
+	// Banco dos modelos de leitura mantidos pelas projeções; deve ser o mesmo
+	// READ_DB_NAME da API
+	readDB := db
+	if readDBName := getEnv("READ_DB_NAME", dbName); readDBName != dbName {
+		readDB, err = sql.Open("postgres", connStr(readDBName))
+		if err != nil {
+			log.Fatalf("Erro ao conectar ao banco de leitura: %v", err)
+		}
+		defer readDB.Close()
+	}
+
+	if err := persistence.RunReadModelMigrations(readDB); err != nil {
+		log.Fatalf("Erro ao executar as migrações do modelo de leitura: %v", err)
+	}
+
+	// A persistência das contas deve ser a mesma escolhida na API
+	var accountRepo account.Repository
+	var uowOptions []persistence.UnitOfWorkOption
+	switch accountStore := getEnv("ACCOUNT_STORE", "postgres"); accountStore {
+	case "postgres":
+		accountRepo = persistence.NewPostgresRepositoryFromDB(db)
+	case "eventstore":
+		snapshotEvery, err := strconv.ParseInt(getEnv("ACCOUNT_SNAPSHOT_EVERY", strconv.Itoa(persistence.DefaultSnapshotEvery)), 10, 64)
+		if err != nil || snapshotEvery < 0 {
+			log.Fatalf("ACCOUNT_SNAPSHOT_EVERY inválido: informe um número de eventos não negativo")
+		}
+		accountRepo = persistence.NewEventSourcedRepository(db, snapshotEvery)
+		uowOptions = append(uowOptions, persistence.WithEventSourcedAccounts(snapshotEvery))
+	default:
+		log.Fatalf("ACCOUNT_STORE inválido %q: use postgres ou eventstore", accountStore)
+	}
+
+	uow := persistence.NewPostgresUnitOfWork(db, uowOptions...)
+
 	// Configuração do Kafka
 	kafkaBrokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:29092"), ",")
 	groupID := getEnv("CONSUMER_GROUP_ID", "account-events-worker")
@@ -21,15 +87,150 @@ func main() {
 	// Criar consumidor
 	consumer := kafka.NewEventConsumer(kafkaBrokers, groupID, topic)
 
+	// Publicador para o caminho rápido dos eventos gerados pelo worker;
+	// o outbox processor da API garante a entrega caso ele falhe
+	eventPublisher := kafka.NewEventPublisher(kafkaBrokers)
+	defer eventPublisher.Close()
+
+	var fastPathPublisher event.Publisher
+	if getEnv("EVENT_FAST_PATH", "true") == "true" {
+		fastPathPublisher = eventPublisher
+	}
+
+	processTransferHandler := command.NewProcessTransferHandler(uow, fastPathPublisher)
+
 	// Registrar handlers para cada tipo de evento
 	consumer.RegisterHandler(handlers.NewAccountCreatedHandler())
 	consumer.RegisterHandler(handlers.NewAccountDepositedHandler())
 	consumer.RegisterHandler(handlers.NewAccountWithdrawnHandler())
+	consumer.RegisterHandler(handlers.NewAccountBlockedHandler())
+	consumer.RegisterHandler(handlers.NewAccountActivatedHandler())
+	consumer.RegisterHandler(handlers.NewAccountClosedHandler())
+	consumer.RegisterHandler(handlers.NewTransferInitiatedHandler(processTransferHandler))
+
+	// Modelo de leitura do histórico de transações
+	transactionHistory := persistence.NewPostgresTransactionHistoryRepository(db)
+	consumer.RegisterHandler(handlers.NewDepositHistoryHandler(transactionHistory))
+	consumer.RegisterHandler(handlers.NewWithdrawalHistoryHandler(transactionHistory))
+	consumer.RegisterHandler(handlers.NewCaptureHistoryHandler(transactionHistory))
+	consumer.RegisterHandler(handlers.NewInterestHistoryHandler(transactionHistory))
+	consumer.RegisterHandler(handlers.NewFeeHistoryHandler(transactionHistory))
+	consumer.RegisterHandler(handlers.NewReversalHistoryHandler(transactionHistory))
+
+	// Expiração automática das reservas de saldo vencidas
+	holdExpiryInterval, err := time.ParseDuration(getEnv("HOLD_EXPIRY_INTERVAL", "1m"))
+	if err != nil || holdExpiryInterval <= 0 {
+		log.Fatalf("HOLD_EXPIRY_INTERVAL inválido: informe uma duração positiva, como 30s ou 1m")
+	}
+	expireHoldsHandler := command.NewExpireHoldsHandler(uow, fastPathPublisher, persistence.NewPostgresHoldRepository(db))
+	holdExpiry := scheduler.NewJob("expiração de reservas", holdExpiryInterval, func(now time.Time) error {
+		released, err := expireHoldsHandler.Handle(command.ExpireHoldsCommand{Now: now, BatchSize: 100})
+		if released > 0 {
+			log.Printf("%d reservas vencidas liberadas", released)
+		}
+		return err
+	})
+	holdExpiry.Start()
+	defer holdExpiry.Stop()
+
+	// Apuração diária e crédito mensal dos juros das contas poupança
+	savingsRate, err := interest.ParseAnnualRate(getEnv("SAVINGS_ANNUAL_RATE", "0.05"))
+	if err != nil {
+		log.Fatalf("SAVINGS_ANNUAL_RATE inválido: informe a taxa anual como fração, como 0.05 para 5%%")
+	}
+	interestAccrualInterval, err := time.ParseDuration(getEnv("INTEREST_ACCRUAL_INTERVAL", "1h"))
+	if err != nil || interestAccrualInterval <= 0 {
+		log.Fatalf("INTEREST_ACCRUAL_INTERVAL inválido: informe uma duração positiva, como 30m ou 1h")
+	}
+	accrueInterestHandler := command.NewAccrueInterestHandler(uow, fastPathPublisher, persistence.NewPostgresInterestRepository(db),
+		interest.Rates{account.TypeSavings: savingsRate}, time.Now)
+	interestAccrual := scheduler.NewJob("apuração de juros", interestAccrualInterval, func(now time.Time) error {
+		credited, err := accrueInterestHandler.Handle(command.AccrueInterestCommand{BatchSize: 100})
+		if credited > 0 {
+			log.Printf("Juros creditados em %d contas", credited)
+		}
+		return err
+	})
+	interestAccrual.Start()
+	defer interestAccrual.Stop()
+
+	// Cobrança mensal da tarifa de manutenção
+	maintenanceFeeInterval, err := time.ParseDuration(getEnv("MAINTENANCE_FEE_INTERVAL", "1h"))
+	if err != nil || maintenanceFeeInterval <= 0 {
+		log.Fatalf("MAINTENANCE_FEE_INTERVAL inválido: informe uma duração positiva, como 30m ou 1h")
+	}
+	maintenanceFeesHandler := command.NewChargeMaintenanceFeesHandler(uow, fastPathPublisher, persistence.NewPostgresFeeRepository(db), time.Now)
+	maintenanceFees := scheduler.NewJob("tarifa de manutenção", maintenanceFeeInterval, func(now time.Time) error {
+		charged, err := maintenanceFeesHandler.Handle(command.ChargeMaintenanceFeesCommand{BatchSize: 100})
+		if charged > 0 {
+			log.Printf("Tarifa de manutenção cobrada de %d contas", charged)
+		}
+		return err
+	})
+	maintenanceFees.Start()
+	defer maintenanceFees.Stop()
+
+	// Execução das transferências agendadas; FX_RATES_FILE deve ser o mesmo da
+	// API para que agendamentos entre moedas sejam convertidos
+	var fxRates command.FXRateProvider
+	if path := getEnv("FX_RATES_FILE", ""); path != "" {
+		fileRates, err := fxrates.NewFileProvider(path)
+		if err != nil {
+			log.Fatalf("Erro ao carregar as taxas de câmbio: %v", err)
+		}
+		fxRates = fileRates
+	}
+	scheduledTransferInterval, err := time.ParseDuration(getEnv("SCHEDULED_TRANSFER_INTERVAL", "1m"))
+	if err != nil || scheduledTransferInterval <= 0 {
+		log.Fatalf("SCHEDULED_TRANSFER_INTERVAL inválido: informe uma duração positiva, como 30s ou 1m")
+	}
+	scheduleRetryInterval, err := time.ParseDuration(getEnv("SCHEDULE_RETRY_INTERVAL", command.DefaultScheduleRetryInterval.String()))
+	if err != nil || scheduleRetryInterval <= 0 {
+		log.Fatalf("SCHEDULE_RETRY_INTERVAL inválido: informe uma duração positiva, como 30m ou 1h")
+	}
+	transferHandler := command.NewTransferHandler(uow, fastPathPublisher, processTransferHandler, fxRates)
+	executeSchedulesHandler := command.NewExecuteSchedulesHandler(uow, persistence.NewPostgresScheduleRepository(db),
+		transferHandler, scheduleRetryInterval, time.Now)
+	scheduledTransfers := scheduler.NewJob("transferências agendadas", scheduledTransferInterval, func(now time.Time) error {
+		executed, err := executeSchedulesHandler.Handle(command.ExecuteSchedulesCommand{BatchSize: 100})
+		if executed > 0 {
+			log.Printf("%d transferências agendadas executadas", executed)
+		}
+		return err
+	})
+	scheduledTransfers.Start()
+	defer scheduledTransfers.Stop()
+
+	// Geração antecipada dos extratos mensais
+	statementInterval, err := time.ParseDuration(getEnv("STATEMENT_INTERVAL", "1h"))
+	if err != nil || statementInterval <= 0 {
+		log.Fatalf("STATEMENT_INTERVAL inválido: informe uma duração positiva, como 30m ou 1h")
+	}
+	statementQuery := query.NewStatementQueryHandler(accountRepo, persistence.NewPostgresLedgerRepository(db),
+		transactionHistory, persistence.NewPostgresStatementRepository(db))
+	monthlyStatements := scheduler.NewJob("extratos mensais", statementInterval, func(now time.Time) error {
+		generated, err := statementQuery.GenerateMonthlyStatements(now, 100)
+		if generated > 0 {
+			log.Printf("%d extratos mensais gerados", generated)
+		}
+		return err
+	})
+	monthlyStatements.Start()
+	defer monthlyStatements.Stop()
 
 	// Contexto para graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Projeções dos modelos de leitura consultados pela API, alimentadas pelo
+	// tópico de eventos em um grupo de consumidores próprio
+	projector := projection.NewProjector(persistence.NewPostgresCheckpointRepository(readDB),
+		projection.NewAccountProjection(accountRepo, persistence.NewPostgresAccountViewRepository(readDB)))
+	if err := projector.Bootstrap(ctx); err != nil {
+		log.Fatalf("Erro ao inicializar as projeções: %v", err)
+	}
+	projectionConsumer := kafka.NewProjectionConsumer(kafkaBrokers, getEnv("PROJECTION_GROUP_ID", "account-projections"), topic, projector)
+
 	// Canal para sinais do sistema
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -42,6 +243,12 @@ func main() {
 		}
 	}()
 
+	go func() {
+		if err := projectionConsumer.Start(ctx); err != nil {
+			log.Printf("Erro no consumidor das projeções: %v", err)
+		}
+	}()
+
 	// Aguardar sinal de interrupção
 	<-sigChan
 	log.Println("Recebido sinal de interrupção, encerrando worker...")
@@ -51,6 +258,9 @@ func main() {
 	if err := consumer.Stop(); err != nil {
 		log.Printf("Erro ao parar consumidor: %v", err)
 	}
+	if err := projectionConsumer.Stop(); err != nil {
+		log.Printf("Erro ao parar consumidor das projeções: %v", err)
+	}
 
 	log.Println("Worker encerrado com sucesso")
 }
@@ -0,0 +1,320 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+func TestBlockAccountHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewBlockAccountHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+
+	var saved account.AccountBlockedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountBlockedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.AccountBlockedEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(BlockAccountCommand{AccountID: "account-123", Reason: "suspeita de fraude"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusBlocked, existingAccount.Status)
+	assert.Equal(t, "account-123", saved.AccountID)
+	assert.Equal(t, "suspeita de fraude", saved.Reason)
+	assert.Equal(t, 1, uow.commits)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBlockAccountHandler_Handle_ReasonRequired(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewBlockAccountHandler(uow, nil)
+
+	// Act
+	err := handler.Handle(BlockAccountCommand{AccountID: "account-123"})
+
+	// Assert: nada é lido nem gravado
+	assert.Equal(t, ErrReasonRequired, err)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestBlockAccountHandler_Handle_AlreadyBlocked(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewBlockAccountHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+	existingAccount.Status = account.StatusBlocked
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(BlockAccountCommand{AccountID: "account-123", Reason: "suspeita de fraude"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrAccountAlreadyBlocked)
+	assert.Equal(t, 1, uow.rollbacks)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestActivateAccountHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewActivateAccountHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+	existingAccount.Status = account.StatusBlocked
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountActivatedEvent")).Return(nil)
+
+	// Act
+	err := handler.Handle(ActivateAccountCommand{AccountID: "account-123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusActive, existingAccount.Status)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestActivateAccountHandler_Handle_AccountNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewActivateAccountHandler(uow, nil)
+
+	mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	err := handler.Handle(ActivateAccountCommand{AccountID: "missing"})
+
+	// Assert
+	assert.Equal(t, ErrAccountNotFound, err)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCloseAccountHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	existingAccount := newTestAccount("account-123", 0)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountClosedEvent")).Return(nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, existingAccount.Status)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestCloseAccountHandler_Handle_NonZeroBalance(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	existingAccount := newTestAccount("account-123", 100)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert: a conta continua ativa
+	assert.ErrorIs(t, err, account.ErrAccountHasBalance)
+	assert.Equal(t, account.StatusActive, existingAccount.Status)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCloseAccountHandler_Handle_SettlesBalanceToPayoutAccount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.ledger = recordingLedger(&entries)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	closing := newTestAccount("closing", 4250)
+	payout := newTestAccount("payout", 1000)
+
+	var settlement *transfer.Transfer
+	var recorded []string
+	mockRepo.On("FindByID", "closing").Return(closing, nil)
+	mockRepo.On("FindByID", "payout").Return(payout, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("HasPendingFrom", "closing").Return(false, nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		settlement = args.Get(0).(*transfer.Transfer)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(account.Event).EventName())
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "closing", PayoutAccountID: "payout"})
+
+	// Assert: o saldo vai para a conta de destino e a conta é encerrada zerada
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, closing.Status)
+	assert.True(t, closing.Balance.IsZero())
+	assert.Equal(t, account.NewMoney(5250, account.DefaultCurrency), payout.Balance)
+	assert.Equal(t, transfer.StatusCompleted, settlement.Status)
+	assert.Equal(t, []string{"AccountWithdrawn", "AccountClosed", "AccountDeposited", "TransferCompleted"}, recorded)
+	assert.Equal(t, map[string]int64{
+		"closing":                        -4250,
+		"payout":                         4250,
+		ledger.TransfersInTransitAccount: 0,
+	}, ledgerBalances(entries))
+	assert.Equal(t, 1, uow.commits)
+}
+
+func TestCloseAccountHandler_Handle_SettlesBlockedAccount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	closing := newTestAccount("closing", 4250)
+	closing.Status = account.StatusBlocked
+	payout := newTestAccount("payout", 0)
+
+	mockRepo.On("FindByID", "closing").Return(closing, nil)
+	mockRepo.On("FindByID", "payout").Return(payout, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("HasPendingFrom", "closing").Return(false, nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "closing", PayoutAccountID: "payout"})
+
+	// Assert: o bloqueio não impede a liquidação do saldo no encerramento
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, closing.Status)
+	assert.True(t, closing.Balance.IsZero())
+	assert.Equal(t, account.NewMoney(4250, account.DefaultCurrency), payout.Balance)
+}
+
+func TestCloseAccountHandler_Handle_PendingTransfers(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	// O valor da transferência pendente já saiu do saldo, mas pode ser devolvido
+	existingAccount := newTestAccount("account-123", 0)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockTransfers.On("HasPendingFrom", "account-123").Return(true, nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert: a conta continua ativa para receber uma eventual devolução
+	assert.ErrorIs(t, err, account.ErrAccountHasTransfers)
+	assert.Equal(t, account.StatusActive, existingAccount.Status)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCloseAccountHandler_Handle_PayoutAccountNotActive(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	closing := newTestAccount("closing", 4250)
+	payout := newTestAccount("payout", 0)
+	payout.Status = account.StatusBlocked
+
+	mockRepo.On("FindByID", "closing").Return(closing, nil)
+	mockRepo.On("FindByID", "payout").Return(payout, nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "closing", PayoutAccountID: "payout"})
+
+	// Assert: nada é confirmado
+	assert.ErrorIs(t, err, account.ErrAccountNotActive)
+	assert.Equal(t, 1, uow.rollbacks)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestClosedAccount_RejectsCommands(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+
+	closed := newTestAccount("account-123", 0)
+	closed.Status = account.StatusClosed
+
+	mockRepo.On("FindByID", "account-123").Return(closed, nil)
+
+	// Act
+	depositErr := NewDepositHandler(uow, nil).Handle(DepositCommand{AccountID: "account-123", Amount: "10.00"})
+	blockErr := NewBlockAccountHandler(uow, nil).Handle(BlockAccountCommand{AccountID: "account-123", Reason: "fraude"})
+	activateErr := NewActivateAccountHandler(uow, nil).Handle(ActivateAccountCommand{AccountID: "account-123"})
+	closeErr := NewCloseAccountHandler(uow, nil, nil, nil).Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert: o encerramento é definitivo
+	assert.ErrorIs(t, depositErr, account.ErrAccountClosed)
+	assert.ErrorIs(t, blockErr, account.ErrAccountClosed)
+	assert.ErrorIs(t, activateErr, account.ErrAccountClosed)
+	assert.ErrorIs(t, closeErr, account.ErrAccountAlreadyClosed)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
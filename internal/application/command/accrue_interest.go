@@ -0,0 +1,194 @@
+package command
+
+import (
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// Clock fornece o instante atual. Injetado nos manipuladores que dependem da
+// data, para que os testes controlem o tempo.
+type Clock func() time.Time
+
+// SavingsAccountFinder localiza as contas poupança, em lotes ordenados por ID
+type SavingsAccountFinder interface {
+	FindSavingsAccounts(afterID string, limit int) ([]string, error)
+}
+
+// AccrueInterestCommand representa o comando para apurar e creditar juros
+type AccrueInterestCommand struct {
+	BatchSize int
+}
+
+// AccrueInterestHandler apura os juros das contas poupança e os credita
+// mensalmente.
+//
+// Cada dia encerrado é apurado sobre o saldo do fim do dia segundo o razão, de
+// modo que dias perdidos (worker parado) são recuperados com o saldo correto.
+// Quando o mês termina, as apurações do mês são somadas e creditadas na conta
+// com o evento InterestCredited. Executar o comando de novo no mesmo dia não
+// apura nem credita nada em dobro.
+type AccrueInterestHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+	accounts  SavingsAccountFinder
+	rates     interest.Rates
+	clock     Clock
+}
+
+// NewAccrueInterestHandler cria um novo manipulador de juros. Sem clock, usa time.Now.
+func NewAccrueInterestHandler(uow persistence.UnitOfWork, publisher event.Publisher, accounts SavingsAccountFinder, rates interest.Rates, clock Clock) *AccrueInterestHandler {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &AccrueInterestHandler{
+		uow:       uow,
+		publisher: publisher,
+		accounts:  accounts,
+		rates:     rates,
+		clock:     clock,
+	}
+}
+
+// Handle apura os dias encerrados de todas as contas poupança e credita os
+// meses encerrados. Retorna quantos créditos de juros foram feitos. Cada conta
+// é processada em sua própria transação, de modo que a falha em uma conta não
+// impede as demais.
+func (h *AccrueInterestHandler) Handle(cmd AccrueInterestCommand) (int, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	today := interest.Day(h.clock())
+
+	credited := 0
+	afterID := ""
+	for {
+		accountIDs, err := h.accounts.FindSavingsAccounts(afterID, batchSize)
+		if err != nil {
+			return credited, err
+		}
+
+		for _, accountID := range accountIDs {
+			count, err := h.accrue(accountID, today)
+			if err != nil {
+				log.Printf("Erro ao apurar juros da conta %s: %v", accountID, err)
+				continue
+			}
+			credited += count
+		}
+
+		if len(accountIDs) < batchSize {
+			return credited, nil
+		}
+		afterID = accountIDs[len(accountIDs)-1]
+	}
+}
+
+// accrue apura os dias da conta anteriores a today e credita os meses
+// encerrados, retornando quantos créditos foram feitos
+func (h *AccrueInterestHandler) accrue(accountID string, today time.Time) (int, error) {
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			acc, err := tx.Accounts().FindByID(accountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+			if acc.Status == account.StatusClosed {
+				return nil
+			}
+
+			// Creditar apenas os meses encerrados
+			if err := accrueInterest(tx, acc, h.rates.For(acc.Type), today, interest.MonthStart(today)); err != nil {
+				return err
+			}
+
+			events = acc.Changes()
+			if len(events) == 0 {
+				return nil
+			}
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	return len(events), nil
+}
+
+// accrueInterest apura os dias da conta anteriores a today ainda não apurados,
+// desde a abertura, e credita na conta as apurações de dias anteriores a
+// creditBefore que ainda não foram creditadas, um crédito por mês
+func accrueInterest(tx persistence.Transaction, acc *account.Account, rate interest.AnnualRate, today, creditBefore time.Time) error {
+	from := interest.Day(acc.CreatedAt)
+	last, err := tx.Interest().LastAccrualDay(acc.ID)
+	if err != nil {
+		return err
+	}
+	if !last.IsZero() {
+		from = last.AddDate(0, 0, 1)
+	}
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		balance, err := tx.Ledger().BalanceAt(acc.ID, acc.Currency(), day.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		if err := tx.Interest().Record(interest.Accrue(acc.ID, day, balance, rate)); err != nil {
+			return err
+		}
+	}
+
+	pending, err := tx.Interest().Unposted(acc.ID, creditBefore)
+	if err != nil {
+		return err
+	}
+	for _, month := range interest.Monthly(pending, acc.Currency()) {
+		eventID, err := creditInterest(tx, acc, month)
+		if err != nil {
+			return err
+		}
+		if err := tx.Interest().MarkPosted(acc.ID, month.End, eventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creditInterest credita na conta os juros do mês e lança o crédito no razão,
+// retornando o ID do evento. Meses cujo total arredonda para zero não geram
+// crédito, e o ID retornado é vazio.
+func creditInterest(tx persistence.Transaction, acc *account.Account, month interest.MonthlyInterest) (string, error) {
+	if !month.Amount.IsPositive() {
+		return "", nil
+	}
+	if err := acc.CreditInterest(month.Amount, month.Period); err != nil {
+		return "", err
+	}
+	changes := acc.Changes()
+	eventID := changes[len(changes)-1].EventID()
+
+	entry, err := ledger.NewInterestEntry(eventID, acc.ID, month.Amount)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Ledger().Append(entry); err != nil {
+		return "", err
+	}
+	return eventID, nil
+}
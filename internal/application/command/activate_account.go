@@ -0,0 +1,33 @@
+package command
+
+import (
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// ActivateAccountCommand representa o comando para reativar uma conta
+type ActivateAccountCommand struct {
+	AccountID string `json:"account_id"`
+}
+
+// ActivateAccountHandler manipula o comando de ativação de conta
+type ActivateAccountHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewActivateAccountHandler cria um novo manipulador de ativação de conta
+func NewActivateAccountHandler(uow persistence.UnitOfWork, publisher event.Publisher) *ActivateAccountHandler {
+	return &ActivateAccountHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de ativação de conta
+func (h *ActivateAccountHandler) Handle(cmd ActivateAccountCommand) error {
+	return updateAccount(h.uow, h.publisher, cmd.AccountID, func(acc *account.Account) error {
+		return acc.Activate()
+	})
+}
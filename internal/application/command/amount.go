@@ -0,0 +1,85 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// parseAmount converte o valor recebido no comando para Money, exigindo valor positivo.
+// Sem moeda, o valor é interpretado em account.DefaultCurrency. As regras de
+// interpretação do texto, inclusive a precisão de cada moeda, estão documentadas
+// em account.ParseMoney.
+func parseAmount(value json.Number, currency string) (account.Money, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return account.Money{}, err
+	}
+	amount, err := account.ParseMoney(value.String(), currency)
+	if err != nil {
+		return account.Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	if !amount.IsPositive() {
+		return account.Money{}, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// checkAmount valida o valor de um comando sobre uma conta antes de a conta ser
+// carregada. Com moeda, o valor é interpretado nela. Sem moeda, o valor será
+// interpretado na moeda da conta, cuja precisão ainda não é conhecida: só o
+// formato e o sinal são verificados, e o valor retornado não tem moeda.
+func checkAmount(value json.Number, currency string) (account.Money, error) {
+	if currency != "" {
+		return parseAmount(value, currency)
+	}
+	amount, err := account.ParseDecimal(value.String())
+	if err != nil {
+		return account.Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	if !amount.IsPositive() {
+		return account.Money{}, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// accountAmount converte o valor recebido no comando para Money na moeda da
+// conta, exigindo valor positivo. Sem moeda no comando, vale a moeda da conta;
+// com moeda, ela precisa ser a moeda da conta.
+func accountAmount(acc *account.Account, value json.Number, currency string) (account.Money, error) {
+	if currency == "" {
+		currency = acc.Currency()
+	}
+	amount, err := parseAmount(value, currency)
+	if err != nil {
+		return account.Money{}, err
+	}
+	if err := requireCurrency(acc, amount); err != nil {
+		return account.Money{}, err
+	}
+	return amount, nil
+}
+
+// normalizeCurrency padroniza o código de moeda informado no comando,
+// usando account.DefaultCurrency quando nenhum é informado
+func normalizeCurrency(currency string) (string, error) {
+	if currency == "" {
+		return account.DefaultCurrency, nil
+	}
+	currency = strings.ToUpper(currency)
+	if err := account.ValidateCurrency(currency); err != nil {
+		return "", err
+	}
+	return currency, nil
+}
+
+// requireCurrency recusa valores numa moeda diferente da moeda da conta
+func requireCurrency(acc *account.Account, amount account.Money) error {
+	if amount.Currency() != acc.Currency() {
+		return fmt.Errorf("%w: account %s is in %s, not %s",
+			account.ErrCurrencyMismatch, acc.ID, acc.Currency(), amount.Currency())
+	}
+	return nil
+}
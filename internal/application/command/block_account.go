@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// BlockAccountCommand representa o comando para bloquear uma conta
+type BlockAccountCommand struct {
+	AccountID string `json:"account_id"`
+	Reason    string `json:"reason"`
+}
+
+// BlockAccountHandler manipula o comando de bloqueio de conta
+type BlockAccountHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewBlockAccountHandler cria um novo manipulador de bloqueio de conta
+func NewBlockAccountHandler(uow persistence.UnitOfWork, publisher event.Publisher) *BlockAccountHandler {
+	return &BlockAccountHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de bloqueio de conta
+func (h *BlockAccountHandler) Handle(cmd BlockAccountCommand) error {
+	if cmd.Reason == "" {
+		return ErrReasonRequired
+	}
+
+	return updateAccount(h.uow, h.publisher, cmd.AccountID, func(acc *account.Account) error {
+		return acc.Block(cmd.Reason)
+	})
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// CaptureHoldCommand representa o comando para capturar uma reserva.
+// Sem Amount, o valor reservado é capturado integralmente.
+type CaptureHoldCommand struct {
+	AccountID string      `json:"account_id"`
+	HoldID    string      `json:"hold_id"`
+	Amount    json.Number `json:"amount"`
+	Currency  string      `json:"currency"` // Opcional; precisa ser a moeda da conta
+}
+
+// CaptureHoldHandler manipula o comando de captura de reserva
+type CaptureHoldHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewCaptureHoldHandler cria um novo manipulador de captura de reserva
+func NewCaptureHoldHandler(uow persistence.UnitOfWork, publisher event.Publisher) *CaptureHoldHandler {
+	return &CaptureHoldHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de captura de reserva
+func (h *CaptureHoldHandler) Handle(cmd CaptureHoldCommand) error {
+	// Validar valor da captura, se informado; sem moeda, ele é interpretado
+	// na moeda da conta
+	if cmd.Amount != "" {
+		if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
+			return err
+		}
+	}
+
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			// Buscar a conta
+			acc, err := tx.Accounts().FindByID(cmd.AccountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+
+			// Capturar a reserva, integralmente se o valor não foi informado
+			var captured account.Money
+			if cmd.Amount == "" {
+				hold, ok := acc.FindHold(cmd.HoldID)
+				if !ok {
+					return account.ErrHoldNotFound
+				}
+				captured = hold.Amount
+			} else if captured, err = accountAmount(acc, cmd.Amount, cmd.Currency); err != nil {
+				return err
+			}
+
+			// Verificar os limites de saque da conta sobre o uso recente
+			if err := checkWithdrawalLimits(tx, acc.ID, captured); err != nil {
+				return err
+			}
+			if err := acc.CaptureHold(cmd.HoldID, captured); err != nil {
+				return err
+			}
+			events = acc.Changes()
+
+			// Atualizar a conta
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Lançar a movimentação no razão na mesma transação
+			entry, err := ledger.NewHoldCaptureEntry(events[0].EventID(), cmd.HoldID, acc.ID, captured)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
+
+			// Contabilizar a captura no uso dos limites
+			if err := recordWithdrawalUsage(tx, acc.ID, events[0], captured); err != nil {
+				return err
+			}
+
+			// Salvar o evento no outbox na mesma transação da atualização
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	return nil
+}
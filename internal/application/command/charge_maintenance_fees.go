@@ -0,0 +1,137 @@
+package command
+
+import (
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// AccountFinder percorre as contas em lotes, em ordem de ID
+type AccountFinder interface {
+	FindAccounts(afterID string, limit int) ([]string, error)
+}
+
+// ChargeMaintenanceFeesCommand representa o comando para cobrar a tarifa de
+// manutenção do mês encerrado
+type ChargeMaintenanceFeesCommand struct {
+	BatchSize int
+}
+
+// ChargeMaintenanceFeesHandler cobra a tarifa de manutenção do mês anterior ao
+// atual, com o evento FeeCharged, das contas abertas antes do início desse mês.
+// Cada conta é tarifada no máximo uma vez por mês, então o comando pode ser
+// executado repetidamente; contas isentas no momento da execução são tarifadas
+// numa execução posterior, se a isenção vencer antes do fim do mês seguinte.
+type ChargeMaintenanceFeesHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+	accounts  AccountFinder
+	clock     Clock
+}
+
+// NewChargeMaintenanceFeesHandler cria um novo manipulador de tarifas de
+// manutenção. clock nil usa o relógio do sistema.
+func NewChargeMaintenanceFeesHandler(uow persistence.UnitOfWork, publisher event.Publisher, accounts AccountFinder, clock Clock) *ChargeMaintenanceFeesHandler {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &ChargeMaintenanceFeesHandler{
+		uow:       uow,
+		publisher: publisher,
+		accounts:  accounts,
+		clock:     clock,
+	}
+}
+
+// Handle tarifa todas as contas, em lotes, e retorna quantas foram tarifadas.
+// Cada conta é processada em sua própria transação, de modo que a falha em uma
+// conta não impede as demais.
+func (h *ChargeMaintenanceFeesHandler) Handle(cmd ChargeMaintenanceFeesCommand) (int, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	month := fee.PreviousMonth(h.clock())
+
+	charged := 0
+	afterID := ""
+	for {
+		accountIDs, err := h.accounts.FindAccounts(afterID, batchSize)
+		if err != nil {
+			return charged, err
+		}
+
+		for _, accountID := range accountIDs {
+			ok, err := h.charge(accountID, month)
+			if err != nil {
+				log.Printf("Erro ao cobrar a tarifa de manutenção da conta %s: %v", accountID, err)
+				continue
+			}
+			if ok {
+				charged++
+			}
+		}
+
+		if len(accountIDs) < batchSize {
+			return charged, nil
+		}
+		afterID = accountIDs[len(accountIDs)-1]
+	}
+}
+
+// charge cobra da conta a tarifa de manutenção do mês iniciado em month,
+// informando se ela foi cobrada
+func (h *ChargeMaintenanceFeesHandler) charge(accountID string, month time.Time) (bool, error) {
+	period := fee.Period(month)
+
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		events = nil
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			acc, err := tx.Accounts().FindByID(accountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+			// Contas encerradas e contas abertas durante o mês não são tarifadas
+			if acc.Status == account.StatusClosed || !acc.CreatedAt.Before(month) {
+				return nil
+			}
+
+			charged, err := tx.Fees().Charged(acc.ID, fee.KindMaintenance, period)
+			if err != nil || charged {
+				return err
+			}
+			amount, err := feeFor(tx, acc, fee.KindMaintenance)
+			if err != nil {
+				return err
+			}
+			if err := chargeFee(tx, acc, fee.KindMaintenance, amount, "", period); err != nil {
+				return err
+			}
+
+			events = acc.Changes()
+			if len(events) == 0 {
+				return nil
+			}
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	return len(events) > 0, nil
+}
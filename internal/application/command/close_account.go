@@ -0,0 +1,200 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// CloseAccountCommand representa o comando para encerrar uma conta.
+//
+// PayoutAccountID é opcional: se informado, o saldo restante é transferido para
+// essa conta antes do encerramento; sem ele, só contas com saldo zero são encerradas.
+type CloseAccountCommand struct {
+	AccountID       string `json:"account_id"`
+	PayoutAccountID string `json:"payout_account_id"`
+}
+
+// CloseAccountHandler manipula o comando de encerramento de conta. As contas
+// poupança recebem no encerramento os juros apurados e ainda não creditados,
+// às taxas de rates.
+type CloseAccountHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+	rates     interest.Rates
+	clock     Clock
+}
+
+// NewCloseAccountHandler cria um novo manipulador de encerramento de conta. Sem clock, usa time.Now.
+func NewCloseAccountHandler(uow persistence.UnitOfWork, publisher event.Publisher, rates interest.Rates, clock Clock) *CloseAccountHandler {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &CloseAccountHandler{
+		uow:       uow,
+		publisher: publisher,
+		rates:     rates,
+		clock:     clock,
+	}
+}
+
+// Handle executa o comando de encerramento de conta. A liquidação do saldo e o
+// encerramento são confirmados na mesma transação: a conta nunca fica encerrada
+// com saldo, nem o saldo é transferido sem que a conta seja encerrada.
+func (h *CloseAccountHandler) Handle(cmd CloseAccountCommand) error {
+	if cmd.PayoutAccountID == cmd.AccountID {
+		return ErrSameAccount
+	}
+
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			// Buscar a conta
+			acc, err := tx.Accounts().FindByID(cmd.AccountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+
+			// Reservas pendentes impedem o encerramento; sem esta verificação a
+			// liquidação falharia antes, por falta de saldo disponível. As
+			// reservas vencidas ainda não liberadas pelo worker são liberadas aqui.
+			now := h.clock()
+			acc.ExpireHolds(now)
+			if len(acc.Holds) > 0 {
+				return account.ErrAccountHasHolds
+			}
+
+			// Uma transferência pendente ainda pode ser devolvida à conta, e a
+			// devolução não pode chegar a uma conta encerrada. Uma transferência
+			// iniciada depois desta consulta altera a versão da conta, e o
+			// encerramento falha por conflito de concorrência.
+			pending, err := tx.Transfers().HasPendingFrom(acc.ID)
+			if err != nil {
+				return err
+			}
+			if pending {
+				return account.ErrAccountHasTransfers
+			}
+
+			// Uma conta encerrada não é mais apurada: os dias encerrados ainda não
+			// apurados e os juros ainda não creditados, inclusive os do mês
+			// corrente, entram no saldo antes da liquidação
+			if acc.Type == account.TypeSavings {
+				today := interest.Day(now)
+				if err := accrueInterest(tx, acc, h.rates.For(acc.Type), today, today); err != nil {
+					return err
+				}
+			}
+
+			// Transferir o saldo restante para a conta de destino
+			var settled []account.Event
+			if acc.Balance.IsPositive() && cmd.PayoutAccountID != "" {
+				settled, err = h.settle(tx, acc, cmd.PayoutAccountID)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Encerrar a conta
+			if err := acc.Close(); err != nil {
+				return err
+			}
+			events = append(acc.Changes(), settled...)
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Salvar os eventos no outbox na mesma transação do encerramento
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	return nil
+}
+
+// settle transfere todo o saldo da conta para a conta de destino, registrando
+// uma transferência já concluída e os lançamentos correspondentes no razão.
+// A liquidação não passa pelos limites de saque (veja limits.go).
+// O débito fica pendente na conta e é gravado junto com o encerramento; são
+// retornados apenas os eventos do crédito e da transferência.
+func (h *CloseAccountHandler) settle(tx persistence.Transaction, acc *account.Account, payoutAccountID string) ([]account.Event, error) {
+	payout, err := tx.Accounts().FindByID(payoutAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if payout == nil {
+		return nil, ErrPayoutAccountNotFound
+	}
+
+	// O saldo só pode ser transferido para uma conta na mesma moeda
+	amount := acc.Balance
+	if err := requireCurrency(payout, amount); err != nil {
+		return nil, fmt.Errorf("payout account: %w", err)
+	}
+	t, err := transfer.NewTransfer(acc.ID, payout.ID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Debitar a conta encerrada, mesmo que bloqueada, e creditar a conta de destino
+	if err := acc.SettleOut(amount, t.ID); err != nil {
+		return nil, err
+	}
+	changes := acc.Changes()
+	withdrawn := changes[len(changes)-1:]
+	t.DebitEventID = withdrawn[0].EventID()
+
+	if err := payout.TransferIn(amount, t.ID); err != nil {
+		return nil, fmt.Errorf("payout account: %w", err)
+	}
+	deposited := payout.Changes()
+	if err := tx.Accounts().Update(payout); err != nil {
+		return nil, err
+	}
+
+	if err := t.Complete(); err != nil {
+		return nil, err
+	}
+	if err := tx.Transfers().Save(t); err != nil {
+		return nil, err
+	}
+
+	// Lançar o débito e o crédito no razão na mesma transação
+	debit, err := ledger.NewTransferDebitEntry(withdrawn[0].EventID(), t.ID, acc.ID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Ledger().Append(debit); err != nil {
+		return nil, err
+	}
+	credit, err := ledger.NewTransferCreditEntry(deposited[0].EventID(), t.ID, payout.ID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Ledger().Append(credit); err != nil {
+		return nil, err
+	}
+
+	return append(deposited, transfer.TransferCompletedEvent{
+		BaseEvent:            newBaseEvent("TransferCompleted", acc.ID, t.ID),
+		SourceAccountID:      t.SourceAccountID,
+		DestinationAccountID: t.DestinationAccountID,
+		Amount:               t.Amount,
+		DestinationAmount:    t.DestinationAmount,
+	}), nil
+}
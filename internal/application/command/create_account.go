@@ -1,90 +1,79 @@
 package command
 
 import (
-	"log"
-	"time"
-
-	"github.com/google/uuid"
+	"strings"
 
 	"github.com/viniciuslima/account-EDA/internal/application/event"
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
+// CreateAccountCommand representa o comando para abrir uma conta
 type CreateAccountCommand struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Currency string `json:"currency"` // Código ISO 4217; padrão: account.DefaultCurrency
+	Type     string `json:"type"`     // checking ou savings; padrão: checking
 }
 
+// CreateAccountHandler manipula o comando de criação de conta
 type CreateAccountHandler struct {
-	repository account.Repository
-	publisher  event.Publisher
-	outboxRepo persistence.OutboxRepositoryInterface
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
 }
 
 // NewCreateAccountHandler cria um novo manipulador de criação de conta
-func NewCreateAccountHandler(repository account.Repository, publisher event.Publisher, outboxRepo persistence.OutboxRepositoryInterface) *CreateAccountHandler {
+func NewCreateAccountHandler(uow persistence.UnitOfWork, publisher event.Publisher) *CreateAccountHandler {
 	return &CreateAccountHandler{
-		repository: repository,
-		publisher:  publisher,
-		outboxRepo: outboxRepo,
+		uow:       uow,
+		publisher: publisher,
 	}
 }
 
 // Handle executa o comando de criação de conta
 func (h *CreateAccountHandler) Handle(cmd CreateAccountCommand) (string, error) {
-	// Verificar se já existe uma conta com este e-mail
-	existingAccount, err := h.repository.FindByEmail(cmd.Email)
-	if err == nil && existingAccount != nil {
-		return "", ErrEmailAlreadyExists
-	}
-
-	// Criar a nova conta
-	newAccount, err := account.NewAccount(cmd.Name, cmd.Email)
+	currency, err := normalizeCurrency(cmd.Currency)
 	if err != nil {
 		return "", err
 	}
-
-	// Persistir a nova conta
-	if err := h.repository.Save(newAccount); err != nil {
-		return "", err
+	accountType := account.TypeChecking
+	if cmd.Type != "" {
+		accountType = account.AccountType(strings.ToLower(cmd.Type))
 	}
 
-	// Publicar evento de conta criada
-	event := account.AccountCreatedEvent{
-		BaseEvent: account.BaseEvent{
-			ID:        uuid.New().String(),
-			AccountID: newAccount.ID,
-			EventType: "AccountCreated",
-			Timestamp: time.Now(),
-			AggrID:    newAccount.ID,
-		},
-		Name:  newAccount.Name,
-		Email: newAccount.Email,
-	}
+	var newAccount *account.Account
+	var events []account.Event
 
-	// Salvar no outbox primeiro (para garantir que o evento será enviado eventualmente)
-	if err := h.outboxRepo.Save(event.EventName(), event.AggregateID(), event); err != nil {
-		log.Printf("ERRO ao salvar evento no outbox: %v", err)
-		// Não falha a operação principal, mas registra o erro
-	}
+	// A conta e o evento no outbox são gravados na mesma transação:
+	// ou ambos são persistidos, ou nenhum é
+	err = h.uow.Do(func(tx persistence.Transaction) error {
+		// Verificar se já existe uma conta com este e-mail
+		existingAccount, err := tx.Accounts().FindByEmail(cmd.Email)
+		if err == nil && existingAccount != nil {
+			return ErrEmailAlreadyExists
+		}
 
-	// Tenta publicar diretamente (para entrega imediata quando possível)
-	if err := h.publisher.Publish(event); err != nil {
-		// Tenta publicar no DLQ
-		if dlqErr := h.publisher.PublishToDLQ(event, err.Error()); dlqErr != nil {
-			// Se falhar também no DLQ, registra o erro e continua
-			log.Printf("ERRO crítico: falha ao publicar evento no DLQ: %v", dlqErr)
-		} else {
-			log.Printf("Evento redirecionado para DLQ devido ao erro: %v", err)
+		// Criar a nova conta
+		newAccount, err = account.NewAccount(cmd.Name, cmd.Email, currency, accountType)
+		if err != nil {
+			return err
+		}
+		events = newAccount.Changes()
+
+		// Persistir a nova conta
+		if err := tx.Accounts().Save(newAccount); err != nil {
+			return err
 		}
 
-		// A operação principal continua, pois o evento será processado pelo sistema de outbox
+		// Salvar no outbox para garantir que o evento será enviado eventualmente
+		return recordEvents(tx, events...)
+	})
+	if err != nil {
+		return "", err
 	}
 
-	// Se a publicação foi bem-sucedida, podemos marcar o evento como publicado no outbox
-	// Isso seria feito em um job separado em um sistema real, mas incluímos aqui para demonstração
-	log.Printf("Evento publicado com sucesso: %s", event.EventName())
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
 
 	return newAccount.ID, nil
 }
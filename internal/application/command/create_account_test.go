@@ -16,7 +16,8 @@ func TestCreateAccountHandler_Handle_Success(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -30,17 +31,21 @@ func TestCreateAccountHandler_Handle_Success(t *testing.T) {
 	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Return(nil)
 
 	// Mock: salvar no outbox com sucesso
-	mockOutbox.On("Save", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountCreatedEvent")).Return(nil)
 
 	// Mock: publicar evento com sucesso
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountCreatedEvent")).Return(nil)
 
+	// Mock: marcar evento como publicado no outbox
+	mockOutbox.On("MarkAsPublished", mock.AnythingOfType("string")).Return(nil)
+
 	// Act
 	accountID, err := handler.Handle(cmd)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotEmpty(t, accountID)
+	assert.Equal(t, 0, uow.rollbacks)
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
@@ -53,7 +58,8 @@ func TestCreateAccountHandler_Handle_EmailAlreadyExists(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -86,7 +92,8 @@ func TestCreateAccountHandler_Handle_InvalidAccountData(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "", // Nome vazio
@@ -113,7 +120,8 @@ func TestCreateAccountHandler_Handle_RepositorySaveError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -143,7 +151,8 @@ func TestCreateAccountHandler_Handle_OutboxSaveError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -157,21 +166,21 @@ func TestCreateAccountHandler_Handle_OutboxSaveError(t *testing.T) {
 	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Return(nil)
 
 	// Mock: erro ao salvar no outbox
-	mockOutbox.On("Save", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.Anything).Return(errors.New("outbox error"))
-
-	// Mock: publicar evento com sucesso
-	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountCreatedEvent")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountCreatedEvent")).Return(errors.New("outbox error"))
 
 	// Act
 	accountID, err := handler.Handle(cmd)
 
-	// Assert
-	assert.NoError(t, err) // A operação principal não deve falhar
-	assert.NotEmpty(t, accountID)
+	// Assert: a conta não pode existir sem o evento, então a transação é desfeita
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox error")
+	assert.Empty(t, accountID)
+	assert.Equal(t, 1, uow.rollbacks)
+	assert.Equal(t, 0, uow.commits)
 
 	mockRepo.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
 	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
 }
 
 func TestCreateAccountHandler_Handle_PublisherError(t *testing.T) {
@@ -180,7 +189,8 @@ func TestCreateAccountHandler_Handle_PublisherError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, mockPublisher)
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -194,14 +204,11 @@ func TestCreateAccountHandler_Handle_PublisherError(t *testing.T) {
 	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Return(nil)
 
 	// Mock: salvar no outbox com sucesso
-	mockOutbox.On("Save", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountCreatedEvent")).Return(nil)
 
 	// Mock: erro ao publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountCreatedEvent")).Return(errors.New("publish error"))
 
-	// Mock: publicar no DLQ com sucesso
-	mockPublisher.On("PublishToDLQ", mock.AnythingOfType("account.AccountCreatedEvent"), "publish error").Return(nil)
-
 	// Act
 	accountID, err := handler.Handle(cmd)
 
@@ -212,15 +219,19 @@ func TestCreateAccountHandler_Handle_PublisherError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
 	mockOutbox.AssertExpectations(t)
+
+	// O evento continua pendente no outbox para ser entregue pelo OutboxProcessor
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
+	mockPublisher.AssertNotCalled(t, "PublishToDLQ", mock.Anything, mock.Anything)
 }
 
-func TestCreateAccountHandler_Handle_PublisherAndDLQError(t *testing.T) {
+func TestCreateAccountHandler_Handle_WithoutPublisher(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	mockPublisher := new(MockPublisher)
 	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewCreateAccountHandler(mockRepo, mockPublisher, mockOutbox)
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCreateAccountHandler(uow, nil) // Sem caminho rápido, apenas outbox
 
 	cmd := CreateAccountCommand{
 		Name:  "João Silva",
@@ -234,22 +245,17 @@ func TestCreateAccountHandler_Handle_PublisherAndDLQError(t *testing.T) {
 	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Return(nil)
 
 	// Mock: salvar no outbox com sucesso
-	mockOutbox.On("Save", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
-
-	// Mock: erro ao publicar evento
-	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountCreatedEvent")).Return(errors.New("publish error"))
-
-	// Mock: erro ao publicar no DLQ
-	mockPublisher.On("PublishToDLQ", mock.AnythingOfType("account.AccountCreatedEvent"), "publish error").Return(errors.New("dlq error"))
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountCreatedEvent")).Return(nil)
 
 	// Act
 	accountID, err := handler.Handle(cmd)
 
 	// Assert
-	assert.NoError(t, err) // A operação principal não deve falhar
+	assert.NoError(t, err)
 	assert.NotEmpty(t, accountID)
+	assert.Equal(t, 1, uow.commits)
 
 	mockRepo.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
 	mockOutbox.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
 }
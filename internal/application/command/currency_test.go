@@ -0,0 +1,249 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+)
+
+func TestCreateAccountHandler_Handle_WithCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewCreateAccountHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	var saved *account.Account
+	var created account.AccountCreatedEvent
+	mockRepo.On("FindByEmail", "taro@example.com").Return(nil, errors.New("not found"))
+	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*account.Account)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountCreatedEvent")).Run(func(args mock.Arguments) {
+		created = args.Get(0).(account.AccountCreatedEvent)
+	}).Return(nil)
+
+	// Act: o código é aceito em minúsculas
+	_, err := handler.Handle(CreateAccountCommand{Name: "Taro", Email: "taro@example.com", Currency: "jpy"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "JPY", saved.Currency())
+	assert.Equal(t, account.Zero("JPY"), saved.Balance)
+	assert.Equal(t, "JPY", created.Currency)
+}
+
+func TestCreateAccountHandler_Handle_DefaultCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewCreateAccountHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	var saved *account.Account
+	mockRepo.On("FindByEmail", "joao@example.com").Return(nil, errors.New("not found"))
+	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*account.Account)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	_, err := handler.Handle(CreateAccountCommand{Name: "João Silva", Email: "joao@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.DefaultCurrency, saved.Currency())
+}
+
+func TestCreateAccountHandler_Handle_UnsupportedCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewCreateAccountHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	// Act
+	_, err := handler.Handle(CreateAccountCommand{Name: "João Silva", Email: "joao@example.com", Currency: "XYZ"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrUnsupportedCurrency)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestDepositHandler_Handle_CurrencyPrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		amount   string
+		expected int64
+		valid    bool
+	}{
+		{"JPY sem casas decimais", "JPY", "1500", 1500, true},
+		{"JPY com casa decimal", "JPY", "1500.5", 0, false},
+		{"JPY com zeros decimais", "JPY", "1500.00", 1500, true},
+		{"BHD com três casas", "BHD", "1.234", 1234, true},
+		{"BHD com quatro casas", "BHD", "1.2345", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockOutbox := new(MockOutboxRepository)
+
+			handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+			existingAccount := newTestAccount("account-123", 0, inCurrency(tt.currency))
+			mockRepo.On("FindByID", "account-123").Return(existingAccount, nil).Maybe()
+			mockRepo.On("Update", existingAccount).Return(nil).Maybe()
+			mockOutbox.On("Save", mock.Anything).Return(nil).Maybe()
+
+			// Act
+			err := handler.Handle(DepositCommand{AccountID: "account-123", Amount: json.Number(tt.amount), Currency: tt.currency})
+
+			// Assert
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidAmount)
+				mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, account.NewMoney(tt.expected, tt.currency), existingAccount.Balance)
+		})
+	}
+}
+
+func TestDepositHandler_Handle_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	existingAccount := newTestAccount("account-123", 10000, inCurrency(account.DefaultCurrency))
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(DepositCommand{AccountID: "account-123", Amount: "50.00", Currency: "USD"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrCurrencyMismatch)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	existingAccount := newTestAccount("account-123", 10000, inCurrency("USD"))
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "50.00", Currency: "BRL"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrCurrencyMismatch)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestHandlers_OmittedCurrency_UsesAccountCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		handle   func(uow *MockUnitOfWork) error
+		expected int64
+	}{
+		{
+			name: "depósito",
+			handle: func(uow *MockUnitOfWork) error {
+				return NewDepositHandler(uow, nil).Handle(DepositCommand{AccountID: "account-123", Amount: "50.25"})
+			},
+			expected: 15025,
+		},
+		{
+			name: "saque",
+			handle: func(uow *MockUnitOfWork) error {
+				return NewWithdrawHandler(uow, nil).Handle(WithdrawCommand{AccountID: "account-123", Amount: "50.25"})
+			},
+			expected: 4975,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockOutbox := new(MockOutboxRepository)
+
+			existingAccount := newTestAccount("account-123", 10000, inCurrency("USD"))
+			mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+			mockRepo.On("Update", existingAccount).Return(nil)
+			mockOutbox.On("Save", mock.Anything).Return(nil)
+
+			// Act: sem moeda no comando, o valor é interpretado em USD
+			err := tt.handle(newMockUnitOfWork(mockRepo, mockOutbox))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, account.NewMoney(tt.expected, "USD"), existingAccount.Balance)
+		})
+	}
+}
+
+func TestWithdrawHandler_Handle_OmittedCurrencyPrecision(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), nil)
+
+	existingAccount := newTestAccount("account-123", 10000, inCurrency("JPY"))
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: a precisão aplicada é a da moeda da conta, que não tem casas decimais
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "50.5"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	assert.Equal(t, account.NewMoney(10000, "JPY"), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTransferHandler_Handle_NoExchangeRate(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000, inCurrency("USD"))
+	destination := newTestAccount("destination", 0, inCurrency("EUR"))
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+
+	// Act
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "50.00",
+		Currency:             "USD",
+	})
+
+	// Assert: sem taxa de câmbio para converter, a transferência é recusada
+	assert.ErrorIs(t, err, fx.ErrRateNotFound)
+	assert.Equal(t, account.NewMoney(10000, "USD"), source.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+}
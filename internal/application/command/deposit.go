@@ -1,76 +1,88 @@
 package command
 
 import (
-	"time"
-
-	"github.com/google/uuid"
+	"encoding/json"
 
 	"github.com/viniciuslima/account-EDA/internal/application/event"
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
 // DepositCommand representa o comando para depositar em uma conta
 type DepositCommand struct {
-	AccountID string  `json:"account_id"`
-	Amount    float64 `json:"amount"`
+	AccountID string      `json:"account_id"`
+	Amount    json.Number `json:"amount"`
+	Currency  string      `json:"currency"` // Opcional; precisa ser a moeda da conta
 }
 
 // DepositHandler manipula o comando de depósito
 type DepositHandler struct {
-	repository account.Repository
-	publisher  event.Publisher
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
 }
 
 // NewDepositHandler cria um novo manipulador de depósito
-func NewDepositHandler(repository account.Repository, publisher event.Publisher) *DepositHandler {
+func NewDepositHandler(uow persistence.UnitOfWork, publisher event.Publisher) *DepositHandler {
 	return &DepositHandler{
-		repository: repository,
-		publisher:  publisher,
+		uow:       uow,
+		publisher: publisher,
 	}
 }
 
 // Handle executa o comando de depósito
 func (h *DepositHandler) Handle(cmd DepositCommand) error {
-	// Validar valor do depósito
-	if cmd.Amount <= 0 {
-		return ErrInvalidAmount
-	}
-
-	// Buscar a conta
-	acc, err := h.repository.FindByID(cmd.AccountID)
-	if err != nil {
+	// Validar valor do depósito; sem moeda, ele é interpretado na moeda da conta
+	if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
 		return err
 	}
-	if acc == nil {
-		return ErrAccountNotFound
-	}
 
-	// Realizar o depósito
-	if err := acc.Deposit(cmd.Amount); err != nil {
-		return err
-	}
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			// Buscar a conta
+			acc, err := tx.Accounts().FindByID(cmd.AccountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+			amount, err := accountAmount(acc, cmd.Amount, cmd.Currency)
+			if err != nil {
+				return err
+			}
 
-	// Atualizar a conta
-	if err := h.repository.Update(acc); err != nil {
-		return err
-	}
+			// Realizar o depósito
+			if err := acc.Deposit(amount); err != nil {
+				return err
+			}
+			events = acc.Changes()
 
-	// Publicar evento de depósito
-	event := account.AccountDepositedEvent{
-		BaseEvent: account.BaseEvent{
-			ID:        uuid.New().String(),
-			AccountID: acc.ID,
-			EventType: "AccountDeposited",
-			Timestamp: time.Now(),
-			AggrID:    acc.ID,
-		},
-		Amount:         cmd.Amount,
-		CurrentBalance: acc.Balance,
-	}
+			// Atualizar a conta
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Lançar a movimentação no razão na mesma transação
+			entry, err := ledger.NewDepositEntry(events[0].EventID(), acc.ID, amount)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
 
-	if err := h.publisher.Publish(event); err != nil {
-		// Log o erro, mas não falha a operação
+			// Salvar o evento no outbox na mesma transação da atualização
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return err
 	}
 
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
 	return nil
 }
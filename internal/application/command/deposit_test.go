@@ -14,19 +14,20 @@ func TestDepositHandler_Handle_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 50.0,
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -36,30 +37,38 @@ func TestDepositHandler_Handle_Success(t *testing.T) {
 	// Mock: atualizar conta
 	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
 
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
+
 	// Mock: publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
 
+	// Mock: marcar evento como publicado no outbox
+	mockOutbox.On("MarkAsPublished", mock.AnythingOfType("string")).Return(nil)
+
 	// Act
 	err := handler.Handle(cmd)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 150.0, existingAccount.Balance) // 50 + 100
+	assert.Equal(t, account.NewMoney(15000, account.DefaultCurrency), existingAccount.Balance) // 50 + 100
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
 }
 
 func TestDepositHandler_Handle_InvalidAmount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    0, // Valor inválido
+		Amount:    "0", // Valor inválido
 	}
 
 	// Act
@@ -74,12 +83,13 @@ func TestDepositHandler_Handle_NegativeAmount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    -50.0, // Valor negativo
+		Amount:    "-50.00", // Valor negativo
 	}
 
 	// Act
@@ -90,16 +100,79 @@ func TestDepositHandler_Handle_NegativeAmount(t *testing.T) {
 	assert.Equal(t, ErrInvalidAmount, err)
 }
 
+func TestDepositHandler_Handle_ExcessPrecision(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Balance: account.Zero(account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	cmd := DepositCommand{
+		AccountID: "account-123",
+		Amount:    "10.005", // Mais casas decimais que a moeda da conta permite
+	}
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestDepositHandler_Handle_NoRoundingDrift(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
+
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Name:    "João Silva",
+		Email:   "joao@example.com",
+		Balance: account.Zero(account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
+	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
+
+	// Mock: marcar evento como publicado no outbox
+	mockOutbox.On("MarkAsPublished", mock.AnythingOfType("string")).Return(nil)
+
+	// Act: 0.10 + 0.20 em ponto flutuante resultaria em 0.30000000000000004
+	assert.NoError(t, handler.Handle(DepositCommand{AccountID: "account-123", Amount: "0.10"}))
+	assert.NoError(t, handler.Handle(DepositCommand{AccountID: "account-123", Amount: "0.20"}))
+
+	// Assert
+	assert.Equal(t, account.NewMoney(30, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, "0.30", existingAccount.Balance.String())
+}
+
 func TestDepositHandler_Handle_AccountNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "non-existent-account",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	// Mock: conta não encontrada
@@ -119,12 +192,13 @@ func TestDepositHandler_Handle_AccountNotFound_NilReturn(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "non-existent-account",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	// Mock: conta não encontrada (retorna nil)
@@ -144,12 +218,13 @@ func TestDepositHandler_Handle_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	// Mock: erro no repositório
@@ -169,19 +244,20 @@ func TestDepositHandler_Handle_UpdateError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 50.0,
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -205,19 +281,20 @@ func TestDepositHandler_Handle_PublisherError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 50.0,
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -227,6 +304,9 @@ func TestDepositHandler_Handle_PublisherError(t *testing.T) {
 	// Mock: atualizar conta
 	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
 
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
+
 	// Mock: erro ao publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountDepositedEvent")).Return(errors.New("publish error"))
 
@@ -234,30 +314,125 @@ func TestDepositHandler_Handle_PublisherError(t *testing.T) {
 	err := handler.Handle(cmd)
 
 	// Assert
-	assert.NoError(t, err)                          // A operação principal não deve falhar por erro de publicação
-	assert.Equal(t, 150.0, existingAccount.Balance) // 50 + 100
+	assert.NoError(t, err)                                                                     // A operação principal não deve falhar por erro de publicação
+	assert.Equal(t, account.NewMoney(15000, account.DefaultCurrency), existingAccount.Balance) // 50 + 100
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+
+	// O evento continua pendente no outbox para ser entregue pelo OutboxProcessor
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
+}
+
+func TestDepositHandler_Handle_OutboxSaveError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewDepositHandler(uow, mockPublisher)
+
+	cmd := DepositCommand{
+		AccountID: "account-123",
+		Amount:    "100.00",
+	}
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Name:    "João Silva",
+		Email:   "joao@example.com",
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+
+	// Mock: buscar conta
+	mockRepo.On("FindByID", cmd.AccountID).Return(existingAccount, nil)
+
+	// Mock: atualizar conta
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: erro ao salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Return(errors.New("outbox error"))
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert: sem o evento gravado, a atualização do saldo é desfeita
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox error")
+	assert.Equal(t, 1, uow.rollbacks)
+	assert.Equal(t, 0, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestDepositHandler_Handle_WithoutPublisher(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewDepositHandler(uow, nil) // Sem caminho rápido, apenas outbox
+
+	cmd := DepositCommand{
+		AccountID: "account-123",
+		Amount:    "100.00",
+	}
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Name:    "João Silva",
+		Email:   "joao@example.com",
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+
+	// Mock: buscar conta
+	mockRepo.On("FindByID", cmd.AccountID).Return(existingAccount, nil)
+
+	// Mock: atualizar conta
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: salvar evento no outbox com o saldo resultante
+	mockOutbox.On("Save", mock.MatchedBy(func(e account.AccountDepositedEvent) bool {
+		return e.AccountID == "account-123" && e.CurrentBalance == account.NewMoney(15000, account.DefaultCurrency)
+	})).Return(nil)
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(15000, account.DefaultCurrency), existingAccount.Balance) // 50 + 100
+	assert.Equal(t, 1, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
 }
 
 func TestDepositHandler_Handle_InactiveAccount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewDepositHandler(mockRepo, mockPublisher)
+	handler := NewDepositHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := DepositCommand{
 		AccountID: "account-123",
-		Amount:    100.0,
+		Amount:    "100.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 50.0,
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
 		Status:  account.StatusInactive, // Conta inativa
 	}
 
@@ -273,3 +448,51 @@ func TestDepositHandler_Handle_InactiveAccount(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestDepositHandler_Handle_VersionConflictRetry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewDepositHandler(uow, nil)
+
+	cmd := DepositCommand{
+		AccountID: "account-123",
+		Amount:    "100.00",
+	}
+
+	staleAccount := &account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+		Version: 1,
+	}
+	// Releitura: outro depósito de 25 foi confirmado entre as tentativas
+	currentAccount := &account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(7500, account.DefaultCurrency),
+		Status:  account.StatusActive,
+		Version: 2,
+	}
+
+	mockRepo.On("FindByID", cmd.AccountID).Return(staleAccount, nil).Once()
+	mockRepo.On("FindByID", cmd.AccountID).Return(currentAccount, nil).Once()
+	mockRepo.On("Update", staleAccount).Return(account.ErrVersionConflict).Once()
+	mockRepo.On("Update", currentAccount).Return(nil).Once()
+	mockOutbox.On("Save", mock.MatchedBy(func(e account.AccountDepositedEvent) bool {
+		return e.CurrentBalance == account.NewMoney(17500, account.DefaultCurrency)
+	})).Return(nil)
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert: nenhuma atualização concorrente é perdida
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(17500, account.DefaultCurrency), currentAccount.Balance) // 75 + 100
+	assert.Equal(t, 1, uow.rollbacks)
+	assert.Equal(t, 1, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
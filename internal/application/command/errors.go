@@ -4,8 +4,21 @@ import "errors"
 
 // Erros comuns para comandos
 var (
-	ErrAccountNotFound    = errors.New("account not found")
-	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrInsufficientFunds  = errors.New("insufficient funds")
-	ErrInvalidAmount      = errors.New("invalid amount")
+	ErrAccountNotFound       = errors.New("account not found")
+	ErrEmailAlreadyExists    = errors.New("email already exists")
+	ErrInsufficientFunds     = errors.New("insufficient funds")
+	ErrInvalidAmount         = errors.New("invalid amount")
+	ErrSameAccount           = errors.New("source and destination accounts must be different")
+	ErrTransferNotFound      = errors.New("transfer not found")
+	ErrConcurrentUpdate      = errors.New("account was modified concurrently, please retry")
+	ErrReasonRequired        = errors.New("reason is required")
+	ErrPayoutAccountNotFound = errors.New("payout account not found")
+	ErrQuoteNotFound         = errors.New("exchange quote not found")
+	ErrQuoteMismatch         = errors.New("transfer does not match the exchange quote")
+	ErrWaiverNotFound        = errors.New("fee waiver not found")
+	ErrInvalidExpiration     = errors.New("expiration must be in the future")
+	ErrScheduleNotFound      = errors.New("transfer schedule not found")
+	ErrInvalidDate           = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrAlreadyReversed       = errors.New("transaction already reversed")
 )
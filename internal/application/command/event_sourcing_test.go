@@ -0,0 +1,58 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+func TestAccountEvents_ReplayReproducesState(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	createHandler := NewCreateAccountHandler(uow, nil)
+	depositHandler := NewDepositHandler(uow, nil)
+	withdrawHandler := NewWithdrawHandler(uow, nil)
+
+	var saved *account.Account
+	var recorded []account.Event
+
+	mockRepo.On("FindByEmail", "joao@example.com").Return(nil, errors.New("not found"))
+	mockRepo.On("Save", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*account.Account)
+		saved.ClearChanges()
+		mockRepo.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Run(func(args mock.Arguments) {
+		args.Get(0).(*account.Account).ClearChanges()
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(account.Event))
+	}).Return(nil)
+
+	// Act
+	accountID, err := createHandler.Handle(CreateAccountCommand{Name: "João Silva", Email: "joao@example.com"})
+	assert.NoError(t, err)
+	assert.NoError(t, depositHandler.Handle(DepositCommand{AccountID: accountID, Amount: "100.00"}))
+	assert.NoError(t, withdrawHandler.Handle(WithdrawCommand{AccountID: accountID, Amount: "30.50"}))
+
+	replayed, err := account.Rehydrate(recorded)
+
+	// Assert: os eventos emitidos bastam para reconstruir a conta
+	assert.NoError(t, err)
+	assert.Len(t, recorded, 3)
+	assert.Equal(t, saved.ID, replayed.ID)
+	assert.Equal(t, saved.Name, replayed.Name)
+	assert.Equal(t, saved.Email, replayed.Email)
+	assert.Equal(t, saved.Status, replayed.Status)
+	assert.Equal(t, account.NewMoney(6950, account.DefaultCurrency), replayed.Balance) // 100 - 30,50
+	assert.Equal(t, saved.Balance, replayed.Balance)
+	assert.Equal(t, int64(3), replayed.Version)
+	assert.Empty(t, replayed.Changes())
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// newBaseEvent preenche os campos comuns de um novo evento de domínio
+func newBaseEvent(eventType, accountID, aggregateID string) account.BaseEvent {
+	return account.BaseEvent{
+		ID:        uuid.New().String(),
+		AccountID: accountID,
+		EventType: eventType,
+		Timestamp: time.Now(),
+		AggrID:    aggregateID,
+	}
+}
+
+// recordEvents grava os eventos no outbox da transação corrente. O outbox é o
+// registro oficial dos eventos: se a gravação falhar, a transação inteira é desfeita.
+func recordEvents(tx persistence.Transaction, events ...account.Event) error {
+	for _, evt := range events {
+		if err := tx.Outbox().Save(evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishCommitted tenta entregar imediatamente eventos já confirmados no outbox
+// (caminho rápido). O publisher é opcional; sem ele, ou em caso de falha, a entrega
+// fica a cargo do OutboxProcessor, por isso erros aqui apenas são registrados.
+func publishCommitted(uow persistence.UnitOfWork, publisher event.Publisher, events ...account.Event) {
+	if publisher == nil {
+		return
+	}
+
+	for _, evt := range events {
+		if err := publisher.Publish(evt); err != nil {
+			log.Printf("Publicação imediata do evento %s falhou, será entregue pelo outbox: %v", evt.EventID(), err)
+			continue
+		}
+
+		err := uow.Do(func(tx persistence.Transaction) error {
+			return tx.Outbox().MarkAsPublished(evt.EventID())
+		})
+		if err != nil {
+			log.Printf("Erro ao marcar evento %s como publicado: %v", evt.EventID(), err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// DefaultScheduleRetryInterval é o intervalo padrão entre as tentativas de uma
+// ocorrência agendada recusada por falta de saldo
+const DefaultScheduleRetryInterval = time.Hour
+
+// DueScheduleFinder localiza os agendamentos com ocorrências a executar
+type DueScheduleFinder interface {
+	FindDue(now time.Time, limit int) ([]*schedule.Schedule, error)
+}
+
+// ExecuteSchedulesCommand representa o comando para executar as transferências agendadas vencidas
+type ExecuteSchedulesCommand struct {
+	BatchSize int
+}
+
+// ExecuteSchedulesHandler executa as ocorrências vencidas dos agendamentos pelo
+// TransferHandler. O ID da transferência de cada ocorrência é derivado do
+// agendamento e da data, então uma ocorrência processada mais de uma vez (por
+// uma falha antes de avançar o agendamento ou por dois workers) gera uma única
+// transferência.
+type ExecuteSchedulesHandler struct {
+	uow           persistence.UnitOfWork
+	schedules     DueScheduleFinder
+	transfers     *TransferHandler
+	retryInterval time.Duration
+	clock         Clock
+}
+
+// NewExecuteSchedulesHandler cria um novo manipulador de transferências
+// agendadas. clock nil usa o relógio do sistema.
+func NewExecuteSchedulesHandler(uow persistence.UnitOfWork, schedules DueScheduleFinder, transfers *TransferHandler, retryInterval time.Duration, clock Clock) *ExecuteSchedulesHandler {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &ExecuteSchedulesHandler{
+		uow:           uow,
+		schedules:     schedules,
+		transfers:     transfers,
+		retryInterval: retryInterval,
+		clock:         clock,
+	}
+}
+
+// Handle executa um lote de ocorrências vencidas e retorna quantas geraram
+// transferência. Agendamentos com várias ocorrências atrasadas avançam uma
+// ocorrência por execução.
+func (h *ExecuteSchedulesHandler) Handle(cmd ExecuteSchedulesCommand) (int, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	now := h.clock()
+
+	due, err := h.schedules.FindDue(now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	executed := 0
+	for _, s := range due {
+		ok, err := h.execute(s, now)
+		if err != nil {
+			log.Printf("Erro ao executar o agendamento %s: %v", s.ID, err)
+			continue
+		}
+		if ok {
+			executed++
+		}
+	}
+	return executed, nil
+}
+
+// execute executa a ocorrência atual do agendamento e o avança conforme o
+// resultado, informando se a transferência foi feita. Erros que não são uma
+// recusa da transferência deixam o agendamento como está, para a próxima execução.
+func (h *ExecuteSchedulesHandler) execute(s *schedule.Schedule, now time.Time) (bool, error) {
+	if !s.Due(now) {
+		return false, nil
+	}
+
+	transferID, err := h.transfers.Handle(TransferCommand{
+		SourceAccountID:      s.SourceAccountID,
+		DestinationAccountID: s.DestinationAccountID,
+		Amount:               json.Number(s.Amount.String()),
+		Currency:             s.Amount.Currency(),
+		TransferID:           s.TransferID(),
+	})
+	executed := err == nil
+	switch {
+	case executed:
+		s.Executed(transferID, now)
+	case errors.Is(err, ErrInsufficientFunds) || errors.Is(err, limit.ErrLimitExceeded):
+		if skipped := s.InsufficientFunds(err.Error(), now, h.retryInterval); skipped {
+			log.Printf("Ocorrência do agendamento %s pulada: %v", s.ID, err)
+		}
+	case isScheduleRejection(err):
+		log.Printf("Ocorrência do agendamento %s pulada: %v", s.ID, err)
+		s.Skip(err.Error(), now)
+	default:
+		return false, err
+	}
+
+	err = h.uow.Do(func(tx persistence.Transaction) error {
+		return tx.Schedules().Update(s)
+	})
+	if err != nil && !errors.Is(err, schedule.ErrVersionConflict) {
+		return false, err
+	}
+	// Num conflito de versão, outro worker ou o cliente alterou o agendamento;
+	// a transferência, se feita, é reconhecida pelo ID na próxima execução
+	return executed, nil
+}
+
+// isScheduleRejection indica se a transferência foi recusada por uma condição
+// das contas que não se resolve com novas tentativas da mesma ocorrência
+func isScheduleRejection(err error) bool {
+	return errors.Is(err, ErrAccountNotFound) ||
+		errors.Is(err, account.ErrAccountNotActive) ||
+		errors.Is(err, account.ErrAccountClosed) ||
+		errors.Is(err, account.ErrCurrencyMismatch) ||
+		errors.Is(err, fx.ErrRateNotFound) ||
+		errors.Is(err, fx.ErrAmountTooSmall)
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// ExpiredHoldFinder localiza as contas com reservas vencidas
+type ExpiredHoldFinder interface {
+	FindAccountsWithExpiredHolds(now time.Time, limit int) ([]string, error)
+}
+
+// ExpireHoldsCommand representa o comando para liberar as reservas vencidas
+type ExpireHoldsCommand struct {
+	Now       time.Time
+	BatchSize int
+}
+
+// ExpireHoldsHandler libera automaticamente as reservas vencidas. As reservas
+// vencidas já deixam de reduzir o saldo disponível; a liberação registra o
+// evento HoldReleased e as remove da conta.
+type ExpireHoldsHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+	holds     ExpiredHoldFinder
+}
+
+// NewExpireHoldsHandler cria um novo manipulador de expiração de reservas
+func NewExpireHoldsHandler(uow persistence.UnitOfWork, publisher event.Publisher, holds ExpiredHoldFinder) *ExpireHoldsHandler {
+	return &ExpireHoldsHandler{
+		uow:       uow,
+		publisher: publisher,
+		holds:     holds,
+	}
+}
+
+// Handle libera as reservas vencidas de um lote de contas e retorna quantas
+// reservas foram liberadas. Cada conta é atualizada em sua própria transação,
+// de modo que a falha em uma conta não impede as demais.
+func (h *ExpireHoldsHandler) Handle(cmd ExpireHoldsCommand) (int, error) {
+	accountIDs, err := h.holds.FindAccountsWithExpiredHolds(cmd.Now, cmd.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, accountID := range accountIDs {
+		var count int
+		err := updateAccount(h.uow, h.publisher, accountID, func(acc *account.Account) error {
+			count = acc.ExpireHolds(cmd.Now)
+			return nil
+		})
+		if err != nil {
+			log.Printf("Erro ao expirar reservas da conta %s: %v", accountID, err)
+			continue
+		}
+		released += count
+	}
+
+	return released, nil
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// SetFeeWaiverCommand representa o comando para isentar uma conta de um tipo
+// de tarifa, ou de todas com o tipo "all"
+type SetFeeWaiverCommand struct {
+	AccountID string     `json:"account_id"`
+	Kind      string     `json:"kind"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"` // Opcional; sem vencimento, vale até ser removida
+}
+
+// SetFeeWaiverHandler manipula o comando de isenção de tarifa
+type SetFeeWaiverHandler struct {
+	uow persistence.UnitOfWork
+}
+
+// NewSetFeeWaiverHandler cria um novo manipulador de isenção de tarifa
+func NewSetFeeWaiverHandler(uow persistence.UnitOfWork) *SetFeeWaiverHandler {
+	return &SetFeeWaiverHandler{uow: uow}
+}
+
+// Handle grava a isenção, substituindo a do mesmo tipo, e a retorna
+func (h *SetFeeWaiverHandler) Handle(cmd SetFeeWaiverCommand) (*fee.Waiver, error) {
+	if err := fee.ValidateWaiverKind(cmd.Kind); err != nil {
+		return nil, err
+	}
+	if cmd.Reason == "" {
+		return nil, ErrReasonRequired
+	}
+	now := time.Now()
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiration
+	}
+
+	waiver := &fee.Waiver{
+		AccountID: cmd.AccountID,
+		Kind:      cmd.Kind,
+		Reason:    cmd.Reason,
+		ExpiresAt: cmd.ExpiresAt,
+		CreatedAt: now,
+	}
+	err := h.uow.Do(func(tx persistence.Transaction) error {
+		acc, err := tx.Accounts().FindByID(cmd.AccountID)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return ErrAccountNotFound
+		}
+		return tx.Fees().SaveWaiver(*waiver)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return waiver, nil
+}
+
+// RemoveFeeWaiverCommand representa o comando para remover uma isenção de tarifa
+type RemoveFeeWaiverCommand struct {
+	AccountID string `json:"account_id"`
+	Kind      string `json:"kind"`
+}
+
+// RemoveFeeWaiverHandler manipula o comando de remoção de isenção de tarifa
+type RemoveFeeWaiverHandler struct {
+	uow persistence.UnitOfWork
+}
+
+// NewRemoveFeeWaiverHandler cria um novo manipulador de remoção de isenção
+func NewRemoveFeeWaiverHandler(uow persistence.UnitOfWork) *RemoveFeeWaiverHandler {
+	return &RemoveFeeWaiverHandler{uow: uow}
+}
+
+// Handle remove a isenção; a conta volta a ser tarifada a partir da próxima operação
+func (h *RemoveFeeWaiverHandler) Handle(cmd RemoveFeeWaiverCommand) error {
+	return h.uow.Do(func(tx persistence.Transaction) error {
+		removed, err := tx.Fees().DeleteWaiver(cmd.AccountID, cmd.Kind)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return ErrWaiverNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// feeFor retorna a tarifa do tipo kind a cobrar da conta, considerando o tipo
+// e a moeda da conta e as isenções vigentes; zero quando não há tarifa
+func feeFor(tx persistence.Transaction, acc *account.Account, kind string) (account.Money, error) {
+	schedule, err := tx.Fees().FindSchedule(acc.ID, acc.Type, acc.Currency(), time.Now())
+	if err != nil {
+		return account.Money{}, err
+	}
+	if amount, ok := schedule.For(kind); ok {
+		return amount, nil
+	}
+	return account.Zero(acc.Currency()), nil
+}
+
+// chargeFee cobra a tarifa da conta, lança a cobrança no razão e a registra
+// em fee_charges, tudo na transação da operação tarifada. Tarifas zeradas
+// não são cobradas.
+func chargeFee(tx persistence.Transaction, acc *account.Account, kind string, amount account.Money, reference, period string) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	if err := acc.ChargeFee(amount, kind, reference, period); err != nil {
+		return err
+	}
+	changes := acc.Changes()
+	charged := changes[len(changes)-1]
+
+	entry, err := ledger.NewFeeEntry(charged.EventID(), acc.ID, amount)
+	if err != nil {
+		return err
+	}
+	if err := tx.Ledger().Append(entry); err != nil {
+		return err
+	}
+
+	return tx.Fees().Record(fee.Charge{
+		EventID:   charged.EventID(),
+		AccountID: acc.ID,
+		Kind:      kind,
+		Amount:    amount,
+		Reference: reference,
+		Period:    period,
+		ChargedAt: charged.OccurredAt(),
+	})
+}
+
+// reverseFee estorna a tarifa do tipo kind cobrada pela operação reference, se
+// houver, e lança no razão o estorno do lançamento da tarifa, na transação da
+// operação que desfaz a tarifada
+func reverseFee(tx persistence.Transaction, acc *account.Account, kind, reference, reason string) error {
+	charge, err := tx.Fees().FindCharge(acc.ID, kind, reference)
+	if err != nil {
+		return err
+	}
+	if charge == nil {
+		return nil
+	}
+	original, err := tx.Ledger().FindByEventID(charge.EventID)
+	if err != nil {
+		return err
+	}
+	if original == nil {
+		return fmt.Errorf("fee %s has no journal entry", charge.EventID)
+	}
+
+	if err := acc.ReverseTransaction(charge.EventID, "FeeCharged", charge.Amount, reason); err != nil {
+		return err
+	}
+	changes := acc.Changes()
+	entry, err := ledger.NewReversalEntry(changes[len(changes)-1].EventID(), original)
+	if err != nil {
+		return err
+	}
+	return tx.Ledger().Append(entry)
+}
+
+// requireFunds verifica se o saldo disponível da conta, fora das reservas,
+// cobre o valor da operação somado à tarifa
+func requireFunds(acc *account.Account, amount, charge account.Money) error {
+	total, err := amount.Add(charge)
+	if err != nil {
+		return err
+	}
+
+	if cmp, err := acc.AvailableBalance().Cmp(total); err != nil {
+		return err
+	} else if cmp < 0 {
+		return ErrInsufficientFunds
+	}
+	return nil
+}
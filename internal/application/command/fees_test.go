@@ -0,0 +1,295 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// testFeeSchedule tarifa saques em R$ 2,00, transferências em R$ 1,00 e a manutenção em R$ 12,90
+var testFeeSchedule = fee.Schedule{
+	fee.KindWithdrawal:  account.NewMoney(200, account.DefaultCurrency),
+	fee.KindTransfer:    account.NewMoney(100, account.DefaultCurrency),
+	fee.KindMaintenance: account.NewMoney(1290, account.DefaultCurrency),
+}
+
+// MockAccountFinder é um mock da consulta de contas em lotes
+type MockAccountFinder struct {
+	mock.Mock
+}
+
+func (m *MockAccountFinder) FindAccounts(afterID string, limit int) ([]string, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestWithdrawHandler_Handle_ChargesFee(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = recordingLedger(&entries)
+	uow.fees = newMockFeeRepository(testFeeSchedule)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 10000)
+
+	var saved []account.Event
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).(account.Event))
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "20.00"})
+
+	// Assert: o saque e a tarifa são confirmados juntos
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(7800, account.DefaultCurrency), existingAccount.Balance)
+	assert.Len(t, saved, 2)
+	charged := saved[1].(account.FeeChargedEvent)
+	assert.Equal(t, fee.KindWithdrawal, charged.Kind)
+	assert.Equal(t, saved[0].EventID(), charged.Reference)
+	assert.Equal(t, account.NewMoney(7800, account.DefaultCurrency), charged.CurrentBalance)
+
+	// A tarifa é lançada contra a receita de tarifas e registrada
+	assert.Equal(t, map[string]int64{
+		"account-123":           -2200,
+		ledger.CashOutAccount:   2000,
+		ledger.FeeIncomeAccount: 200,
+	}, ledgerBalances(entries))
+	uow.fees.AssertCalled(t, "Record", mock.MatchedBy(func(c fee.Charge) bool {
+		return c.EventID == charged.ID && c.Kind == fee.KindWithdrawal
+	}))
+}
+
+func TestWithdrawHandler_Handle_FeeRequiresFunds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.fees = newMockFeeRepository(testFeeSchedule)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 2000)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: o saldo cobre o saque, mas não a tarifa
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "20.00"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.Equal(t, account.NewMoney(2000, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_WaivedFee(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	// A isenção retira o saque da tabela de tarifas da conta
+	uow.fees = newMockFeeRepository(fee.Schedule{fee.KindTransfer: account.NewMoney(100, account.DefaultCurrency)})
+
+	existingAccount := newTestAccount("account-123", 2000)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "20.00"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, existingAccount.Balance.IsZero())
+	mockOutbox.AssertNumberOfCalls(t, "Save", 1)
+	uow.fees.AssertNotCalled(t, "Record", mock.Anything)
+}
+
+func TestTransferHandler_Handle_ChargesFeeToSource(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.ledger = recordingLedger(&entries)
+	uow.fees = newMockFeeRepository(testFeeSchedule)
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000)
+	destination := newTestAccount("destination", 0)
+
+	var charged account.FeeChargedEvent
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved := args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.FeeChargedEvent")).Run(func(args mock.Arguments) {
+		charged = args.Get(0).(account.FeeChargedEvent)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert: só a origem paga a tarifa; o destino recebe o valor integral
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(6900, account.DefaultCurrency), source.Balance)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), destination.Balance)
+	assert.Equal(t, fee.KindTransfer, charged.Kind)
+	assert.Equal(t, transferID, charged.Reference)
+	assert.Equal(t, map[string]int64{
+		"source":                         -3100,
+		"destination":                    3000,
+		ledger.TransfersInTransitAccount: 0,
+		ledger.FeeIncomeAccount:          100,
+	}, ledgerBalances(entries))
+}
+
+func TestChargeMaintenanceFeesHandler_Handle_ChargesPreviousMonth(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockAccountFinder)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.fees = newMockFeeRepository(testFeeSchedule)
+	handler := NewChargeMaintenanceFeesHandler(uow, nil, mockFinder,
+		fixedClock(time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)))
+
+	// Uma conta antiga, uma aberta durante setembro e uma encerrada
+	old := newTestAccount("old", 500)
+	old.CreatedAt = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	recent := newTestAccount("recent", 500)
+	recent.CreatedAt = time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+	closed := newTestAccount("closed", 0)
+	closed.CreatedAt = old.CreatedAt
+	closed.Status = account.StatusClosed
+
+	var charged account.FeeChargedEvent
+	mockFinder.On("FindAccounts", "", 100).Return([]string{"closed", "old", "recent"}, nil)
+	mockRepo.On("FindByID", "old").Return(old, nil)
+	mockRepo.On("FindByID", "recent").Return(recent, nil)
+	mockRepo.On("FindByID", "closed").Return(closed, nil)
+	mockRepo.On("Update", old).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.FeeChargedEvent")).Run(func(args mock.Arguments) {
+		charged = args.Get(0).(account.FeeChargedEvent)
+	}).Return(nil)
+
+	// Act
+	count, err := handler.Handle(ChargeMaintenanceFeesCommand{})
+
+	// Assert: a tarifa de setembro pode deixar a conta negativa
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "old", charged.AccountID)
+	assert.Equal(t, fee.KindMaintenance, charged.Kind)
+	assert.Equal(t, "2026-09", charged.Period)
+	assert.Equal(t, account.NewMoney(-790, account.DefaultCurrency), old.Balance)
+	mockRepo.AssertNotCalled(t, "Update", recent)
+	mockRepo.AssertNotCalled(t, "Update", closed)
+}
+
+func TestChargeMaintenanceFeesHandler_Handle_AlreadyCharged(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockAccountFinder)
+	mockFees := new(MockFeeRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.fees = mockFees
+	handler := NewChargeMaintenanceFeesHandler(uow, nil, mockFinder,
+		fixedClock(time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)))
+
+	existingAccount := newTestAccount("account-123", 5000)
+	mockFinder.On("FindAccounts", "", 100).Return([]string{"account-123"}, nil)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Mock: setembro já foi tarifado numa execução anterior
+	mockFees.On("Charged", "account-123", fee.KindMaintenance, "2026-09").Return(true, nil)
+
+	// Act
+	count, err := handler.Handle(ChargeMaintenanceFeesCommand{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance)
+	mockFees.AssertNotCalled(t, "FindSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSetFeeWaiverHandler_Handle(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		cmd      SetFeeWaiverCommand
+		expected error
+	}{
+		{"isenção de todas as tarifas", SetFeeWaiverCommand{Kind: fee.KindAll, Reason: "cliente private"}, nil},
+		{"isenção com vencimento", SetFeeWaiverCommand{Kind: fee.KindMaintenance, Reason: "campanha", ExpiresAt: &future}, nil},
+		{"tipo desconhecido", SetFeeWaiverCommand{Kind: "deposit", Reason: "campanha"}, fee.ErrUnknownKind},
+		{"sem motivo", SetFeeWaiverCommand{Kind: fee.KindWithdrawal}, ErrReasonRequired},
+		{"vencimento no passado", SetFeeWaiverCommand{Kind: fee.KindWithdrawal, Reason: "campanha", ExpiresAt: &past}, ErrInvalidExpiration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockFees := new(MockFeeRepository)
+
+			uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+			uow.fees = mockFees
+			handler := NewSetFeeWaiverHandler(uow)
+
+			mockRepo.On("FindByID", "account-123").Return(newTestAccount("account-123", 0), nil).Maybe()
+			mockFees.On("SaveWaiver", mock.AnythingOfType("fee.Waiver")).Return(nil).Maybe()
+
+			// Act
+			tt.cmd.AccountID = "account-123"
+			waiver, err := handler.Handle(tt.cmd)
+
+			// Assert
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				mockFees.AssertNotCalled(t, "SaveWaiver", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cmd.Kind, waiver.Kind)
+			mockFees.AssertCalled(t, "SaveWaiver", *waiver)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// DefaultQuoteTTL é o prazo de validade padrão das cotações de câmbio
+const DefaultQuoteTTL = time.Minute
+
+// FXRateProvider fornece as taxas de câmbio usadas nas conversões. Deve
+// retornar fx.ErrRateNotFound quando não houver taxa para o par de moedas.
+type FXRateProvider interface {
+	Rate(from, to string) (fx.Rate, error)
+}
+
+// CreateQuoteCommand representa o comando para cotar a conversão de um valor
+type CreateQuoteCommand struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`    // Moeda do valor; opcional
+	To       string      `json:"to_currency"` // Moeda para a qual o valor será convertido
+}
+
+// CreateQuoteHandler manipula o comando de cotação. A cotação fixa a taxa e o
+// valor convertido até vencer, para que o cliente confirme a transferência
+// sabendo quanto será creditado no destino.
+type CreateQuoteHandler struct {
+	uow   persistence.UnitOfWork
+	rates FXRateProvider
+	ttl   time.Duration
+}
+
+// NewCreateQuoteHandler cria um novo manipulador de cotação. Um ttl não
+// positivo usa DefaultQuoteTTL.
+func NewCreateQuoteHandler(uow persistence.UnitOfWork, rates FXRateProvider, ttl time.Duration) *CreateQuoteHandler {
+	if ttl <= 0 {
+		ttl = DefaultQuoteTTL
+	}
+	return &CreateQuoteHandler{
+		uow:   uow,
+		rates: rates,
+		ttl:   ttl,
+	}
+}
+
+// Handle executa o comando de cotação e retorna a cotação criada
+func (h *CreateQuoteHandler) Handle(cmd CreateQuoteCommand) (*fx.Quote, error) {
+	amount, err := parseAmount(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+	to, err := normalizeCurrency(cmd.To)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := lookupRate(h.rates, amount.Currency(), to)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := fx.NewQuote(rate, amount, h.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.uow.Do(func(tx persistence.Transaction) error {
+		return tx.Quotes().Save(quote)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// lookupRate busca a taxa de câmbio no provedor; sem provedor configurado,
+// nenhuma conversão está disponível
+func lookupRate(rates FXRateProvider, from, to string) (fx.Rate, error) {
+	if from == to {
+		return fx.Rate{}, fx.ErrSameCurrency
+	}
+	if rates == nil {
+		return fx.Rate{}, fx.ErrRateNotFound
+	}
+	return rates.Rate(from, to)
+}
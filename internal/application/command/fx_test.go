@@ -0,0 +1,261 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// staticRates é um provedor de taxas fixas, indexadas por "ORIGEM/DESTINO"
+type staticRates map[string]string
+
+func (r staticRates) Rate(from, to string) (fx.Rate, error) {
+	value, ok := r[from+"/"+to]
+	if !ok {
+		return fx.Rate{}, fx.ErrRateNotFound
+	}
+	return fx.ParseRate(from, to, value)
+}
+
+// newTestQuote cria uma cotação de USD para BRL a 5.0125, válida por ttl
+func newTestQuote(t *testing.T, amount int64, ttl time.Duration) *fx.Quote {
+	rate, err := fx.ParseRate("USD", "BRL", "5.0125")
+	assert.NoError(t, err)
+	quote, err := fx.NewQuote(rate, account.NewMoney(amount, "USD"), ttl)
+	assert.NoError(t, err)
+	return quote
+}
+
+func TestCreateQuoteHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockQuotes := new(MockQuoteRepository)
+	uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+	uow.quotes = mockQuotes
+	handler := NewCreateQuoteHandler(uow, staticRates{"USD/BRL": "5.0125"}, 30*time.Second)
+
+	mockQuotes.On("Save", mock.AnythingOfType("*fx.Quote")).Return(nil)
+
+	// Act
+	quote, err := handler.Handle(CreateQuoteCommand{Amount: "100.00", Currency: "usd", To: "brl"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(10000, "USD"), quote.SourceAmount)
+	assert.Equal(t, account.NewMoney(50125, "BRL"), quote.DestinationAmount) // 100 × 5.0125
+	assert.Equal(t, "5.0125", quote.Rate.String())
+	assert.Equal(t, 30*time.Second, quote.ExpiresAt.Sub(quote.CreatedAt))
+	mockQuotes.AssertExpectations(t)
+}
+
+func TestCreateQuoteHandler_Handle_Rounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		rate     string
+		amount   json.Number
+		want     account.Money
+	}{
+		{"arredonda para baixo", "USD", "JPY", "149.5", "10.05", account.NewMoney(1502, "JPY")},     // 1502.475
+		{"arredonda para cima", "USD", "JPY", "149.55", "10.05", account.NewMoney(1503, "JPY")},     // 1502.9775
+		{"metade para cima", "BRL", "USD", "0.19", "0.50", account.NewMoney(10, "USD")},             // 0.095
+		{"moeda com três casas", "USD", "BHD", "0.376", "10.00", account.NewMoney(3760, "BHD")},     // 3.760
+		{"moeda sem casas decimais", "JPY", "USD", "0.00667", "1000", account.NewMoney(667, "USD")}, // 6.67
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockQuotes := new(MockQuoteRepository)
+			uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+			uow.quotes = mockQuotes
+			handler := NewCreateQuoteHandler(uow, staticRates{tt.from + "/" + tt.to: tt.rate}, 0)
+
+			mockQuotes.On("Save", mock.AnythingOfType("*fx.Quote")).Return(nil)
+
+			// Act
+			quote, err := handler.Handle(CreateQuoteCommand{Amount: tt.amount, Currency: tt.from, To: tt.to})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, quote.DestinationAmount)
+		})
+	}
+}
+
+func TestCreateQuoteHandler_Handle_RateNotFound(t *testing.T) {
+	// Arrange
+	mockQuotes := new(MockQuoteRepository)
+	uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+	uow.quotes = mockQuotes
+	handler := NewCreateQuoteHandler(uow, staticRates{}, 0)
+
+	// Act
+	_, err := handler.Handle(CreateQuoteCommand{Amount: "100.00", Currency: "USD", To: "EUR"})
+
+	// Assert
+	assert.ErrorIs(t, err, fx.ErrRateNotFound)
+	mockQuotes.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTransferHandler_Handle_WithQuote(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+	mockQuotes := new(MockQuoteRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.quotes = mockQuotes
+	uow.ledger = recordingLedger(&entries)
+	// O provedor tem outra taxa: vale a taxa fixada na cotação
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), staticRates{"USD/BRL": "4.90"})
+
+	source := newTestAccount("source", 20000, inCurrency("USD"))
+	destination := newTestAccount("destination", 0, inCurrency("BRL"))
+	quote := newTestQuote(t, 10000, time.Minute)
+
+	var saved *transfer.Transfer
+	var completed transfer.TransferCompletedEvent
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockQuotes.On("FindByID", quote.ID).Return(quote, nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+		mockQuotes.On("MarkUsed", quote.ID, saved.ID).Return(nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("transfer.TransferCompletedEvent")).Run(func(args mock.Arguments) {
+		completed = args.Get(0).(transfer.TransferCompletedEvent)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act: o valor vem da cotação
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		QuoteID:              quote.ID,
+	})
+
+	// Assert: a origem é debitada em USD e o destino creditado em BRL pela taxa cotada
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.StatusCompleted, saved.Status)
+	assert.Equal(t, account.NewMoney(10000, "USD"), source.Balance)
+	assert.Equal(t, account.NewMoney(50125, "BRL"), destination.Balance)
+	assert.Equal(t, "5.0125", saved.ExchangeRate)
+	assert.Equal(t, quote.ID, saved.QuoteID)
+
+	// O evento registra a taxa e os dois valores
+	assert.Equal(t, account.NewMoney(10000, "USD"), completed.Amount)
+	assert.Equal(t, account.NewMoney(50125, "BRL"), completed.DestinationAmount)
+	assert.Equal(t, "5.0125", completed.ExchangeRate)
+	assert.Equal(t, quote.ID, completed.QuoteID)
+
+	// O crédito passa pela conta de câmbio, que recebe USD e entrega BRL
+	assert.Len(t, entries, 2)
+	assert.Len(t, entries[1].Postings, 4)
+	assert.Contains(t, entries[1].Postings, ledger.Credit(ledger.FXConversionAccount, account.NewMoney(10000, "USD")))
+	assert.Contains(t, entries[1].Postings, ledger.Debit(ledger.FXConversionAccount, account.NewMoney(50125, "BRL")))
+	mockQuotes.AssertExpectations(t)
+}
+
+func TestTransferHandler_Handle_ProviderRate(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), staticRates{"USD/EUR": "0.92"})
+
+	source := newTestAccount("source", 10000, inCurrency("USD"))
+	destination := newTestAccount("destination", 0, inCurrency("EUR"))
+
+	var saved *transfer.Transfer
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	uow.outbox.On("Save", mock.Anything).Return(nil)
+
+	// Act: sem cotação, vale a taxa atual do provedor
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "50.00",
+		Currency:             "USD",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(5000, "USD"), source.Balance)
+	assert.Equal(t, account.NewMoney(4600, "EUR"), destination.Balance) // 50 × 0.92
+	assert.Equal(t, "0.92", saved.ExchangeRate)
+	assert.Empty(t, saved.QuoteID)
+}
+
+func TestTransferHandler_Handle_RejectsUnusableQuote(t *testing.T) {
+	expired := newTestQuote(t, 10000, time.Minute)
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	used := newTestQuote(t, 10000, time.Minute)
+	used.TransferID = "transfer-0"
+
+	tests := []struct {
+		name    string
+		quote   *fx.Quote
+		amount  json.Number
+		wantErr error
+	}{
+		{"cotação vencida", expired, "", fx.ErrQuoteExpired},
+		{"cotação já usada", used, "", fx.ErrQuoteAlreadyUsed},
+		{"valor diferente do cotado", newTestQuote(t, 10000, time.Minute), "90.00", ErrQuoteMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockTransfers := new(MockTransferRepository)
+			mockQuotes := new(MockQuoteRepository)
+
+			uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+			uow.transfers = mockTransfers
+			uow.quotes = mockQuotes
+			handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+			source := newTestAccount("source", 20000, inCurrency("USD"))
+			mockRepo.On("FindByID", "source").Return(source, nil)
+			mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0, inCurrency("BRL")), nil)
+			mockQuotes.On("FindByID", tt.quote.ID).Return(tt.quote, nil)
+
+			// Act
+			_, err := handler.Handle(TransferCommand{
+				SourceAccountID:      "source",
+				DestinationAccountID: "destination",
+				Amount:               tt.amount,
+				QuoteID:              tt.quote.ID,
+			})
+
+			// Assert: nada é debitado
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, account.NewMoney(20000, "USD"), source.Balance)
+			mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+			mockQuotes.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+		})
+	}
+}
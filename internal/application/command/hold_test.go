@@ -0,0 +1,320 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// newHeldTestAccount cria uma conta ativa com uma reserva válida por uma hora
+func newHeldTestAccount(id string, balance, held int64) (*account.Account, account.Hold) {
+	acc := newTestAccount(id, balance)
+	hold := account.Hold{
+		ID:        "hold-1",
+		Amount:    account.NewMoney(held, account.DefaultCurrency),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+	acc.Holds = []account.Hold{hold}
+	return acc, hold
+}
+
+func TestPlaceHoldHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewPlaceHoldHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 10000)
+
+	var saved account.HoldPlacedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.HoldPlacedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.HoldPlacedEvent)
+	}).Return(nil)
+
+	// Act
+	holdID, err := handler.Handle(PlaceHoldCommand{AccountID: "account-123", Amount: "40.00"})
+
+	// Assert: o saldo contábil não muda, apenas o disponível
+	assert.NoError(t, err)
+	assert.Equal(t, holdID, saved.HoldID)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, account.NewMoney(6000, account.DefaultCurrency), existingAccount.AvailableBalance())
+	assert.Equal(t, account.NewMoney(6000, account.DefaultCurrency), saved.AvailableBalance)
+	assert.WithinDuration(t, time.Now().Add(DefaultHoldTTL), saved.ExpiresAt, time.Minute)
+}
+
+func TestPlaceHoldHandler_Handle_InsufficientAvailableBalance(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewPlaceHoldHandler(uow, nil)
+
+	existingAccount, _ := newHeldTestAccount("account-123", 10000, 8000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	_, err := handler.Handle(PlaceHoldCommand{AccountID: "account-123", Amount: "30.00"})
+
+	// Assert: o saldo já reservado não pode ser reservado de novo
+	assert.Equal(t, ErrInsufficientFunds, err)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_RespectsAvailableBalance(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount, _ := newHeldTestAccount("account-123", 10000, 8000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "30.00"})
+
+	// Assert
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCaptureHoldHandler_Handle_PartialCapture(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = recordingLedger(&entries)
+	handler := NewCaptureHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 10000, 5000)
+
+	var saved account.HoldCapturedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.HoldCapturedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.HoldCapturedEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(CaptureHoldCommand{AccountID: "account-123", HoldID: hold.ID, Amount: "35.00"})
+
+	// Assert: debita o capturado e libera o restante da reserva
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(6500, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, existingAccount.Balance, existingAccount.AvailableBalance())
+	assert.Empty(t, existingAccount.Holds)
+	assert.Equal(t, account.NewMoney(1500, account.DefaultCurrency), saved.ReleasedAmount)
+	assert.Equal(t, map[string]int64{
+		"account-123":                -3500,
+		ledger.CardSettlementAccount: 3500,
+	}, ledgerBalances(entries))
+}
+
+func TestCaptureHoldHandler_Handle_FullCaptureByDefault(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCaptureHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 10000, 5000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.HoldCapturedEvent")).Return(nil)
+
+	// Act
+	err := handler.Handle(CaptureHoldCommand{AccountID: "account-123", HoldID: hold.ID})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance)
+}
+
+func TestCaptureHoldHandler_Handle_ExceedsHold(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCaptureHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 10000, 5000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(CaptureHoldCommand{AccountID: "account-123", HoldID: hold.ID, Amount: "50.01"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrCaptureExceedsHold)
+	assert.Len(t, existingAccount.Holds, 1)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCaptureHoldHandler_Handle_ExpiredHold(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCaptureHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 10000, 5000)
+	existingAccount.Holds[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(CaptureHoldCommand{AccountID: "account-123", HoldID: hold.ID})
+
+	// Assert: reservas vencidas não são capturadas e não reduzem o disponível
+	assert.ErrorIs(t, err, account.ErrHoldExpired)
+	assert.Equal(t, existingAccount.Balance, existingAccount.AvailableBalance())
+}
+
+func TestReleaseHoldHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewReleaseHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 10000, 5000)
+
+	var saved account.HoldReleasedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.HoldReleasedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.HoldReleasedEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(ReleaseHoldCommand{AccountID: "account-123", HoldID: hold.ID})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, existingAccount.Holds)
+	assert.Equal(t, account.HoldReleasedManually, saved.Reason)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), existingAccount.AvailableBalance())
+}
+
+// MockExpiredHoldFinder é um mock da consulta de reservas vencidas
+type MockExpiredHoldFinder struct {
+	mock.Mock
+}
+
+func (m *MockExpiredHoldFinder) FindAccountsWithExpiredHolds(now time.Time, limit int) ([]string, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestExpireHoldsHandler_Handle_ReleasesExpiredHolds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockExpiredHoldFinder)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewExpireHoldsHandler(uow, nil, mockFinder)
+
+	now := time.Now()
+	existingAccount, _ := newHeldTestAccount("account-123", 10000, 5000)
+	existingAccount.Holds[0].ExpiresAt = now.Add(-time.Minute)
+	existingAccount.Holds = append(existingAccount.Holds, account.Hold{
+		ID:        "hold-2",
+		Amount:    account.NewMoney(1000, account.DefaultCurrency),
+		ExpiresAt: now.Add(time.Hour),
+	})
+
+	var saved account.HoldReleasedEvent
+	mockFinder.On("FindAccountsWithExpiredHolds", now, 100).Return([]string{"account-123"}, nil)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.HoldReleasedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.HoldReleasedEvent)
+	}).Return(nil)
+
+	// Act
+	released, err := handler.Handle(ExpireHoldsCommand{Now: now, BatchSize: 100})
+
+	// Assert: apenas a reserva vencida é liberada
+	assert.NoError(t, err)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, "hold-1", saved.HoldID)
+	assert.Equal(t, account.HoldReleasedExpired, saved.Reason)
+	assert.Len(t, existingAccount.Holds, 1)
+	assert.Equal(t, "hold-2", existingAccount.Holds[0].ID)
+}
+
+func TestCloseAccountHandler_Handle_PendingHolds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	existingAccount, _ := newHeldTestAccount("account-123", 5000, 5000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrAccountHasHolds)
+	assert.Equal(t, account.StatusActive, existingAccount.Status)
+}
+
+func TestCloseAccountHandler_Handle_ReleasesExpiredHolds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	// A reserva venceu, mas o worker ainda não a liberou
+	existingAccount, _ := newHeldTestAccount("account-123", 0, 5000)
+	existingAccount.Holds[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	var recorded []string
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(account.Event).EventName())
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "account-123"})
+
+	// Assert: a reserva vencida é liberada junto com o encerramento
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, existingAccount.Status)
+	assert.Empty(t, existingAccount.Holds)
+	assert.Equal(t, []string{"HoldReleased", "AccountClosed"}, recorded)
+}
@@ -0,0 +1,307 @@
+package command
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// MockSavingsAccountFinder é um mock da consulta de contas poupança
+type MockSavingsAccountFinder struct {
+	mock.Mock
+}
+
+func (m *MockSavingsAccountFinder) FindSavingsAccounts(afterID string, limit int) ([]string, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// memoryAccruals guarda as apurações em memória, com as mesmas regras do
+// repositório: um registro por dia e crédito marcado por período
+type memoryAccruals struct {
+	accruals map[time.Time]interest.Accrual
+	posted   map[time.Time]string
+}
+
+func newMemoryAccruals() *memoryAccruals {
+	return &memoryAccruals{accruals: make(map[time.Time]interest.Accrual), posted: make(map[time.Time]string)}
+}
+
+func (m *memoryAccruals) LastAccrualDay(accountID string) (time.Time, error) {
+	var last time.Time
+	for day := range m.accruals {
+		if day.After(last) {
+			last = day
+		}
+	}
+	return last, nil
+}
+
+func (m *memoryAccruals) Record(a interest.Accrual) error {
+	if _, ok := m.accruals[a.Day]; !ok {
+		m.accruals[a.Day] = a
+	}
+	return nil
+}
+
+func (m *memoryAccruals) Unposted(accountID string, before time.Time) ([]interest.Accrual, error) {
+	var result []interest.Accrual
+	for day, a := range m.accruals {
+		if _, posted := m.posted[day]; !posted && day.Before(before) {
+			result = append(result, a)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Day.Before(result[j].Day) })
+	return result, nil
+}
+
+func (m *memoryAccruals) MarkPosted(accountID string, before time.Time, eventID string) error {
+	for day := range m.accruals {
+		if _, posted := m.posted[day]; !posted && day.Before(before) {
+			m.posted[day] = eventID
+		}
+	}
+	return nil
+}
+
+// fixedClock retorna sempre o mesmo instante
+func fixedClock(now time.Time) Clock {
+	return func() time.Time { return now }
+}
+
+// savingsTestRates remunera as contas poupança a 3,65% ao ano (0,01% ao dia)
+var savingsTestRates = interest.Rates{account.TypeSavings: 36_500}
+
+// newSavingsTestAccount cria uma conta poupança aberta em createdAt
+func newSavingsTestAccount(balance int64, createdAt time.Time) *account.Account {
+	acc := newTestAccount("savings-1", balance)
+	acc.Type = account.TypeSavings
+	acc.CreatedAt = createdAt
+	return acc
+}
+
+// newInterestTestUnitOfWork monta a unidade de trabalho com o saldo do razão informado
+func newInterestTestUnitOfWork(mockRepo *MockRepository, mockOutbox *MockOutboxRepository, entries *[]*ledger.JournalEntry, balance int64) *MockUnitOfWork {
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.accruals = newMemoryAccruals()
+	uow.ledger = recordingLedger(entries)
+	uow.ledger.On("BalanceAt", "savings-1", account.DefaultCurrency, mock.Anything).Return(account.NewMoney(balance, account.DefaultCurrency), nil)
+	return uow
+}
+
+func TestAccrueInterestHandler_Handle_CreditsClosedMonth(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockSavingsAccountFinder)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, 100000)
+	handler := NewAccrueInterestHandler(uow, nil, mockFinder, savingsTestRates,
+		fixedClock(time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)))
+
+	existingAccount := newSavingsTestAccount(100000, time.Date(2026, 9, 28, 10, 0, 0, 0, time.UTC))
+
+	var credited account.InterestCreditedEvent
+	mockFinder.On("FindSavingsAccounts", "", 100).Return([]string{"savings-1"}, nil)
+	mockRepo.On("FindByID", "savings-1").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.InterestCreditedEvent")).Run(func(args mock.Arguments) {
+		credited = args.Get(0).(account.InterestCreditedEvent)
+	}).Return(nil)
+
+	// Act
+	count, err := handler.Handle(AccrueInterestCommand{})
+
+	// Assert: 28, 29 e 30 de setembro apurados a R$ 0,10 por dia e creditados juntos
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	accruals := uow.accruals.(*memoryAccruals).accruals
+	assert.Len(t, accruals, 3)
+	assert.Equal(t, int64(10*interest.MicroUnits), accruals[time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)].Interest)
+	assert.Equal(t, "2026-09", credited.Period)
+	assert.Equal(t, account.NewMoney(30, account.DefaultCurrency), credited.Amount)
+	assert.Equal(t, account.NewMoney(100030, account.DefaultCurrency), existingAccount.Balance)
+
+	// O crédito é lançado contra a despesa de juros
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]int64{
+		"savings-1":                   30,
+		ledger.InterestExpenseAccount: -30,
+	}, ledgerBalances(entries))
+}
+
+func TestAccrueInterestHandler_Handle_AccruesWithoutCreditingOpenMonth(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockSavingsAccountFinder)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, 100000)
+	handler := NewAccrueInterestHandler(uow, nil, mockFinder, savingsTestRates,
+		fixedClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)))
+
+	existingAccount := newSavingsTestAccount(100000, time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	mockFinder.On("FindSavingsAccounts", "", 100).Return([]string{"savings-1"}, nil)
+	mockRepo.On("FindByID", "savings-1").Return(existingAccount, nil)
+
+	// Act: executado duas vezes no mesmo dia
+	_, err := handler.Handle(AccrueInterestCommand{})
+	assert.NoError(t, err)
+	count, err := handler.Handle(AccrueInterestCommand{})
+
+	// Assert: os dias 1 a 14 são apurados uma única vez; outubro ainda não é creditado
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Len(t, uow.accruals.(*memoryAccruals).accruals, 14)
+	assert.Empty(t, entries)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAccrueInterestHandler_Handle_RoundsMonthlyTotal(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockSavingsAccountFinder)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, 12345)
+	rates := interest.Rates{account.TypeSavings: 50_000} // 5% ao ano
+	handler := NewAccrueInterestHandler(uow, nil, mockFinder, rates,
+		fixedClock(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+
+	existingAccount := newSavingsTestAccount(12345, time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC))
+
+	var credited account.InterestCreditedEvent
+	mockFinder.On("FindSavingsAccounts", "", 100).Return([]string{"savings-1"}, nil)
+	mockRepo.On("FindByID", "savings-1").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.InterestCreditedEvent")).Run(func(args mock.Arguments) {
+		credited = args.Get(0).(account.InterestCreditedEvent)
+	}).Return(nil)
+
+	// Act
+	_, err := handler.Handle(AccrueInterestCommand{})
+
+	// Assert: R$ 123,45 a 5% rendem 1,691 centavo por dia; as frações são somadas
+	// nos 30 dias (50,7 centavos) e só o total é arredondado, em vez de 2 centavos por dia
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(51, account.DefaultCurrency), credited.Amount)
+}
+
+func TestAccrueInterestHandler_Handle_NegativeBalanceEarnsNothing(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFinder := new(MockSavingsAccountFinder)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, -5000)
+	handler := NewAccrueInterestHandler(uow, nil, mockFinder, savingsTestRates,
+		fixedClock(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)))
+
+	existingAccount := newSavingsTestAccount(-5000, time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC))
+	mockFinder.On("FindSavingsAccounts", "", 100).Return([]string{"savings-1"}, nil)
+	mockRepo.On("FindByID", "savings-1").Return(existingAccount, nil)
+
+	// Act
+	count, err := handler.Handle(AccrueInterestCommand{})
+
+	// Assert: setembro é encerrado sem crédito
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	memory := uow.accruals.(*memoryAccruals)
+	assert.Len(t, memory.accruals, 2)
+	assert.Equal(t, "", memory.posted[time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)])
+	assert.Contains(t, memory.posted, time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCloseAccountHandler_Handle_CreditsPendingInterest(t *testing.T) {
+	// Arrange: o worker não creditou setembro nem apurou outubro
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, 100000)
+	uow.transfers = mockTransfers
+	handler := NewCloseAccountHandler(uow, nil, savingsTestRates,
+		fixedClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)))
+
+	closing := newSavingsTestAccount(100000, time.Date(2026, 9, 28, 10, 0, 0, 0, time.UTC))
+	payout := newTestAccount("payout", 0)
+
+	var recorded []string
+	var credited []account.InterestCreditedEvent
+	mockRepo.On("FindByID", "savings-1").Return(closing, nil)
+	mockRepo.On("FindByID", "payout").Return(payout, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("HasPendingFrom", "savings-1").Return(false, nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		event := args.Get(0).(account.Event)
+		recorded = append(recorded, event.EventName())
+		if e, ok := event.(account.InterestCreditedEvent); ok {
+			credited = append(credited, e)
+		}
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(CloseAccountCommand{AccountID: "savings-1", PayoutAccountID: "payout"})
+
+	// Assert: setembro (3 dias) e outubro até a véspera (14 dias) são creditados
+	// a R$ 0,10 por dia antes da liquidação, e o saldo com os juros vai para o destino
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, closing.Status)
+	assert.True(t, closing.Balance.IsZero())
+	assert.Equal(t, account.NewMoney(100170, account.DefaultCurrency), payout.Balance)
+	assert.Equal(t, []string{"InterestCredited", "InterestCredited", "AccountWithdrawn", "AccountClosed", "AccountDeposited", "TransferCompleted"}, recorded)
+	if assert.Len(t, credited, 2) {
+		assert.Equal(t, "2026-09", credited[0].Period)
+		assert.Equal(t, account.NewMoney(30, account.DefaultCurrency), credited[0].Amount)
+		assert.Equal(t, "2026-10", credited[1].Period)
+		assert.Equal(t, account.NewMoney(140, account.DefaultCurrency), credited[1].Amount)
+	}
+
+	// Nenhuma apuração fica sem crédito
+	memory := uow.accruals.(*memoryAccruals)
+	assert.Len(t, memory.accruals, 17)
+	assert.Len(t, memory.posted, 17)
+	assert.Equal(t, int64(-170), ledgerBalances(entries)[ledger.InterestExpenseAccount])
+	assert.Equal(t, 1, uow.commits)
+}
+
+func TestCloseAccountHandler_Handle_PendingInterestKeepsBalance(t *testing.T) {
+	// Arrange: o saldo foi sacado no dia do encerramento, depois de dois dias rendendo
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newInterestTestUnitOfWork(mockRepo, mockOutbox, &entries, 100000)
+	handler := NewCloseAccountHandler(uow, nil, savingsTestRates,
+		fixedClock(time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)))
+
+	closing := newSavingsTestAccount(0, time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC))
+	mockRepo.On("FindByID", "savings-1").Return(closing, nil)
+
+	// Act: sem conta de destino
+	err := handler.Handle(CloseAccountCommand{AccountID: "savings-1"})
+
+	// Assert: os juros creditados deixam saldo, e o encerramento exige uma conta de destino
+	assert.ErrorIs(t, err, account.ErrAccountHasBalance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
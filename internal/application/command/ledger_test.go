@@ -0,0 +1,184 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// recordingLedger cria um mock do razão que guarda os lançamentos recebidos
+func recordingLedger(entries *[]*ledger.JournalEntry) *MockLedgerRepository {
+	mockLedger := new(MockLedgerRepository)
+	mockLedger.On("Append", mock.AnythingOfType("*ledger.JournalEntry")).Run(func(args mock.Arguments) {
+		*entries = append(*entries, args.Get(0).(*ledger.JournalEntry))
+	}).Return(nil)
+	return mockLedger
+}
+
+// ledgerBalances soma créditos menos débitos por conta, em centavos
+func ledgerBalances(entries []*ledger.JournalEntry) map[string]int64 {
+	balances := make(map[string]int64)
+	for _, entry := range entries {
+		for _, p := range entry.Postings {
+			if p.Direction == ledger.DirectionCredit {
+				balances[p.AccountID] += p.Amount.MinorUnits()
+			} else {
+				balances[p.AccountID] -= p.Amount.MinorUnits()
+			}
+		}
+	}
+	return balances
+}
+
+func TestDepositHandler_Handle_PostsLedgerEntry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = recordingLedger(&entries)
+	handler := NewDepositHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+
+	var saved account.AccountDepositedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.AccountDepositedEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(DepositCommand{AccountID: "account-123", Amount: "100.00"})
+
+	// Assert: o lançamento referencia o evento e credita a conta
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, saved.ID, entries[0].EventID)
+	assert.Equal(t, map[string]int64{
+		"account-123":        10000,
+		ledger.CashInAccount: -10000,
+	}, ledgerBalances(entries))
+}
+
+func TestWithdrawHandler_Handle_LedgerAppendErrorRollsBack(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockLedger := new(MockLedgerRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = mockLedger
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+
+	// Mock: erro ao gravar no razão
+	mockLedger.On("Append", mock.AnythingOfType("*ledger.JournalEntry")).Return(errors.New("ledger error"))
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "20.00"})
+
+	// Assert: sem o lançamento, a movimentação não é confirmada
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ledger error")
+	assert.Equal(t, 1, uow.rollbacks)
+	assert.Equal(t, 0, uow.commits)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTransferHandler_Handle_LedgerMatchesBalances(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.ledger = recordingLedger(&entries)
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000)
+	destination := newTestAccount("destination", 2000)
+
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved := args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert: débito e crédito lançados, conta de trânsito zerada
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, map[string]int64{
+		"source":                         -3000,
+		"destination":                    3000,
+		ledger.TransfersInTransitAccount: 0,
+	}, ledgerBalances(entries))
+}
+
+func TestProcessTransferHandler_Handle_CompensationPostsRefundEntry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.ledger = recordingLedger(&entries)
+	handler := NewProcessTransferHandler(uow, nil)
+
+	source := newTestAccount("source", 7000)
+	destination := newTestAccount("destination", 0)
+	destination.Status = account.StatusBlocked
+
+	pending := &transfer.Transfer{
+		ID:                   "transfer-1",
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               account.NewMoney(3000, account.DefaultCurrency),
+		DestinationAmount:    account.NewMoney(3000, account.DefaultCurrency),
+		Status:               transfer.StatusPending,
+	}
+
+	mockTransfers.On("FindByID", "transfer-1").Return(pending, nil)
+	mockTransfers.On("Update", pending).Return(nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("Update", source).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	err := handler.Handle(ProcessTransferCommand{TransferID: "transfer-1"})
+
+	// Assert: o estorno sai da conta de trânsito e volta para a origem
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]int64{
+		"source":                         3000,
+		ledger.TransfersInTransitAccount: -3000,
+	}, ledgerBalances(entries))
+}
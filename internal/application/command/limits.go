@@ -0,0 +1,45 @@
+package command
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// Os limites de saque valem para todo valor que sai da conta por iniciativa do
+// titular: saques, transferências enviadas e capturas de reservas. Cada um
+// desses comandos chama checkWithdrawalLimits antes do débito e
+// recordWithdrawalUsage depois dele, na mesma transação. Um débito desfeito,
+// pelo estorno do saque ou pela devolução da transferência, é retirado do uso
+// com releaseWithdrawalUsage.
+//
+// A liquidação do saldo no encerramento da conta não passa pelos limites: ela
+// precisa transferir todo o saldo para que a conta seja encerrada, e a conta
+// de destino continua sujeita aos próprios limites.
+
+// checkWithdrawalLimits verifica se o débito cabe nos limites da conta,
+// retornando um *limit.ExceededError se algum deles for ultrapassado
+func checkWithdrawalLimits(tx persistence.Transaction, accountID string, amount account.Money) error {
+	policy, err := tx.Limits().FindPolicy(accountID, amount.Currency())
+	if err != nil {
+		return err
+	}
+	usage, err := tx.Limits().Usage(accountID, amount.Currency(), time.Now())
+	if err != nil {
+		return err
+	}
+	return policy.Check(amount, usage)
+}
+
+// recordWithdrawalUsage contabiliza o débito no uso dos limites da conta,
+// identificado pelo evento que o registrou
+func recordWithdrawalUsage(tx persistence.Transaction, accountID string, debited account.Event, amount account.Money) error {
+	return tx.Limits().Record(accountID, debited.EventID(), amount, debited.OccurredAt())
+}
+
+// releaseWithdrawalUsage retira do uso dos limites da conta o débito desfeito,
+// identificado pelo evento que o registrou
+func releaseWithdrawalUsage(tx persistence.Transaction, accountID, debitedEventID string) error {
+	return tx.Limits().Release(accountID, debitedEventID)
+}
@@ -0,0 +1,259 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+)
+
+func TestWithdrawHandler_Handle_RecordsLimitUsage(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	limitRepo := new(MockLimitRepository)
+	policy, _ := limit.ForTier(limit.TierStandard, account.DefaultCurrency)
+	limitRepo.On("FindPolicy", "account-123", account.DefaultCurrency).Return(policy, nil)
+	limitRepo.On("Usage", "account-123", account.DefaultCurrency, mock.Anything).Return(usedLimits(0, 0), nil)
+	uow.limits = limitRepo
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 10000)
+
+	var saved account.AccountWithdrawnEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.AccountWithdrawnEvent)
+	}).Return(nil)
+	limitRepo.On("Record", "account-123", mock.Anything, account.NewMoney(5000, account.DefaultCurrency), mock.Anything).Return(nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "50.00"})
+
+	// Assert: o saque entra no uso, identificado pelo evento
+	assert.NoError(t, err)
+	limitRepo.AssertCalled(t, "Record", "account-123", saved.ID, account.NewMoney(5000, account.DefaultCurrency), saved.Timestamp)
+}
+
+func TestWithdrawHandler_Handle_PerTransactionLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 1_000_000_00)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: o nível padrão permite até 5.000,00 por saque
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "5000.01"})
+
+	// Assert
+	assert.ErrorIs(t, err, limit.ErrLimitExceeded)
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.PerTransaction, exceeded.Limit)
+	assert.Equal(t, account.NewMoney(5_000_00, account.DefaultCurrency), *exceeded.Remaining.PerTransaction)
+	assert.Equal(t, account.NewMoney(1_000_000_00, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	uow.limits.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_DailyLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.limits = newMockLimitRepository(usedLimits(9_000_00, 9_000_00))
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 1_000_000_00)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: restam 1.000,00 do limite diário de 10.000,00
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "1500.00"})
+
+	// Assert
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.Daily, exceeded.Limit)
+	assert.Equal(t, account.NewMoney(1_000_00, account.DefaultCurrency), *exceeded.Remaining.Daily)
+	assert.Equal(t, account.NewMoney(41_000_00, account.DefaultCurrency), *exceeded.Remaining.Monthly)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_MonthlyLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.limits = newMockLimitRepository(usedLimits(0, 49_500_00))
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 1_000_000_00)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "600.00"})
+
+	// Assert
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.Monthly, exceeded.Limit)
+	assert.Equal(t, account.NewMoney(500_00, account.DefaultCurrency), *exceeded.Remaining.Monthly)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTransferHandler_Handle_DailyLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.limits = newMockLimitRepository(usedLimits(9_000_00, 9_000_00))
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 1_000_000_00)
+	destination := newTestAccount("destination", 0)
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+
+	// Act: transferir para outra conta consome o mesmo limite diário do saque
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "1500.00",
+	})
+
+	// Assert
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.Daily, exceeded.Limit)
+	assert.Equal(t, account.NewMoney(1_000_000_00, account.DefaultCurrency), source.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTransferHandler_Handle_RecordsLimitUsage(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000)
+	destination := newTestAccount("destination", 0)
+
+	var withdrawn account.AccountWithdrawnEvent
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockTransfers.On("FindByID", mock.Anything).Return(nil, errors.New("unavailable"))
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Run(func(args mock.Arguments) {
+		withdrawn = args.Get(0).(account.AccountWithdrawnEvent)
+	}).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	_, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert: o débito da origem entra no uso, identificado pelo evento
+	assert.NoError(t, err)
+	uow.limits.AssertCalled(t, "Record", "source", withdrawn.ID, account.NewMoney(3000, account.DefaultCurrency), withdrawn.Timestamp)
+}
+
+func TestCaptureHoldHandler_Handle_PerTransactionLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewCaptureHoldHandler(uow, nil)
+
+	existingAccount, hold := newHeldTestAccount("account-123", 1_000_000_00, 6_000_00)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: a captura integral ultrapassa os 5.000,00 por transação
+	err := handler.Handle(CaptureHoldCommand{AccountID: "account-123", HoldID: hold.ID})
+
+	// Assert: a reserva continua pendente
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.PerTransaction, exceeded.Limit)
+	assert.Len(t, existingAccount.Holds, 1)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCloseAccountHandler_Handle_PayoutIgnoresLimits(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.limits = newMockLimitRepository(usedLimits(10_000_00, 50_000_00))
+	handler := NewCloseAccountHandler(uow, nil, nil, nil)
+
+	closing := newTestAccount("closing", 80_000_00)
+	payout := newTestAccount("payout", 0)
+
+	mockRepo.On("FindByID", "closing").Return(closing, nil)
+	mockRepo.On("FindByID", "payout").Return(payout, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("HasPendingFrom", "closing").Return(false, nil)
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act: o saldo supera todos os limites, que já estão esgotados
+	err := handler.Handle(CloseAccountCommand{AccountID: "closing", PayoutAccountID: "payout"})
+
+	// Assert: a liquidação é feita e não entra no uso dos limites
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusClosed, closing.Status)
+	assert.Equal(t, account.NewMoney(80_000_00, account.DefaultCurrency), payout.Balance)
+	uow.limits.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_LimitsInAccountCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 1_000_000_00, inCurrency("USD"))
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act: no nível padrão, contas em USD podem sacar até 1.000,00 por vez
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "1000.01", Currency: "USD"})
+
+	// Assert
+	var exceeded *limit.ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, limit.PerTransaction, exceeded.Limit)
+	assert.Equal(t, account.NewMoney(1_000_00, "USD"), *exceeded.Remaining.PerTransaction)
+	assert.Equal(t, account.NewMoney(1_000_000_00, "USD"), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
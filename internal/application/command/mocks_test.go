@@ -1,9 +1,18 @@
 package command
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
@@ -33,12 +42,12 @@ func (m *MockRepository) FindByEmail(email string) (*account.Account, error) {
 	return args.Get(0).(*account.Account), args.Error(1)
 }
 
-func (m *MockRepository) FindAll() ([]*account.Account, error) {
-	args := m.Called()
+func (m *MockRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	args := m.Called(criteria)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*account.Account), args.Error(1)
+	return args.Get(0).(*account.Page), args.Error(1)
 }
 
 func (m *MockRepository) Update(acc *account.Account) error {
@@ -46,11 +55,6 @@ func (m *MockRepository) Update(acc *account.Account) error {
 	return args.Error(0)
 }
 
-func (m *MockRepository) Delete(id string) error {
-	args := m.Called(id)
-	return args.Error(0)
-}
-
 // MockPublisher é um mock do publisher de eventos
 type MockPublisher struct {
 	mock.Mock
@@ -71,8 +75,8 @@ type MockOutboxRepository struct {
 	mock.Mock
 }
 
-func (m *MockOutboxRepository) Save(eventName, aggregateID string, event interface{}) error {
-	args := m.Called(eventName, aggregateID, event)
+func (m *MockOutboxRepository) Save(event account.Event) error {
+	args := m.Called(event)
 	return args.Error(0)
 }
 
@@ -93,3 +97,344 @@ func (m *MockOutboxRepository) MarkAsFailed(id string, err error) error {
 	args := m.Called(id, err)
 	return args.Error(0)
 }
+
+// MockTransferRepository é um mock do repositório de transferências
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferRepository) Save(t *transfer.Transfer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTransferRepository) FindByID(id string) (*transfer.Transfer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transfer.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) Update(t *transfer.Transfer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTransferRepository) HasPendingFrom(accountID string) (bool, error) {
+	args := m.Called(accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockLedgerRepository é um mock do razão contábil
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) Append(entry *ledger.JournalEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) FindByEventID(eventID string) (*ledger.JournalEntry, error) {
+	args := m.Called(eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.JournalEntry), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Reversed(eventID string) (bool, error) {
+	args := m.Called(eventID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Balance(accountID, currency string) (account.Money, error) {
+	args := m.Called(accountID, currency)
+	return args.Get(0).(account.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) BalanceAt(accountID, currency string, at time.Time) (account.Money, error) {
+	args := m.Called(accountID, currency, at)
+	return args.Get(0).(account.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Reconcile() ([]ledger.Discrepancy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ledger.Discrepancy), args.Error(1)
+}
+
+// MockLimitRepository é um mock dos limites de saque
+type MockLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockLimitRepository) FindPolicy(accountID, currency string) (limit.Policy, error) {
+	args := m.Called(accountID, currency)
+	return args.Get(0).(limit.Policy), args.Error(1)
+}
+
+func (m *MockLimitRepository) Usage(accountID, currency string, now time.Time) (limit.Usage, error) {
+	args := m.Called(accountID, currency, now)
+	return args.Get(0).(limit.Usage), args.Error(1)
+}
+
+func (m *MockLimitRepository) Record(accountID, eventID string, amount account.Money, at time.Time) error {
+	args := m.Called(accountID, eventID, amount, at)
+	return args.Error(0)
+}
+
+func (m *MockLimitRepository) Release(accountID, eventID string) error {
+	args := m.Called(accountID, eventID)
+	return args.Error(0)
+}
+
+// MockQuoteRepository é um mock das cotações de câmbio
+type MockQuoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuoteRepository) Save(quote *fx.Quote) error {
+	args := m.Called(quote)
+	return args.Error(0)
+}
+
+func (m *MockQuoteRepository) FindByID(id string) (*fx.Quote, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fx.Quote), args.Error(1)
+}
+
+func (m *MockQuoteRepository) MarkUsed(id, transferID string) error {
+	args := m.Called(id, transferID)
+	return args.Error(0)
+}
+
+// MockFeeRepository é um mock das tarifas e isenções
+type MockFeeRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeeRepository) FindSchedule(accountID string, accountType account.AccountType, currency string, at time.Time) (fee.Schedule, error) {
+	args := m.Called(accountID, accountType, currency, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(fee.Schedule), args.Error(1)
+}
+
+func (m *MockFeeRepository) SaveWaiver(w fee.Waiver) error {
+	args := m.Called(w)
+	return args.Error(0)
+}
+
+func (m *MockFeeRepository) DeleteWaiver(accountID, kind string) (bool, error) {
+	args := m.Called(accountID, kind)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFeeRepository) Record(c fee.Charge) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func (m *MockFeeRepository) Charged(accountID, kind, period string) (bool, error) {
+	args := m.Called(accountID, kind, period)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFeeRepository) FindCharge(accountID, kind, reference string) (*fee.Charge, error) {
+	args := m.Called(accountID, kind, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Charge), args.Error(1)
+}
+
+// newMockFeeRepository cria tarifas com a tabela informada, sem cobranças anteriores
+func newMockFeeRepository(schedule fee.Schedule) *MockFeeRepository {
+	feeRepo := new(MockFeeRepository)
+	feeRepo.On("FindSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(schedule, nil).Maybe()
+	feeRepo.On("Record", mock.Anything).Return(nil).Maybe()
+	feeRepo.On("Charged", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	feeRepo.On("FindCharge", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return feeRepo
+}
+
+// MockScheduleRepository é um mock das transferências agendadas
+type MockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduleRepository) Save(s *schedule.Schedule) error {
+	args := m.Called(s)
+	return args.Error(0)
+}
+
+func (m *MockScheduleRepository) FindByID(id string) (*schedule.Schedule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schedule.Schedule), args.Error(1)
+}
+
+func (m *MockScheduleRepository) FindByAccount(accountID string) ([]*schedule.Schedule, error) {
+	args := m.Called(accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*schedule.Schedule), args.Error(1)
+}
+
+func (m *MockScheduleRepository) FindDue(now time.Time, limit int) ([]*schedule.Schedule, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*schedule.Schedule), args.Error(1)
+}
+
+func (m *MockScheduleRepository) Update(s *schedule.Schedule) error {
+	args := m.Called(s)
+	return args.Error(0)
+}
+
+// MockUnitOfWork executa o bloco transacional diretamente sobre os repositórios mockados,
+// contabilizando quantas vezes a transação foi confirmada ou desfeita
+type MockUnitOfWork struct {
+	accounts  *MockRepository
+	outbox    *MockOutboxRepository
+	transfers *MockTransferRepository
+	ledger    *MockLedgerRepository
+	limits    *MockLimitRepository
+	quotes    *MockQuoteRepository
+	accruals  interest.Repository
+	fees      *MockFeeRepository
+	schedules *MockScheduleRepository
+	commits   int
+	rollbacks int
+}
+
+// newMockUnitOfWork cria uma unidade de trabalho que expõe os mocks informados.
+// O razão aceita qualquer lançamento por padrão; testes que verificam os
+// lançamentos substituem uow.ledger por um mock com expectativas próprias.
+// Da mesma forma, nenhuma conta tem transferências pendentes, os limites seguem
+// o nível padrão, sem uso anterior, e nenhuma tarifa é cobrada.
+func newMockUnitOfWork(accounts *MockRepository, outbox *MockOutboxRepository) *MockUnitOfWork {
+	ledgerRepo := new(MockLedgerRepository)
+	ledgerRepo.On("Append", mock.Anything).Return(nil).Maybe()
+	transferRepo := new(MockTransferRepository)
+	transferRepo.On("HasPendingFrom", mock.Anything).Return(false, nil).Maybe()
+	return &MockUnitOfWork{
+		accounts:  accounts,
+		outbox:    outbox,
+		transfers: transferRepo,
+		ledger:    ledgerRepo,
+		limits:    newMockLimitRepository(usedLimits(0, 0)),
+		fees:      newMockFeeRepository(fee.Schedule{}),
+	}
+}
+
+// usedLimits monta o uso diário e mensal dos limites, em centavos
+func usedLimits(daily, monthly int64) limit.Usage {
+	return limit.Usage{
+		Daily:   account.NewMoney(daily, account.DefaultCurrency),
+		Monthly: account.NewMoney(monthly, account.DefaultCurrency),
+	}
+}
+
+// newMockLimitRepository cria limites do nível padrão com o uso informado, em
+// account.DefaultCurrency. Contas nas demais moedas usadas nos testes seguem o
+// nível padrão na própria moeda, sem uso anterior.
+func newMockLimitRepository(usage limit.Usage) *MockLimitRepository {
+	limitRepo := new(MockLimitRepository)
+	for _, currency := range []string{account.DefaultCurrency, "USD", "EUR", "JPY", "BHD"} {
+		policy, _ := limit.ForTier(limit.TierStandard, currency)
+		used := limit.Usage{Daily: account.Zero(currency), Monthly: account.Zero(currency)}
+		if currency == account.DefaultCurrency {
+			used = usage
+		}
+		limitRepo.On("FindPolicy", mock.Anything, currency).Return(policy, nil).Maybe()
+		limitRepo.On("Usage", mock.Anything, currency, mock.Anything).Return(used, nil).Maybe()
+	}
+	limitRepo.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	limitRepo.On("Release", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return limitRepo
+}
+
+func (u *MockUnitOfWork) Do(fn func(tx persistence.Transaction) error) error {
+	if err := fn(u); err != nil {
+		u.rollbacks++
+		return err
+	}
+	u.commits++
+	return nil
+}
+
+func (u *MockUnitOfWork) Accounts() account.Repository {
+	return u.accounts
+}
+
+func (u *MockUnitOfWork) Outbox() persistence.OutboxRepositoryInterface {
+	return u.outbox
+}
+
+func (u *MockUnitOfWork) Transfers() transfer.Repository {
+	return u.transfers
+}
+
+func (u *MockUnitOfWork) Ledger() ledger.Repository {
+	return u.ledger
+}
+
+func (u *MockUnitOfWork) Limits() limit.Repository {
+	return u.limits
+}
+
+func (u *MockUnitOfWork) Quotes() fx.QuoteRepository {
+	return u.quotes
+}
+
+func (u *MockUnitOfWork) Interest() interest.Repository {
+	return u.accruals
+}
+
+func (u *MockUnitOfWork) Fees() fee.Repository {
+	return u.fees
+}
+
+func (u *MockUnitOfWork) Schedules() schedule.Repository {
+	return u.schedules
+}
+
+// testAccountOption ajusta a conta criada por newTestAccount
+type testAccountOption func(*account.Account)
+
+// inCurrency cria a conta na moeda informada em vez de account.DefaultCurrency
+func inCurrency(currency string) testAccountOption {
+	return func(a *account.Account) {
+		a.Balance = account.NewMoney(a.Balance.MinorUnits(), currency)
+	}
+}
+
+// newTestAccount cria uma conta ativa com o saldo informado em unidades menores,
+// em account.DefaultCurrency salvo indicação em contrário
+func newTestAccount(id string, balance int64, opts ...testAccountOption) *account.Account {
+	a := &account.Account{
+		ID:      id,
+		Name:    "Conta " + id,
+		Email:   id + "@example.com",
+		Balance: account.NewMoney(balance, account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
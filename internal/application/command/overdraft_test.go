@@ -0,0 +1,126 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+func TestSetOverdraftLimitHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewSetOverdraftLimitHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+
+	var saved account.OverdraftLimitChangedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.OverdraftLimitChangedEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.OverdraftLimitChangedEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(SetOverdraftLimitCommand{AccountID: "account-123", Limit: "200.00"})
+
+	// Assert: o limite passa a compor o saldo disponível
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(20000, account.DefaultCurrency), existingAccount.OverdraftLimit)
+	assert.Equal(t, account.NewMoney(25000, account.DefaultCurrency), existingAccount.AvailableBalance())
+	assert.Equal(t, account.NewMoney(20000, account.DefaultCurrency), saved.Limit)
+	assert.True(t, saved.PreviousLimit.IsZero())
+}
+
+func TestSetOverdraftLimitHandler_Handle_NegativeLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewSetOverdraftLimitHandler(uow, nil)
+
+	// Act
+	err := handler.Handle(SetOverdraftLimitCommand{AccountID: "account-123", Limit: "-10.00"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestSetOverdraftLimitHandler_Handle_BelowCurrentUse(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewSetOverdraftLimitHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", -8000)
+	existingAccount.OverdraftLimit = account.NewMoney(10000, account.DefaultCurrency)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(SetOverdraftLimitCommand{AccountID: "account-123", Limit: "50.00"})
+
+	// Assert: o limite não pode ficar abaixo do que já foi usado
+	assert.ErrorIs(t, err, account.ErrOverdraftLimitBelowUse)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), existingAccount.OverdraftLimit)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_UsesOverdraft(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+	existingAccount.OverdraftLimit = account.NewMoney(10000, account.DefaultCurrency)
+
+	var saved account.AccountWithdrawnEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(account.AccountWithdrawnEvent)
+	}).Return(nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "120.00"})
+
+	// Assert: o saldo fica negativo dentro do limite
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(-7000, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, account.NewMoney(-7000, account.DefaultCurrency), saved.CurrentBalance)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), existingAccount.AvailableBalance())
+}
+
+func TestWithdrawHandler_Handle_BeyondOverdraftLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 5000)
+	existingAccount.OverdraftLimit = account.NewMoney(10000, account.DefaultCurrency)
+
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	err := handler.Handle(WithdrawCommand{AccountID: "account-123", Amount: "150.01"})
+
+	// Assert
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
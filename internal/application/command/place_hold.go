@@ -0,0 +1,74 @@
+package command
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// DefaultHoldTTL é a validade de uma reserva quando o comando não informa o vencimento
+const DefaultHoldTTL = 7 * 24 * time.Hour
+
+// PlaceHoldCommand representa o comando para reservar parte do saldo de uma conta
+type PlaceHoldCommand struct {
+	AccountID string      `json:"account_id"`
+	Amount    json.Number `json:"amount"`
+	Currency  string      `json:"currency"`   // Opcional; precisa ser a moeda da conta
+	ExpiresAt time.Time   `json:"expires_at"` // Opcional; padrão: agora + DefaultHoldTTL
+}
+
+// PlaceHoldHandler manipula o comando de reserva de saldo
+type PlaceHoldHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewPlaceHoldHandler cria um novo manipulador de reserva de saldo
+func NewPlaceHoldHandler(uow persistence.UnitOfWork, publisher event.Publisher) *PlaceHoldHandler {
+	return &PlaceHoldHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de reserva e retorna o ID da reserva criada
+func (h *PlaceHoldHandler) Handle(cmd PlaceHoldCommand) (string, error) {
+	// Validar valor da reserva; sem moeda, ele é interpretado na moeda da conta
+	if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
+		return "", err
+	}
+	expiresAt := cmd.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(DefaultHoldTTL)
+	}
+
+	var holdID string
+	err := updateAccount(h.uow, h.publisher, cmd.AccountID, func(acc *account.Account) error {
+		amount, err := accountAmount(acc, cmd.Amount, cmd.Currency)
+		if err != nil {
+			return err
+		}
+
+		// Verificar se há saldo disponível suficiente, fora das reservas
+		if cmp, err := acc.AvailableBalance().Cmp(amount); err != nil {
+			return err
+		} else if cmp < 0 {
+			return ErrInsufficientFunds
+		}
+
+		hold, err := acc.PlaceHold(amount, expiresAt)
+		if err != nil {
+			return err
+		}
+		holdID = hold.ID
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return holdID, nil
+}
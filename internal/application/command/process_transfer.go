@@ -0,0 +1,175 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// ProcessTransferCommand representa o comando para concluir uma transferência pendente
+type ProcessTransferCommand struct {
+	TransferID string `json:"transfer_id"`
+}
+
+// ProcessTransferHandler executa a segunda etapa da saga de transferência:
+// credita o destino ou, se não for possível, devolve à origem o valor e a
+// tarifa cobrada e retira o débito do uso dos limites de saque.
+//
+// O manipulador é idempotente e pode ser chamado tanto pela API quanto pelo
+// worker: transferências já finalizadas são ignoradas, e a atualização
+// condicional do repositório impede que duas execuções concorrentes
+// finalizem a mesma transferência.
+type ProcessTransferHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewProcessTransferHandler cria um novo manipulador de conclusão de transferência
+func NewProcessTransferHandler(uow persistence.UnitOfWork, publisher event.Publisher) *ProcessTransferHandler {
+	return &ProcessTransferHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de conclusão de transferência
+func (h *ProcessTransferHandler) Handle(cmd ProcessTransferCommand) error {
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			t, err := tx.Transfers().FindByID(cmd.TransferID)
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				return ErrTransferNotFound
+			}
+			if t.Status != transfer.StatusPending {
+				// Já finalizada por outra execução
+				return nil
+			}
+
+			destination, err := tx.Accounts().FindByID(t.DestinationAccountID)
+			if err != nil {
+				return err
+			}
+
+			// Creditar o destino
+			creditErr := ErrAccountNotFound
+			if destination != nil {
+				creditErr = destination.TransferIn(t.DestinationAmount, t.ID)
+			}
+			if creditErr == nil {
+				deposited := destination.Changes()
+				if err := tx.Accounts().Update(destination); err != nil {
+					return err
+				}
+				if err := t.Complete(); err != nil {
+					return err
+				}
+				if err := tx.Transfers().Update(t); err != nil {
+					return err
+				}
+
+				// Lançar o crédito no razão na mesma transação
+				entry, err := creditEntry(deposited[0].EventID(), t)
+				if err != nil {
+					return err
+				}
+				if err := tx.Ledger().Append(entry); err != nil {
+					return err
+				}
+
+				events = append(deposited, transfer.TransferCompletedEvent{
+					BaseEvent:            newBaseEvent("TransferCompleted", t.SourceAccountID, t.ID),
+					SourceAccountID:      t.SourceAccountID,
+					DestinationAccountID: t.DestinationAccountID,
+					Amount:               t.Amount,
+					DestinationAmount:    t.DestinationAmount,
+					ExchangeRate:         t.ExchangeRate,
+					QuoteID:              t.QuoteID,
+				})
+				return recordEvents(tx, events...)
+			}
+
+			// Compensação: o destino recusou o crédito, então o valor volta à origem
+			// junto com a tarifa da transferência, e o débito deixa de contar nos
+			// limites de saque
+			source, err := tx.Accounts().FindByID(t.SourceAccountID)
+			if err != nil {
+				return err
+			}
+			if source == nil {
+				return ErrAccountNotFound
+			}
+			if err := source.Refund(t.Amount, t.ID); err != nil {
+				return err
+			}
+			if err := reverseFee(tx, source, fee.KindTransfer, t.ID, creditErr.Error()); err != nil {
+				return err
+			}
+			if t.DebitEventID != "" {
+				if err := releaseWithdrawalUsage(tx, source.ID, t.DebitEventID); err != nil {
+					return err
+				}
+			}
+			refunded := source.Changes()
+			if err := tx.Accounts().Update(source); err != nil {
+				return err
+			}
+			if err := t.Fail(creditErr.Error()); err != nil {
+				return err
+			}
+			if err := tx.Transfers().Update(t); err != nil {
+				return err
+			}
+
+			// Lançar a movimentação no razão na mesma transação
+			entry, err := ledger.NewTransferRefundEntry(refunded[0].EventID(), t.ID, source.ID, t.Amount)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
+
+			events = append(refunded, transfer.TransferFailedEvent{
+				BaseEvent:            newBaseEvent("TransferFailed", t.SourceAccountID, t.ID),
+				SourceAccountID:      t.SourceAccountID,
+				DestinationAccountID: t.DestinationAccountID,
+				Amount:               t.Amount,
+				DestinationAmount:    t.DestinationAmount,
+				ExchangeRate:         t.ExchangeRate,
+				QuoteID:              t.QuoteID,
+				Reason:               t.FailureReason,
+			})
+			return recordEvents(tx, events...)
+		})
+	})
+	if errors.Is(err, transfer.ErrAlreadyFinalized) {
+		// Outra execução concorrente finalizou a transferência primeiro
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	return nil
+}
+
+// creditEntry monta o lançamento do crédito no destino; nas transferências
+// entre moedas, a conversão passa pela conta de câmbio
+func creditEntry(eventID string, t *transfer.Transfer) (*ledger.JournalEntry, error) {
+	if t.Converted() {
+		return ledger.NewConvertedTransferCreditEntry(eventID, t.ID, t.DestinationAccountID, t.Amount, t.DestinationAmount)
+	}
+	return ledger.NewTransferCreditEntry(eventID, t.ID, t.DestinationAccountID, t.Amount)
+}
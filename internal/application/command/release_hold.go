@@ -0,0 +1,34 @@
+package command
+
+import (
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// ReleaseHoldCommand representa o comando para cancelar uma reserva sem capturá-la
+type ReleaseHoldCommand struct {
+	AccountID string `json:"account_id"`
+	HoldID    string `json:"hold_id"`
+}
+
+// ReleaseHoldHandler manipula o comando de liberação de reserva
+type ReleaseHoldHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewReleaseHoldHandler cria um novo manipulador de liberação de reserva
+func NewReleaseHoldHandler(uow persistence.UnitOfWork, publisher event.Publisher) *ReleaseHoldHandler {
+	return &ReleaseHoldHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de liberação de reserva
+func (h *ReleaseHoldHandler) Handle(cmd ReleaseHoldCommand) error {
+	return updateAccount(h.uow, h.publisher, cmd.AccountID, func(acc *account.Account) error {
+		return acc.ReleaseHold(cmd.HoldID)
+	})
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// maxConflictRetries é o número máximo de tentativas de um comando cuja
+// gravação foi rejeitada pelo controle de concorrência otimista
+const maxConflictRetries = 3
+
+// retryOnConflict reexecuta fn quando outra transação alterou a conta entre a
+// leitura e a gravação. Como cada tentativa relê a conta, as regras de domínio
+// (como o saldo suficiente) são reavaliadas sobre o estado mais recente.
+func retryOnConflict(fn func() error) error {
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		err := fn()
+		if !errors.Is(err, account.ErrVersionConflict) {
+			return err
+		}
+	}
+	return ErrConcurrentUpdate
+}
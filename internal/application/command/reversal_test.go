@@ -0,0 +1,224 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// newReversalTestLedger cria um razão que contém o lançamento original e
+// guarda os novos lançamentos recebidos
+func newReversalTestLedger(original *ledger.JournalEntry, reversed bool, entries *[]*ledger.JournalEntry) *MockLedgerRepository {
+	mockLedger := recordingLedger(entries)
+	mockLedger.On("FindByEventID", original.EventID).Return(original, nil)
+	mockLedger.On("Reversed", original.EventID).Return(reversed, nil)
+	return mockLedger
+}
+
+func TestReverseTransactionHandler_Handle_Deposit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	original, _ := ledger.NewDepositEntry("deposit-1", "account-123", account.NewMoney(3000, account.DefaultCurrency))
+	entries := []*ledger.JournalEntry{original}
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = newReversalTestLedger(original, false, &entries)
+	handler := NewReverseTransactionHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 10000)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.TransactionReversedEvent")).Return(nil)
+
+	// Act
+	reversal, err := handler.Handle(ReverseTransactionCommand{EventID: "deposit-1", Reason: "depósito na conta errada"})
+
+	// Assert: o depósito é debitado e o evento referencia o original
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(7000, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, "deposit-1", reversal.OriginalEventID)
+	assert.Equal(t, "AccountDeposited", reversal.OriginalEventType)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), reversal.Amount)
+	assert.Equal(t, "depósito na conta errada", reversal.Reason)
+
+	// O estorno inverte as pernas do lançamento original, zerando o efeito no razão
+	assert.Len(t, entries, 2)
+	assert.Equal(t, reversal.ID, entries[1].EventID)
+	assert.Equal(t, "deposit-1", entries[1].ReversesEventID)
+	assert.Equal(t, map[string]int64{
+		"account-123":        0,
+		ledger.CashInAccount: 0,
+	}, ledgerBalances(entries))
+}
+
+func TestReverseTransactionHandler_Handle_WithdrawalOnBlockedAccount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	original, _ := ledger.NewWithdrawalEntry("withdrawal-1", "account-123", account.NewMoney(2000, account.DefaultCurrency))
+	entries := []*ledger.JournalEntry{original}
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = newReversalTestLedger(original, false, &entries)
+	handler := NewReverseTransactionHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 500)
+	existingAccount.Status = account.StatusBlocked
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	reversal, err := handler.Handle(ReverseTransactionCommand{EventID: "withdrawal-1", Reason: "saque em duplicidade"})
+
+	// Assert: o saque é devolvido mesmo com a conta bloqueada
+	assert.NoError(t, err)
+	assert.Equal(t, "AccountWithdrawn", reversal.OriginalEventType)
+	assert.Equal(t, account.NewMoney(2500, account.DefaultCurrency), existingAccount.Balance)
+	assert.Equal(t, account.NewMoney(2500, account.DefaultCurrency), reversal.CurrentBalance)
+}
+
+func TestReverseTransactionHandler_Handle_WithdrawalReversesFeeAndUsage(t *testing.T) {
+	// Arrange: um saque de R$ 20,00 tarifado em R$ 2,50
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockFees := new(MockFeeRepository)
+	mockLimits := new(MockLimitRepository)
+
+	original, _ := ledger.NewWithdrawalEntry("withdrawal-1", "account-123", account.NewMoney(2000, account.DefaultCurrency))
+	feeEntry, _ := ledger.NewFeeEntry("fee-1", "account-123", account.NewMoney(250, account.DefaultCurrency))
+	entries := []*ledger.JournalEntry{original, feeEntry}
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = newReversalTestLedger(original, false, &entries)
+	uow.ledger.On("FindByEventID", "fee-1").Return(feeEntry, nil)
+	uow.fees = mockFees
+	uow.limits = mockLimits
+	handler := NewReverseTransactionHandler(uow, nil)
+
+	existingAccount := newTestAccount("account-123", 500)
+	var saved []account.TransactionReversedEvent
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+	mockRepo.On("Update", existingAccount).Return(nil)
+	mockFees.On("FindCharge", "account-123", fee.KindWithdrawal, "withdrawal-1").
+		Return(&fee.Charge{EventID: "fee-1", AccountID: "account-123", Kind: fee.KindWithdrawal, Amount: account.NewMoney(250, account.DefaultCurrency), Reference: "withdrawal-1"}, nil)
+	mockLimits.On("Release", "account-123", "withdrawal-1").Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.TransactionReversedEvent")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).(account.TransactionReversedEvent))
+	}).Return(nil)
+
+	// Act
+	reversal, err := handler.Handle(ReverseTransactionCommand{EventID: "withdrawal-1", Reason: "saque em duplicidade"})
+
+	// Assert: o saque e a tarifa voltam para a conta, cada um com seu estorno
+	assert.NoError(t, err)
+	assert.Equal(t, "withdrawal-1", reversal.OriginalEventID)
+	assert.Equal(t, account.NewMoney(2750, account.DefaultCurrency), existingAccount.Balance)
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, "AccountWithdrawn", saved[0].OriginalEventType)
+		assert.Equal(t, "fee-1", saved[1].OriginalEventID)
+		assert.Equal(t, "FeeCharged", saved[1].OriginalEventType)
+		assert.Equal(t, account.NewMoney(250, account.DefaultCurrency), saved[1].Amount)
+		assert.Equal(t, "saque em duplicidade", saved[1].Reason)
+	}
+
+	// O razão fica zerado, e o saque deixa de contar nos limites
+	assert.Len(t, entries, 4)
+	assert.Equal(t, "fee-1", entries[2].ReversesEventID)
+	assert.Equal(t, "withdrawal-1", entries[3].ReversesEventID)
+	assert.Equal(t, map[string]int64{
+		"account-123":           0,
+		ledger.CashOutAccount:   0,
+		ledger.FeeIncomeAccount: 0,
+	}, ledgerBalances(entries))
+	mockLimits.AssertExpectations(t)
+}
+
+func TestReverseTransactionHandler_Handle_AlreadyReversed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	original, _ := ledger.NewDepositEntry("deposit-1", "account-123", account.NewMoney(3000, account.DefaultCurrency))
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = newReversalTestLedger(original, true, &entries)
+	handler := NewReverseTransactionHandler(uow, nil)
+
+	// Act
+	_, err := handler.Handle(ReverseTransactionCommand{EventID: "deposit-1", Reason: "depósito na conta errada"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAlreadyReversed)
+	assert.Empty(t, entries)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestReverseTransactionHandler_Handle_DepositRequiresFunds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	original, _ := ledger.NewDepositEntry("deposit-1", "account-123", account.NewMoney(3000, account.DefaultCurrency))
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.ledger = newReversalTestLedger(original, false, &entries)
+	handler := NewReverseTransactionHandler(uow, nil)
+
+	// O valor depositado já foi parcialmente gasto
+	existingAccount := newTestAccount("account-123", 1000)
+	mockRepo.On("FindByID", "account-123").Return(existingAccount, nil)
+
+	// Act
+	_, err := handler.Handle(ReverseTransactionCommand{EventID: "deposit-1", Reason: "depósito na conta errada"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.Equal(t, account.NewMoney(1000, account.DefaultCurrency), existingAccount.Balance)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestReverseTransactionHandler_Handle_Rejections(t *testing.T) {
+	transferCredit, _ := ledger.NewTransferCreditEntry("credit-1", "transfer-1", "account-123", account.NewMoney(3000, account.DefaultCurrency))
+	reversalEntry, _ := ledger.NewReversalEntry("reversal-1", transferCredit)
+
+	tests := []struct {
+		name     string
+		cmd      ReverseTransactionCommand
+		original *ledger.JournalEntry
+		expected error
+	}{
+		{"sem motivo", ReverseTransactionCommand{EventID: "credit-1", Reason: "  "}, transferCredit, ErrReasonRequired},
+		{"evento inexistente", ReverseTransactionCommand{EventID: "missing", Reason: "erro"}, transferCredit, ErrTransactionNotFound},
+		{"crédito de transferência", ReverseTransactionCommand{EventID: "credit-1", Reason: "erro"}, transferCredit, account.ErrNotReversible},
+		{"estorno de estorno", ReverseTransactionCommand{EventID: "reversal-1", Reason: "erro"}, reversalEntry, account.ErrNotReversible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockLedger := new(MockLedgerRepository)
+			mockLedger.On("FindByEventID", tt.original.EventID).Return(tt.original, nil)
+			mockLedger.On("FindByEventID", mock.Anything).Return(nil, nil)
+
+			uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+			uow.ledger = mockLedger
+			handler := NewReverseTransactionHandler(uow, nil)
+
+			// Act
+			_, err := handler.Handle(tt.cmd)
+
+			// Assert: nada é lançado
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+			mockLedger.AssertNotCalled(t, "Append", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// ReverseTransactionCommand representa o comando para estornar um depósito ou
+// saque já lançado, identificado pelo ID do evento original
+type ReverseTransactionCommand struct {
+	EventID string `json:"event_id"`
+	Reason  string `json:"reason"`
+}
+
+// ReverseTransactionHandler manipula o comando de estorno
+type ReverseTransactionHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewReverseTransactionHandler cria um novo manipulador de estorno
+func NewReverseTransactionHandler(uow persistence.UnitOfWork, publisher event.Publisher) *ReverseTransactionHandler {
+	return &ReverseTransactionHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle estorna a movimentação e retorna o evento TransactionReversed. A
+// movimentação original é localizada pelo seu lançamento no razão, e o estorno
+// lança as mesmas pernas invertidas; o razão recusa um segundo estorno do
+// mesmo evento. O estorno de um saque tarifado emite um segundo
+// TransactionReversed, o da tarifa.
+func (h *ReverseTransactionHandler) Handle(cmd ReverseTransactionCommand) (*account.TransactionReversedEvent, error) {
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			original, err := tx.Ledger().FindByEventID(cmd.EventID)
+			if err != nil {
+				return err
+			}
+			if original == nil {
+				return ErrTransactionNotFound
+			}
+			eventType, posting, err := reversibleMovement(original)
+			if err != nil {
+				return err
+			}
+
+			reversed, err := tx.Ledger().Reversed(original.EventID)
+			if err != nil {
+				return err
+			}
+			if reversed {
+				return ErrAlreadyReversed
+			}
+
+			acc, err := tx.Accounts().FindByID(posting.AccountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+
+			// Estornar um depósito debita a conta: o valor precisa estar disponível
+			if eventType == "AccountDeposited" {
+				if err := requireFunds(acc, posting.Amount, account.Zero(acc.Currency())); err != nil {
+					return err
+				}
+			}
+
+			if err := acc.ReverseTransaction(original.EventID, eventType, posting.Amount, reason); err != nil {
+				return err
+			}
+
+			// Um saque estornado devolve também a tarifa cobrada por ele e deixa
+			// de contar nos limites de saque
+			if eventType == "AccountWithdrawn" {
+				if err := reverseFee(tx, acc, fee.KindWithdrawal, original.EventID, reason); err != nil {
+					return err
+				}
+				if err := releaseWithdrawalUsage(tx, acc.ID, original.EventID); err != nil {
+					return err
+				}
+			}
+			events = acc.Changes()
+
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Lançar o estorno no razão, ligado ao lançamento original
+			entry, err := ledger.NewReversalEntry(events[0].EventID(), original)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
+
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishCommitted(h.uow, h.publisher, events...)
+
+	reversal := events[0].(account.TransactionReversedEvent)
+	return &reversal, nil
+}
+
+// reversibleMovement identifica no lançamento original o tipo do evento e a
+// perna da conta do cliente. Apenas depósitos e saques avulsos podem ser
+// estornados; movimentos de transferências, tarifas, juros e os próprios
+// estornos não.
+func reversibleMovement(entry *ledger.JournalEntry) (string, ledger.Posting, error) {
+	var eventType string
+	var direction ledger.Direction
+	switch entry.Description {
+	case ledger.DescriptionDeposit:
+		eventType, direction = "AccountDeposited", ledger.DirectionCredit
+	case ledger.DescriptionWithdrawal:
+		eventType, direction = "AccountWithdrawn", ledger.DirectionDebit
+	default:
+		return "", ledger.Posting{}, account.ErrNotReversible
+	}
+
+	for _, p := range entry.Postings {
+		if p.Direction == direction {
+			return eventType, p, nil
+		}
+	}
+	return "", ledger.Posting{}, account.ErrNotReversible
+}
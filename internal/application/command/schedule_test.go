@@ -0,0 +1,306 @@
+package command
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// scheduleTestNow é o instante das execuções nos testes: 31/01/2026, 9h (UTC)
+var scheduleTestNow = time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+// newTestSchedule cria um agendamento mensal de R$ 30,00 de source para
+// destination, com primeira ocorrência em 31/01/2026
+func newTestSchedule(t *testing.T, policy string, maxAttempts int) *schedule.Schedule {
+	s, err := schedule.NewSchedule("source", "destination", account.NewMoney(3000, account.DefaultCurrency),
+		schedule.FrequencyMonthly, scheduleTestNow, nil, policy, maxAttempts, scheduleTestNow.Add(-time.Hour))
+	assert.NoError(t, err)
+	return s
+}
+
+// newScheduleTestHandler monta o executor de agendamentos sobre os mocks,
+// devolvendo s como único agendamento vencido
+func newScheduleTestHandler(mockRepo *MockRepository, mockOutbox *MockOutboxRepository, mockTransfers *MockTransferRepository, s *schedule.Schedule) (*ExecuteSchedulesHandler, *MockScheduleRepository) {
+	mockSchedules := new(MockScheduleRepository)
+	mockSchedules.On("FindDue", mock.Anything, 100).Return([]*schedule.Schedule{s}, nil)
+	mockSchedules.On("Update", s).Return(nil)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.schedules = mockSchedules
+	transfers := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+	return NewExecuteSchedulesHandler(uow, mockSchedules, transfers, time.Hour, fixedClock(scheduleTestNow)), mockSchedules
+}
+
+func TestCreateScheduleHandler_Handle_Validation(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(schedule.DateLayout)
+
+	tests := []struct {
+		name     string
+		cmd      CreateScheduleCommand
+		expected error
+	}{
+		{"periodicidade desconhecida", CreateScheduleCommand{Frequency: "yearly", StartDate: tomorrow}, schedule.ErrInvalidFrequency},
+		{"data inicial no passado", CreateScheduleCommand{Frequency: "once", StartDate: "2020-01-01"}, schedule.ErrInvalidStartDate},
+		{"data em formato inválido", CreateScheduleCommand{Frequency: "once", StartDate: "01/02/2030"}, ErrInvalidDate},
+		{"data final antes da inicial", CreateScheduleCommand{Frequency: "daily", StartDate: tomorrow, EndDate: "2020-01-01"}, schedule.ErrInvalidEndDate},
+		{"política desconhecida", CreateScheduleCommand{Frequency: "daily", StartDate: tomorrow, OnInsufficientFunds: "wait"}, schedule.ErrInvalidPolicy},
+		{"tentativas acima do limite", CreateScheduleCommand{Frequency: "daily", StartDate: tomorrow, MaxAttempts: 11}, schedule.ErrInvalidAttempts},
+		{"mesma conta", CreateScheduleCommand{DestinationAccountID: "source", Frequency: "daily", StartDate: tomorrow}, ErrSameAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			handler := NewCreateScheduleHandler(newMockUnitOfWork(mockRepo, new(MockOutboxRepository)))
+
+			cmd := tt.cmd
+			cmd.AccountID = "source"
+			if cmd.DestinationAccountID == "" {
+				cmd.DestinationAccountID = "destination"
+			}
+			cmd.Amount = "30.00"
+
+			// Act
+			_, err := handler.Handle(cmd)
+
+			// Assert: nada é consultado ou gravado
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		})
+	}
+}
+
+func TestUpdateScheduleHandler_Handle_OtherAccount(t *testing.T) {
+	// Arrange
+	mockSchedules := new(MockScheduleRepository)
+	uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+	uow.schedules = mockSchedules
+	handler := NewUpdateScheduleHandler(uow)
+
+	s := newTestSchedule(t, "", 0)
+	mockSchedules.On("FindByID", s.ID).Return(s, nil)
+
+	// Act: o agendamento pertence à conta source
+	err := handler.Handle(UpdateScheduleCommand{AccountID: "destination", ScheduleID: s.ID, Amount: "50.00"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), s.Amount)
+	mockSchedules.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestExecuteSchedulesHandler_Handle_ExecutesAndAdvances(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	s := newTestSchedule(t, "", 0)
+	handler, mockSchedules := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+
+	source := newTestAccount("source", 10000)
+	destination := newTestAccount("destination", 0)
+	transferID := s.TransferID()
+
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockTransfers.On("FindByID", transferID).Return(nil, nil).Once()
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved := args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: a transferência usa o ID da ocorrência
+	assert.NoError(t, err)
+	assert.Equal(t, 1, executed)
+	assert.Equal(t, account.NewMoney(7000, account.DefaultCurrency), source.Balance)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), destination.Balance)
+	assert.Equal(t, transferID, s.LastTransferID)
+
+	// Fevereiro não tem dia 31: a próxima ocorrência é no último dia do mês
+	assert.Equal(t, schedule.StatusActive, s.Status)
+	assert.Equal(t, "2026-02-28", s.NextRunAt.Format(schedule.DateLayout))
+	mockSchedules.AssertCalled(t, "Update", s)
+}
+
+func TestExecuteSchedulesHandler_Handle_ExistingTransfer(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	s := newTestSchedule(t, "", 0)
+	handler, _ := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+
+	// A ocorrência já gerou a transferência, mas o agendamento não avançou
+	done := &transfer.Transfer{ID: s.TransferID(), Status: transfer.StatusCompleted}
+	mockTransfers.On("FindByID", done.ID).Return(done, nil)
+
+	// Act
+	executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: nenhuma nova movimentação, e o agendamento avança
+	assert.NoError(t, err)
+	assert.Equal(t, 1, executed)
+	assert.Equal(t, done.ID, s.LastTransferID)
+	assert.Equal(t, "2026-02-28", s.NextRunAt.Format(schedule.DateLayout))
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestExecuteSchedulesHandler_Handle_InsufficientFundsRetries(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	s := newTestSchedule(t, schedule.OnInsufficientFundsRetry, 2)
+	handler, _ := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+
+	mockRepo.On("FindByID", "source").Return(newTestAccount("source", 1000), nil)
+	mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+	mockTransfers.On("FindByID", mock.Anything).Return(nil, nil)
+
+	// Act: primeira tentativa
+	executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: a ocorrência é tentada de novo depois do intervalo
+	assert.NoError(t, err)
+	assert.Equal(t, 0, executed)
+	assert.Equal(t, 1, s.Attempts)
+	assert.Equal(t, scheduleTestNow.Add(time.Hour), *s.RetryAt)
+	assert.Equal(t, "2026-01-31", s.NextRunAt.Format(schedule.DateLayout))
+	assert.Equal(t, ErrInsufficientFunds.Error(), s.LastError)
+
+	// Act: segunda tentativa, já depois do intervalo
+	handler.clock = fixedClock(scheduleTestNow.Add(time.Hour))
+	executed, err = handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: esgotadas as tentativas, a ocorrência é pulada
+	assert.NoError(t, err)
+	assert.Equal(t, 0, executed)
+	assert.Equal(t, 0, s.Attempts)
+	assert.Nil(t, s.RetryAt)
+	assert.Equal(t, "2026-02-28", s.NextRunAt.Format(schedule.DateLayout))
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestExecuteSchedulesHandler_Handle_SkipPolicy(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	s := newTestSchedule(t, schedule.OnInsufficientFundsSkip, 0)
+	handler, _ := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+
+	mockRepo.On("FindByID", "source").Return(newTestAccount("source", 1000), nil)
+	mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+	mockTransfers.On("FindByID", mock.Anything).Return(nil, nil)
+
+	// Act
+	executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: a ocorrência é pulada sem nova tentativa
+	assert.NoError(t, err)
+	assert.Equal(t, 0, executed)
+	assert.Nil(t, s.RetryAt)
+	assert.Equal(t, "2026-02-28", s.NextRunAt.Format(schedule.DateLayout))
+	assert.Equal(t, ErrInsufficientFunds.Error(), s.LastError)
+}
+
+func TestExecuteSchedulesHandler_Handle_LimitExceeded(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		expectedRetry bool
+		expectedNext  string
+	}{
+		{"nova tentativa", schedule.OnInsufficientFundsRetry, true, "2026-01-31"},
+		{"pula a ocorrência", schedule.OnInsufficientFundsSkip, false, "2026-02-28"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			mockOutbox := new(MockOutboxRepository)
+			mockTransfers := new(MockTransferRepository)
+
+			s := newTestSchedule(t, tt.policy, 2)
+			handler, _ := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+			// Só resta R$ 10,00 do limite diário da origem
+			handler.uow.(*MockUnitOfWork).limits = newMockLimitRepository(usedLimits(999000, 999000))
+
+			mockRepo.On("FindByID", "source").Return(newTestAccount("source", 100000), nil)
+			mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+			mockTransfers.On("FindByID", mock.Anything).Return(nil, nil)
+
+			// Act
+			executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+			// Assert: o limite excedido é tratado como a falta de saldo
+			assert.NoError(t, err)
+			assert.Equal(t, 0, executed)
+			assert.Equal(t, tt.expectedRetry, s.RetryAt != nil)
+			assert.Equal(t, tt.expectedNext, s.NextRunAt.Format(schedule.DateLayout))
+			assert.Contains(t, s.LastError, limit.ErrLimitExceeded.Error())
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+			mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+		})
+	}
+}
+
+func TestExecuteSchedulesHandler_Handle_TransientErrorKeepsSchedule(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	s := newTestSchedule(t, "", 0)
+	handler, mockSchedules := newScheduleTestHandler(mockRepo, mockOutbox, mockTransfers, s)
+
+	mockTransfers.On("FindByID", mock.Anything).Return(nil, errors.New("database error"))
+
+	// Act
+	executed, err := handler.Handle(ExecuteSchedulesCommand{})
+
+	// Assert: a ocorrência continua pendente para a próxima execução
+	assert.NoError(t, err)
+	assert.Equal(t, 0, executed)
+	assert.Equal(t, 0, s.Attempts)
+	assert.Equal(t, "2026-01-31", s.NextRunAt.Format(schedule.DateLayout))
+	mockSchedules.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSchedule_MonthlyClampsToLastDay(t *testing.T) {
+	// Arrange
+	s := newTestSchedule(t, "", 0)
+
+	// Act: executa as ocorrências de janeiro a maio
+	var dates []string
+	for i := 0; i < 5; i++ {
+		dates = append(dates, s.NextRunAt.Format(schedule.DateLayout))
+		s.Executed("transfer", scheduleTestNow)
+	}
+
+	// Assert: meses mais curtos usam o último dia, sem perder o dia 31 original
+	assert.Equal(t, []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"}, dates)
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// SetOverdraftLimitCommand representa o comando para definir o limite de
+// cheque especial de uma conta. Limite zero desativa o cheque especial.
+type SetOverdraftLimitCommand struct {
+	AccountID string      `json:"account_id"`
+	Limit     json.Number `json:"limit"`
+	Currency  string      `json:"currency"` // Opcional; precisa ser a moeda da conta
+}
+
+// SetOverdraftLimitHandler manipula o comando de limite de cheque especial
+type SetOverdraftLimitHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+}
+
+// NewSetOverdraftLimitHandler cria um novo manipulador de limite de cheque especial
+func NewSetOverdraftLimitHandler(uow persistence.UnitOfWork, publisher event.Publisher) *SetOverdraftLimitHandler {
+	return &SetOverdraftLimitHandler{
+		uow:       uow,
+		publisher: publisher,
+	}
+}
+
+// Handle executa o comando de limite de cheque especial
+func (h *SetOverdraftLimitHandler) Handle(cmd SetOverdraftLimitCommand) error {
+	// Validar o limite; ao contrário dos valores de movimentação, zero é aceito.
+	// Sem moeda, o limite é interpretado na moeda da conta.
+	if _, err := parseLimit(cmd.Limit, cmd.Currency); err != nil {
+		return err
+	}
+
+	return updateAccount(h.uow, h.publisher, cmd.AccountID, func(acc *account.Account) error {
+		currency := cmd.Currency
+		if currency == "" {
+			currency = acc.Currency()
+		}
+		limit, err := parseLimit(cmd.Limit, currency)
+		if err != nil {
+			return err
+		}
+		if err := requireCurrency(acc, limit); err != nil {
+			return err
+		}
+		return acc.SetOverdraftLimit(limit)
+	})
+}
+
+// parseLimit converte o limite recebido no comando para Money, recusando
+// valores negativos. Sem moeda, só o formato e o sinal são verificados.
+func parseLimit(value json.Number, currency string) (account.Money, error) {
+	var limit account.Money
+	var err error
+	if currency == "" {
+		limit, err = account.ParseDecimal(value.String())
+	} else {
+		if currency, err = normalizeCurrency(currency); err != nil {
+			return account.Money{}, err
+		}
+		limit, err = account.ParseMoney(value.String(), currency)
+	}
+	if err != nil {
+		return account.Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	if limit.IsNegative() {
+		return account.Money{}, ErrInvalidAmount
+	}
+	return limit, nil
+}
@@ -0,0 +1,269 @@
+package command
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// TransferCommand representa o comando para transferir valores entre contas
+type TransferCommand struct {
+	SourceAccountID      string      `json:"source_account_id"`
+	DestinationAccountID string      `json:"destination_account_id"`
+	Amount               json.Number `json:"amount"`   // Opcional com cotação; se informado, precisa ser o valor cotado
+	Currency             string      `json:"currency"` // Opcional; precisa ser a moeda da conta de origem
+	QuoteID              string      `json:"quote_id"` // Cotação de câmbio a usar numa transferência entre moedas
+
+	// TransferID fixa o ID da transferência, tornando o comando idempotente: se
+	// a transferência já existir, ela é retornada sem um novo débito. Usado pelas
+	// transferências agendadas; não é aceito pela API.
+	TransferID string `json:"-"`
+}
+
+// TransferHandler manipula o comando de transferência.
+//
+// A transferência é uma saga em duas etapas: esta etapa debita a origem e
+// registra a transferência como pendente; a segunda (ProcessTransferHandler)
+// credita o destino ou, se o crédito falhar, devolve o valor à origem.
+//
+// Quando as contas estão em moedas diferentes, o valor é convertido pela taxa
+// de uma cotação (QuoteID) ou, sem cotação, pela taxa atual do provedor.
+type TransferHandler struct {
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
+	processor *ProcessTransferHandler
+	rates     FXRateProvider
+}
+
+// NewTransferHandler cria um novo manipulador de transferência. rates pode ser
+// nil, caso em que só transferências com cotação convertem entre moedas.
+func NewTransferHandler(uow persistence.UnitOfWork, publisher event.Publisher, processor *ProcessTransferHandler, rates FXRateProvider) *TransferHandler {
+	return &TransferHandler{
+		uow:       uow,
+		publisher: publisher,
+		processor: processor,
+		rates:     rates,
+	}
+}
+
+// Handle executa o comando de transferência e retorna o ID da transferência criada
+func (h *TransferHandler) Handle(cmd TransferCommand) (string, error) {
+	// Validar valor da transferência; com cotação, o valor vem dela e, sem
+	// moeda, ele é interpretado na moeda da conta de origem
+	if cmd.QuoteID == "" {
+		if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
+			return "", err
+		}
+	}
+	if cmd.SourceAccountID == cmd.DestinationAccountID {
+		return "", ErrSameAccount
+	}
+
+	var t *transfer.Transfer
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		events = nil
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			// Com o ID fixado, uma transferência já registrada não é repetida
+			if cmd.TransferID != "" {
+				existing, err := tx.Transfers().FindByID(cmd.TransferID)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					t = existing
+					return nil
+				}
+			}
+
+			// Buscar as contas envolvidas
+			source, err := tx.Accounts().FindByID(cmd.SourceAccountID)
+			if err != nil {
+				return err
+			}
+			if source == nil {
+				return ErrAccountNotFound
+			}
+
+			destination, err := tx.Accounts().FindByID(cmd.DestinationAccountID)
+			if err != nil {
+				return err
+			}
+			if destination == nil {
+				return ErrAccountNotFound
+			}
+
+			// A origem precisa estar na moeda do valor transferido
+			var amount account.Money
+			var quote *fx.Quote
+			if cmd.QuoteID != "" {
+				if quote, err = findQuote(tx, cmd); err != nil {
+					return err
+				}
+				amount = quote.SourceAmount
+				if err := requireCurrency(source, amount); err != nil {
+					return err
+				}
+			} else if amount, err = accountAmount(source, cmd.Amount, cmd.Currency); err != nil {
+				return err
+			}
+
+			// Verificar se há saldo disponível suficiente para a transferência e a tarifa
+			charge, err := feeFor(tx, source, fee.KindTransfer)
+			if err != nil {
+				return err
+			}
+			if err := requireFunds(source, amount, charge); err != nil {
+				return err
+			}
+
+			// Verificar os limites de saque da origem sobre o uso recente
+			if err := checkWithdrawalLimits(tx, source.ID, amount); err != nil {
+				return err
+			}
+
+			// Registrar a transferência como pendente
+			t, err = transfer.NewTransfer(source.ID, destination.ID, amount)
+			if err != nil {
+				return err
+			}
+			if cmd.TransferID != "" {
+				t.ID = cmd.TransferID
+			}
+			if err := h.convert(t, destination, quote); err != nil {
+				return err
+			}
+
+			// Debitar a origem e cobrar a tarifa, referenciando a transferência
+			if err := source.TransferOut(amount, t.ID); err != nil {
+				return err
+			}
+			if err := chargeFee(tx, source, fee.KindTransfer, charge, t.ID, ""); err != nil {
+				return err
+			}
+			withdrawn := source.Changes()
+			t.DebitEventID = withdrawn[0].EventID()
+			if err := tx.Accounts().Update(source); err != nil {
+				return err
+			}
+			if err := tx.Transfers().Save(t); err != nil {
+				return err
+			}
+			if quote != nil {
+				if err := tx.Quotes().MarkUsed(quote.ID, t.ID); err != nil {
+					return err
+				}
+			}
+
+			// Lançar a movimentação no razão na mesma transação
+			entry, err := ledger.NewTransferDebitEntry(withdrawn[0].EventID(), t.ID, source.ID, amount)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
+
+			// Contabilizar o débito no uso dos limites da origem
+			if err := recordWithdrawalUsage(tx, source.ID, withdrawn[0], amount); err != nil {
+				return err
+			}
+
+			events = append(withdrawn, transfer.TransferInitiatedEvent{
+				BaseEvent:            newBaseEvent("TransferInitiated", source.ID, t.ID),
+				SourceAccountID:      t.SourceAccountID,
+				DestinationAccountID: t.DestinationAccountID,
+				Amount:               t.Amount,
+				DestinationAmount:    t.DestinationAmount,
+				ExchangeRate:         t.ExchangeRate,
+				QuoteID:              t.QuoteID,
+			})
+
+			// O débito, a transferência e os eventos são confirmados juntos
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		return t.ID, nil
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
+	// Tenta concluir a segunda etapa ainda nesta requisição. Se falhar, a
+	// transferência continua pendente e o worker a conclui ao consumir o
+	// evento TransferInitiated entregue pelo outbox.
+	if err := h.processor.Handle(ProcessTransferCommand{TransferID: t.ID}); err != nil {
+		log.Printf("Transferência %s pendente, será concluída pelo worker: %v", t.ID, err)
+	}
+
+	return t.ID, nil
+}
+
+// findQuote busca a cotação informada no comando e verifica se ela ainda pode
+// ser usada e se corresponde ao valor informado, quando houver
+func findQuote(tx persistence.Transaction, cmd TransferCommand) (*fx.Quote, error) {
+	quote, err := tx.Quotes().FindByID(cmd.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, ErrQuoteNotFound
+	}
+	if quote.Used() {
+		return nil, fx.ErrQuoteAlreadyUsed
+	}
+	if quote.Expired(time.Now()) {
+		return nil, fx.ErrQuoteExpired
+	}
+
+	if cmd.Amount != "" {
+		currency := cmd.Currency
+		if currency == "" {
+			currency = quote.SourceAmount.Currency()
+		}
+		amount, err := parseAmount(cmd.Amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		if amount != quote.SourceAmount {
+			return nil, ErrQuoteMismatch
+		}
+	}
+	return quote, nil
+}
+
+// convert define o valor que o destino recebe. Na mesma moeda, é o próprio
+// valor transferido; entre moedas, vale a cotação ou, sem ela, a taxa atual.
+func (h *TransferHandler) convert(t *transfer.Transfer, destination *account.Account, quote *fx.Quote) error {
+	if quote != nil {
+		if quote.DestinationAmount.Currency() != destination.Currency() {
+			return ErrQuoteMismatch
+		}
+		return t.Convert(quote.Rate, quote.DestinationAmount, quote.ID)
+	}
+	if t.Amount.Currency() == destination.Currency() {
+		return nil
+	}
+
+	rate, err := lookupRate(h.rates, t.Amount.Currency(), destination.Currency())
+	if err != nil {
+		return err
+	}
+	converted, err := rate.Convert(t.Amount)
+	if err != nil {
+		return err
+	}
+	return t.Convert(rate, converted, "")
+}
@@ -0,0 +1,206 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// CreateScheduleCommand representa o comando para agendar uma transferência,
+// única ou recorrente, a partir da conta AccountID
+type CreateScheduleCommand struct {
+	AccountID            string      `json:"account_id"`
+	DestinationAccountID string      `json:"destination_account_id"`
+	Amount               json.Number `json:"amount"`
+	Currency             string      `json:"currency"`              // Opcional; precisa ser a moeda da conta de origem
+	Frequency            string      `json:"frequency"`             // once, daily, weekly ou monthly
+	StartDate            string      `json:"start_date"`            // Data da primeira ocorrência (YYYY-MM-DD)
+	EndDate              string      `json:"end_date"`              // Opcional; última data em que pode haver ocorrência
+	OnInsufficientFunds  string      `json:"on_insufficient_funds"` // retry ou skip; padrão: retry
+	MaxAttempts          int         `json:"max_attempts"`          // Tentativas por ocorrência com retry; padrão: 3
+}
+
+// CreateScheduleHandler manipula o comando de agendamento de transferência
+type CreateScheduleHandler struct {
+	uow persistence.UnitOfWork
+}
+
+// NewCreateScheduleHandler cria um novo manipulador de agendamento de transferência
+func NewCreateScheduleHandler(uow persistence.UnitOfWork) *CreateScheduleHandler {
+	return &CreateScheduleHandler{uow: uow}
+}
+
+// Handle executa o comando de agendamento e retorna o ID do agendamento criado
+func (h *CreateScheduleHandler) Handle(cmd CreateScheduleCommand) (string, error) {
+	// Sem moeda, o valor é interpretado na moeda da conta de origem; até a
+	// conta ser carregada, o agendamento é validado com o valor sem moeda
+	amount, err := checkAmount(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return "", err
+	}
+	if cmd.AccountID == cmd.DestinationAccountID {
+		return "", ErrSameAccount
+	}
+	startDate, err := parseDate(cmd.StartDate)
+	if err != nil {
+		return "", err
+	}
+	var endDate *time.Time
+	if cmd.EndDate != "" {
+		end, err := parseDate(cmd.EndDate)
+		if err != nil {
+			return "", err
+		}
+		endDate = &end
+	}
+
+	s, err := schedule.NewSchedule(cmd.AccountID, cmd.DestinationAccountID, amount,
+		schedule.Frequency(cmd.Frequency), startDate, endDate, cmd.OnInsufficientFunds, cmd.MaxAttempts, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	err = h.uow.Do(func(tx persistence.Transaction) error {
+		source, err := tx.Accounts().FindByID(cmd.AccountID)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return ErrAccountNotFound
+		}
+		amount, err := accountAmount(source, cmd.Amount, cmd.Currency)
+		if err != nil {
+			return err
+		}
+		s.Amount = amount
+
+		destination, err := tx.Accounts().FindByID(cmd.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+		if destination == nil {
+			return ErrAccountNotFound
+		}
+
+		return tx.Schedules().Save(s)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.ID, nil
+}
+
+// UpdateScheduleCommand representa o comando para alterar um agendamento ativo.
+// A periodicidade e a data inicial não mudam; para isso, cancela-se o agendamento
+// e cria-se outro.
+type UpdateScheduleCommand struct {
+	AccountID           string      `json:"account_id"`
+	ScheduleID          string      `json:"schedule_id"`
+	Amount              json.Number `json:"amount"`
+	Currency            string      `json:"currency"`
+	EndDate             string      `json:"end_date"`
+	OnInsufficientFunds string      `json:"on_insufficient_funds"`
+	MaxAttempts         int         `json:"max_attempts"`
+}
+
+// UpdateScheduleHandler manipula o comando de alteração de agendamento
+type UpdateScheduleHandler struct {
+	uow persistence.UnitOfWork
+}
+
+// NewUpdateScheduleHandler cria um novo manipulador de alteração de agendamento
+func NewUpdateScheduleHandler(uow persistence.UnitOfWork) *UpdateScheduleHandler {
+	return &UpdateScheduleHandler{uow: uow}
+}
+
+// Handle executa o comando de alteração de agendamento
+func (h *UpdateScheduleHandler) Handle(cmd UpdateScheduleCommand) error {
+	if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
+		return err
+	}
+	var endDate *time.Time
+	if cmd.EndDate != "" {
+		end, err := parseDate(cmd.EndDate)
+		if err != nil {
+			return err
+		}
+		endDate = &end
+	}
+
+	return changeSchedule(h.uow, cmd.AccountID, cmd.ScheduleID, func(s *schedule.Schedule) error {
+		// A moeda do agendamento é a da conta de origem; sem moeda, o valor é
+		// interpretado nela
+		currency := cmd.Currency
+		if currency == "" {
+			currency = s.Amount.Currency()
+		}
+		amount, err := parseAmount(cmd.Amount, currency)
+		if err != nil {
+			return err
+		}
+		if amount.Currency() != s.Amount.Currency() {
+			return account.ErrCurrencyMismatch
+		}
+		return s.Change(amount, endDate, cmd.OnInsufficientFunds, cmd.MaxAttempts, time.Now())
+	})
+}
+
+// CancelScheduleCommand representa o comando para cancelar um agendamento
+type CancelScheduleCommand struct {
+	AccountID  string `json:"account_id"`
+	ScheduleID string `json:"schedule_id"`
+}
+
+// CancelScheduleHandler manipula o comando de cancelamento de agendamento
+type CancelScheduleHandler struct {
+	uow persistence.UnitOfWork
+}
+
+// NewCancelScheduleHandler cria um novo manipulador de cancelamento de agendamento
+func NewCancelScheduleHandler(uow persistence.UnitOfWork) *CancelScheduleHandler {
+	return &CancelScheduleHandler{uow: uow}
+}
+
+// Handle cancela o agendamento; o registro é mantido com o status cancelled
+func (h *CancelScheduleHandler) Handle(cmd CancelScheduleCommand) error {
+	return changeSchedule(h.uow, cmd.AccountID, cmd.ScheduleID, func(s *schedule.Schedule) error {
+		return s.Cancel(time.Now())
+	})
+}
+
+// changeSchedule carrega o agendamento da conta, aplica a alteração e o grava.
+// Um conflito de versão, como uma execução concorrente, é informado como
+// ErrConcurrentUpdate para que o cliente repita a alteração.
+func changeSchedule(uow persistence.UnitOfWork, accountID, scheduleID string, change func(s *schedule.Schedule) error) error {
+	err := uow.Do(func(tx persistence.Transaction) error {
+		s, err := tx.Schedules().FindByID(scheduleID)
+		if err != nil {
+			return err
+		}
+		if s == nil || s.SourceAccountID != accountID {
+			return ErrScheduleNotFound
+		}
+		if err := change(s); err != nil {
+			return err
+		}
+		return tx.Schedules().Update(s)
+	})
+	if errors.Is(err, schedule.ErrVersionConflict) {
+		return ErrConcurrentUpdate
+	}
+	return err
+}
+
+// parseDate interpreta uma data no formato YYYY-MM-DD, em UTC
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(schedule.DateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return date, nil
+}
@@ -0,0 +1,327 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+func TestTransferHandler_Handle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000)
+	destination := newTestAccount("destination", 2000)
+
+	var saved *transfer.Transfer
+
+	// Mock: buscar contas
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+
+	// Mock: atualizar contas
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: salvar e recarregar a transferência
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(nil)
+
+	// Mock: salvar eventos no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("transfer.TransferInitiatedEvent")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountDepositedEvent")).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("transfer.TransferCompletedEvent")).Return(nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, transferID)
+	assert.Equal(t, transfer.StatusCompleted, saved.Status)
+	assert.Equal(t, account.NewMoney(7000, account.DefaultCurrency), source.Balance)      // 100 - 30
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), destination.Balance) // 20 + 30
+	assert.Equal(t, 2, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTransfers.AssertExpectations(t)
+}
+
+func TestTransferHandler_Handle_InsufficientFunds(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	// Mock: buscar contas
+	mockRepo.On("FindByID", "source").Return(newTestAccount("source", 1000), nil)
+	mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Empty(t, transferID)
+	assert.Equal(t, 1, uow.rollbacks)
+
+	mockRepo.AssertExpectations(t)
+	mockTransfers.AssertNotCalled(t, "Save", mock.Anything)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTransferHandler_Handle_SameAccount(t *testing.T) {
+	// Arrange
+	uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "source",
+		Amount:               "30.00",
+	})
+
+	// Assert
+	assert.Equal(t, ErrSameAccount, err)
+	assert.Empty(t, transferID)
+}
+
+func TestTransferHandler_Handle_DestinationNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	// Mock: destino inexistente
+	mockRepo.On("FindByID", "source").Return(newTestAccount("source", 10000), nil)
+	mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "missing",
+		Amount:               "30.00",
+	})
+
+	// Assert: nada é debitado
+	assert.Equal(t, ErrAccountNotFound, err)
+	assert.Empty(t, transferID)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTransferHandler_Handle_SecondStepFailureLeavesTransferPending(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewTransferHandler(uow, nil, NewProcessTransferHandler(uow, nil), nil)
+
+	source := newTestAccount("source", 10000)
+	var saved *transfer.Transfer
+
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+	mockOutbox.On("Save", mock.Anything).Return(nil)
+
+	// Mock: a segunda etapa falha por indisponibilidade do banco
+	mockTransfers.On("Save", mock.AnythingOfType("*transfer.Transfer")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*transfer.Transfer)
+		mockTransfers.On("FindByID", saved.ID).Return(nil, errors.New("connection reset"))
+	}).Return(nil)
+
+	// Act
+	transferID, err := handler.Handle(TransferCommand{
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               "30.00",
+	})
+
+	// Assert: o débito foi confirmado e a transferência fica pendente para o worker
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, transferID)
+	assert.Equal(t, transfer.StatusPending, saved.Status)
+	assert.NotEmpty(t, saved.DebitEventID)
+	assert.Equal(t, account.NewMoney(7000, account.DefaultCurrency), source.Balance)
+	mockOutbox.AssertCalled(t, "Save", mock.AnythingOfType("transfer.TransferInitiatedEvent"))
+}
+
+func TestProcessTransferHandler_Handle_CompensatesWhenDestinationRejectsCredit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+	mockFees := new(MockFeeRepository)
+	mockLimits := new(MockLimitRepository)
+
+	var entries []*ledger.JournalEntry
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	uow.ledger = recordingLedger(&entries)
+	uow.fees = mockFees
+	uow.limits = mockLimits
+	handler := NewProcessTransferHandler(uow, nil)
+
+	// A origem de R$ 100,00 já pagou R$ 30,00 e a tarifa de R$ 1,00, e o
+	// destino foi bloqueado antes do crédito
+	source := newTestAccount("source", 6900)
+	destination := newTestAccount("destination", 0)
+	destination.Status = account.StatusBlocked
+	feeEntry, _ := ledger.NewFeeEntry("fee-1", "source", account.NewMoney(100, account.DefaultCurrency))
+
+	pending := &transfer.Transfer{
+		ID:                   "transfer-1",
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               account.NewMoney(3000, account.DefaultCurrency),
+		DestinationAmount:    account.NewMoney(3000, account.DefaultCurrency),
+		DebitEventID:         "debit-1",
+		Status:               transfer.StatusPending,
+	}
+
+	mockTransfers.On("FindByID", "transfer-1").Return(pending, nil)
+	mockTransfers.On("Update", pending).Return(nil)
+	mockRepo.On("FindByID", "destination").Return(destination, nil)
+	mockRepo.On("FindByID", "source").Return(source, nil)
+	mockRepo.On("Update", source).Return(nil)
+	mockFees.On("FindCharge", "source", fee.KindTransfer, "transfer-1").
+		Return(&fee.Charge{EventID: "fee-1", AccountID: "source", Kind: fee.KindTransfer, Amount: account.NewMoney(100, account.DefaultCurrency), Reference: "transfer-1"}, nil)
+	uow.ledger.On("FindByEventID", "fee-1").Return(feeEntry, nil)
+	mockLimits.On("Release", "source", "debit-1").Return(nil)
+	mockOutbox.On("Save", mock.MatchedBy(func(e account.AccountDepositedEvent) bool {
+		return e.AccountID == "source" && e.TransferID == "transfer-1"
+	})).Return(nil)
+	mockOutbox.On("Save", mock.MatchedBy(func(e account.TransactionReversedEvent) bool {
+		return e.OriginalEventID == "fee-1" && e.OriginalEventType == "FeeCharged"
+	})).Return(nil)
+	mockOutbox.On("Save", mock.AnythingOfType("transfer.TransferFailedEvent")).Return(nil)
+
+	// Act
+	err := handler.Handle(ProcessTransferCommand{TransferID: "transfer-1"})
+
+	// Assert: o valor e a tarifa voltam para a origem e a transferência falha
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.StatusFailed, pending.Status)
+	assert.Contains(t, pending.FailureReason, "account is not active")
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), source.Balance)
+	assert.Equal(t, account.Zero(account.DefaultCurrency), destination.Balance)
+
+	// O razão devolve o valor e estorna a tarifa; o débito sai do uso dos limites
+	assert.Equal(t, map[string]int64{
+		"source":                         3000,
+		ledger.TransfersInTransitAccount: -3000,
+		ledger.FeeIncomeAccount:          0,
+	}, ledgerBalances(append([]*ledger.JournalEntry{feeEntry}, entries...)))
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTransfers.AssertExpectations(t)
+	mockLimits.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", destination)
+}
+
+func TestProcessTransferHandler_Handle_AlreadyFinalized(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, new(MockOutboxRepository))
+	uow.transfers = mockTransfers
+	handler := NewProcessTransferHandler(uow, nil)
+
+	mockTransfers.On("FindByID", "transfer-1").Return(&transfer.Transfer{
+		ID:     "transfer-1",
+		Status: transfer.StatusCompleted,
+	}, nil)
+
+	// Act
+	err := handler.Handle(ProcessTransferCommand{TransferID: "transfer-1"})
+
+	// Assert: reentrega do evento não credita duas vezes
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	mockTransfers.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestProcessTransferHandler_Handle_ConcurrentFinalization(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	uow.transfers = mockTransfers
+	handler := NewProcessTransferHandler(uow, nil)
+
+	mockTransfers.On("FindByID", "transfer-1").Return(&transfer.Transfer{
+		ID:                   "transfer-1",
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               account.NewMoney(3000, account.DefaultCurrency),
+		DestinationAmount:    account.NewMoney(3000, account.DefaultCurrency),
+		Status:               transfer.StatusPending,
+	}, nil)
+	mockRepo.On("FindByID", "destination").Return(newTestAccount("destination", 0), nil)
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: outra execução finalizou a transferência primeiro
+	mockTransfers.On("Update", mock.AnythingOfType("*transfer.Transfer")).Return(transfer.ErrAlreadyFinalized)
+
+	// Act
+	err := handler.Handle(ProcessTransferCommand{TransferID: "transfer-1"})
+
+	// Assert: o crédito desta execução é desfeito e nenhum evento é gravado
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uow.rollbacks)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestProcessTransferHandler_Handle_TransferNotFound(t *testing.T) {
+	// Arrange
+	mockTransfers := new(MockTransferRepository)
+
+	uow := newMockUnitOfWork(new(MockRepository), new(MockOutboxRepository))
+	uow.transfers = mockTransfers
+	handler := NewProcessTransferHandler(uow, nil)
+
+	mockTransfers.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	err := handler.Handle(ProcessTransferCommand{TransferID: "missing"})
+
+	// Assert
+	assert.Equal(t, ErrTransferNotFound, err)
+}
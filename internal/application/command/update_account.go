@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/viniciuslima/account-EDA/internal/application/event"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+// updateAccount carrega a conta, aplica a alteração de domínio e grava a conta
+// e os eventos gerados na mesma transação, repetindo em caso de conflito de versão
+func updateAccount(uow persistence.UnitOfWork, publisher event.Publisher, accountID string, change func(acc *account.Account) error) error {
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return uow.Do(func(tx persistence.Transaction) error {
+			// Buscar a conta
+			acc, err := tx.Accounts().FindByID(accountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+
+			// Aplicar a alteração
+			if err := change(acc); err != nil {
+				return err
+			}
+			events = acc.Changes()
+			if len(events) == 0 {
+				// Nada mudou, por exemplo se outra execução já aplicou a alteração
+				return nil
+			}
+
+			// Atualizar a conta
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Salvar o evento no outbox na mesma transação da atualização
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(uow, publisher, events...)
+
+	return nil
+}
@@ -1,81 +1,111 @@
 package command
 
 import (
-	"time"
-
-	"github.com/google/uuid"
+	"encoding/json"
 
 	"github.com/viniciuslima/account-EDA/internal/application/event"
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
 // WithdrawCommand representa o comando para sacar de uma conta
 type WithdrawCommand struct {
-	AccountID string  `json:"account_id"`
-	Amount    float64 `json:"amount"`
+	AccountID string      `json:"account_id"`
+	Amount    json.Number `json:"amount"`
+	Currency  string      `json:"currency"` // Opcional; precisa ser a moeda da conta
 }
 
 // WithdrawHandler manipula o comando de saque
 type WithdrawHandler struct {
-	repository account.Repository
-	publisher  event.Publisher
+	uow       persistence.UnitOfWork
+	publisher event.Publisher
 }
 
 // NewWithdrawHandler cria um novo manipulador de saque
-func NewWithdrawHandler(repository account.Repository, publisher event.Publisher) *WithdrawHandler {
+func NewWithdrawHandler(uow persistence.UnitOfWork, publisher event.Publisher) *WithdrawHandler {
 	return &WithdrawHandler{
-		repository: repository,
-		publisher:  publisher,
+		uow:       uow,
+		publisher: publisher,
 	}
 }
 
 // Handle executa o comando de saque
 func (h *WithdrawHandler) Handle(cmd WithdrawCommand) error {
-	// Validar valor do saque
-	if cmd.Amount <= 0 {
-		return ErrInvalidAmount
-	}
-
-	// Buscar a conta
-	acc, err := h.repository.FindByID(cmd.AccountID)
-	if err != nil {
+	// Validar valor do saque; sem moeda, ele é interpretado na moeda da conta
+	if _, err := checkAmount(cmd.Amount, cmd.Currency); err != nil {
 		return err
 	}
-	if acc == nil {
-		return ErrAccountNotFound
-	}
 
-	// Verificar se há saldo suficiente
-	if acc.Balance < cmd.Amount {
-		return ErrInsufficientFunds
-	}
+	var events []account.Event
+	err := retryOnConflict(func() error {
+		return h.uow.Do(func(tx persistence.Transaction) error {
+			// Buscar a conta
+			acc, err := tx.Accounts().FindByID(cmd.AccountID)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return ErrAccountNotFound
+			}
+			amount, err := accountAmount(acc, cmd.Amount, cmd.Currency)
+			if err != nil {
+				return err
+			}
 
-	// Realizar o saque
-	if err := acc.Withdraw(cmd.Amount); err != nil {
-		return err
-	}
+			// Verificar se há saldo disponível suficiente para o saque e a tarifa
+			charge, err := feeFor(tx, acc, fee.KindWithdrawal)
+			if err != nil {
+				return err
+			}
+			if err := requireFunds(acc, amount, charge); err != nil {
+				return err
+			}
 
-	// Atualizar a conta
-	if err := h.repository.Update(acc); err != nil {
-		return err
-	}
+			// Verificar os limites de saque da conta sobre o uso recente
+			if err := checkWithdrawalLimits(tx, acc.ID, amount); err != nil {
+				return err
+			}
 
-	// Publicar evento de saque
-	event := account.AccountWithdrawnEvent{
-		BaseEvent: account.BaseEvent{
-			ID:        uuid.New().String(),
-			AccountID: acc.ID,
-			EventType: "AccountWithdrawn",
-			Timestamp: time.Now(),
-			AggrID:    acc.ID,
-		},
-		Amount:         cmd.Amount,
-		CurrentBalance: acc.Balance,
-	}
+			// Realizar o saque e cobrar a tarifa, referenciando o saque
+			if err := acc.Withdraw(amount); err != nil {
+				return err
+			}
+			if err := chargeFee(tx, acc, fee.KindWithdrawal, charge, acc.Changes()[0].EventID(), ""); err != nil {
+				return err
+			}
+			events = acc.Changes()
+
+			// Atualizar a conta
+			if err := tx.Accounts().Update(acc); err != nil {
+				return err
+			}
+
+			// Lançar a movimentação no razão na mesma transação
+			entry, err := ledger.NewWithdrawalEntry(events[0].EventID(), acc.ID, amount)
+			if err != nil {
+				return err
+			}
+			if err := tx.Ledger().Append(entry); err != nil {
+				return err
+			}
 
-	if err := h.publisher.Publish(event); err != nil {
-		// Log o erro, mas não falha a operação
+			// Contabilizar o saque no uso dos limites
+			if err := recordWithdrawalUsage(tx, acc.ID, events[0], amount); err != nil {
+				return err
+			}
+
+			// Salvar o evento no outbox na mesma transação da atualização
+			return recordEvents(tx, events...)
+		})
+	})
+	if err != nil {
+		return err
 	}
 
+	// Tenta publicar diretamente (para entrega imediata quando possível)
+	publishCommitted(h.uow, h.publisher, events...)
+
 	return nil
 }
@@ -14,19 +14,20 @@ func TestWithdrawHandler_Handle_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -36,30 +37,38 @@ func TestWithdrawHandler_Handle_Success(t *testing.T) {
 	// Mock: atualizar conta
 	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
 
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
+
 	// Mock: publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
 
+	// Mock: marcar evento como publicado no outbox
+	mockOutbox.On("MarkAsPublished", mock.AnythingOfType("string")).Return(nil)
+
 	// Act
 	err := handler.Handle(cmd)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 50.0, existingAccount.Balance) // 100 - 50
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance) // 100 - 50
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
 }
 
 func TestWithdrawHandler_Handle_InvalidAmount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    0, // Valor inválido
+		Amount:    "0", // Valor inválido
 	}
 
 	// Act
@@ -74,12 +83,13 @@ func TestWithdrawHandler_Handle_NegativeAmount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    -50.0, // Valor negativo
+		Amount:    "-50.00", // Valor negativo
 	}
 
 	// Act
@@ -94,12 +104,13 @@ func TestWithdrawHandler_Handle_AccountNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "non-existent-account",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	// Mock: conta não encontrada
@@ -119,12 +130,13 @@ func TestWithdrawHandler_Handle_AccountNotFound_NilReturn(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "non-existent-account",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	// Mock: conta não encontrada (retorna nil)
@@ -144,19 +156,20 @@ func TestWithdrawHandler_Handle_InsufficientFunds(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    150.0, // Valor maior que o saldo
+		Amount:    "150.00", // Valor maior que o saldo
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0, // Saldo menor que o valor solicitado
+		Balance: account.NewMoney(10000, account.DefaultCurrency), // Saldo menor que o valor solicitado
 		Status:  account.StatusActive,
 	}
 
@@ -177,12 +190,13 @@ func TestWithdrawHandler_Handle_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	// Mock: erro no repositório
@@ -202,19 +216,20 @@ func TestWithdrawHandler_Handle_UpdateError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -238,19 +253,20 @@ func TestWithdrawHandler_Handle_PublisherError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -260,6 +276,9 @@ func TestWithdrawHandler_Handle_PublisherError(t *testing.T) {
 	// Mock: atualizar conta
 	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
 
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
+
 	// Mock: erro ao publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(errors.New("publish error"))
 
@@ -267,30 +286,125 @@ func TestWithdrawHandler_Handle_PublisherError(t *testing.T) {
 	err := handler.Handle(cmd)
 
 	// Assert
-	assert.NoError(t, err)                         // A operação principal não deve falhar por erro de publicação
-	assert.Equal(t, 50.0, existingAccount.Balance) // 100 - 50
+	assert.NoError(t, err)                                                                    // A operação principal não deve falhar por erro de publicação
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance) // 100 - 50
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+
+	// O evento continua pendente no outbox para ser entregue pelo OutboxProcessor
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_OutboxSaveError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, mockPublisher)
+
+	cmd := WithdrawCommand{
+		AccountID: "account-123",
+		Amount:    "50.00",
+	}
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Name:    "João Silva",
+		Email:   "joao@example.com",
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+
+	// Mock: buscar conta
+	mockRepo.On("FindByID", cmd.AccountID).Return(existingAccount, nil)
+
+	// Mock: atualizar conta
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: erro ao salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(errors.New("outbox error"))
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert: sem o evento gravado, a atualização do saldo é desfeita
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox error")
+	assert.Equal(t, 1, uow.rollbacks)
+	assert.Equal(t, 0, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_WithoutPublisher(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil) // Sem caminho rápido, apenas outbox
+
+	cmd := WithdrawCommand{
+		AccountID: "account-123",
+		Amount:    "50.00",
+	}
+
+	existingAccount := &account.Account{
+		ID:      "account-123",
+		Name:    "João Silva",
+		Email:   "joao@example.com",
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+	}
+
+	// Mock: buscar conta
+	mockRepo.On("FindByID", cmd.AccountID).Return(existingAccount, nil)
+
+	// Mock: atualizar conta
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
+
+	// Mock: salvar evento no outbox com o saldo resultante
+	mockOutbox.On("Save", mock.MatchedBy(func(e account.AccountWithdrawnEvent) bool {
+		return e.AccountID == "account-123" && e.CurrentBalance == account.NewMoney(5000, account.DefaultCurrency)
+	})).Return(nil)
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), existingAccount.Balance) // 100 - 50
+	assert.Equal(t, 1, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkAsPublished", mock.Anything)
 }
 
 func TestWithdrawHandler_Handle_InactiveAccount(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    50.0,
+		Amount:    "50.00",
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusInactive, // Conta inativa
 	}
 
@@ -311,19 +425,20 @@ func TestWithdrawHandler_Handle_ExactBalance(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 	mockPublisher := new(MockPublisher)
+	mockOutbox := new(MockOutboxRepository)
 
-	handler := NewWithdrawHandler(mockRepo, mockPublisher)
+	handler := NewWithdrawHandler(newMockUnitOfWork(mockRepo, mockOutbox), mockPublisher)
 
 	cmd := WithdrawCommand{
 		AccountID: "account-123",
-		Amount:    100.0, // Valor exato do saldo
+		Amount:    "100.00", // Valor exato do saldo
 	}
 
 	existingAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0, // Saldo exato
+		Balance: account.NewMoney(10000, account.DefaultCurrency), // Saldo exato
 		Status:  account.StatusActive,
 	}
 
@@ -333,16 +448,101 @@ func TestWithdrawHandler_Handle_ExactBalance(t *testing.T) {
 	// Mock: atualizar conta
 	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(nil)
 
+	// Mock: salvar evento no outbox
+	mockOutbox.On("Save", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
+
 	// Mock: publicar evento
 	mockPublisher.On("Publish", mock.AnythingOfType("account.AccountWithdrawnEvent")).Return(nil)
 
+	// Mock: marcar evento como publicado no outbox
+	mockOutbox.On("MarkAsPublished", mock.AnythingOfType("string")).Return(nil)
+
 	// Act
 	err := handler.Handle(cmd)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 0.0, existingAccount.Balance) // 100 - 100
+	assert.Equal(t, account.NewMoney(0, account.DefaultCurrency), existingAccount.Balance) // 100 - 100
 
 	mockRepo.AssertExpectations(t)
 	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestWithdrawHandler_Handle_VersionConflictRechecksBalance(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	cmd := WithdrawCommand{
+		AccountID: "account-123",
+		Amount:    "80.00",
+	}
+
+	// Primeira leitura: saldo de 100, mas outro saque concorrente grava antes
+	staleAccount := &account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+		Version: 1,
+	}
+	// Releitura: o saque concorrente já reduziu o saldo para 20
+	currentAccount := &account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(2000, account.DefaultCurrency),
+		Status:  account.StatusActive,
+		Version: 2,
+	}
+
+	mockRepo.On("FindByID", cmd.AccountID).Return(staleAccount, nil).Once()
+	mockRepo.On("FindByID", cmd.AccountID).Return(currentAccount, nil).Once()
+	mockRepo.On("Update", staleAccount).Return(account.ErrVersionConflict).Once()
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert: a nova tentativa enxerga o saldo atualizado e recusa o saque
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Equal(t, 2, uow.rollbacks)
+	assert.Equal(t, 0, uow.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestWithdrawHandler_Handle_VersionConflictRetriesExhausted(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockOutbox := new(MockOutboxRepository)
+
+	uow := newMockUnitOfWork(mockRepo, mockOutbox)
+	handler := NewWithdrawHandler(uow, nil)
+
+	cmd := WithdrawCommand{
+		AccountID: "account-123",
+		Amount:    "10.00",
+	}
+
+	// Mock: cada tentativa relê a conta e perde a disputa para outra transação
+	for i := 0; i < maxConflictRetries; i++ {
+		mockRepo.On("FindByID", cmd.AccountID).Return(&account.Account{
+			ID:      "account-123",
+			Balance: account.NewMoney(10000, account.DefaultCurrency),
+			Status:  account.StatusActive,
+		}, nil).Once()
+	}
+	mockRepo.On("Update", mock.AnythingOfType("*account.Account")).Return(account.ErrVersionConflict)
+
+	// Act
+	err := handler.Handle(cmd)
+
+	// Assert
+	assert.Equal(t, ErrConcurrentUpdate, err)
+	assert.Equal(t, maxConflictRetries, uow.rollbacks)
+
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "Save", mock.Anything)
 }
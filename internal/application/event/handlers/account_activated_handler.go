@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// AccountActivatedHandler processa eventos de reativação de conta
+type AccountActivatedHandler struct {
+	// Dependências como serviços de notificação, cartões, etc.
+}
+
+// NewAccountActivatedHandler cria um novo handler para eventos de conta ativada
+func NewAccountActivatedHandler() *AccountActivatedHandler {
+	return &AccountActivatedHandler{}
+}
+
+// EventType retorna o tipo de evento que este handler processa
+func (h *AccountActivatedHandler) EventType() string {
+	return "AccountActivated"
+}
+
+// Handle processa o evento de conta ativada
+func (h *AccountActivatedHandler) Handle(ctx context.Context, eventData []byte) error {
+	var event account.AccountActivatedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return err
+	}
+
+	log.Printf("Processando reativação - Conta: %s", event.AccountID)
+
+	// Exemplos de processamento:
+
+	// 1. Avisar o cliente que a conta voltou a operar
+	// h.notificationService.SendAccountActivated(event.AccountID)
+
+	// 2. Reativar cartões suspensos no bloqueio
+	// h.cardService.ResumeCards(event.AccountID)
+
+	log.Printf("Reativação da conta %s processada com sucesso", event.AccountID)
+	return nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// AccountBlockedHandler processa eventos de bloqueio de conta
+type AccountBlockedHandler struct {
+	// Dependências como serviços de notificação, antifraude, etc.
+}
+
+// NewAccountBlockedHandler cria um novo handler para eventos de conta bloqueada
+func NewAccountBlockedHandler() *AccountBlockedHandler {
+	return &AccountBlockedHandler{}
+}
+
+// EventType retorna o tipo de evento que este handler processa
+func (h *AccountBlockedHandler) EventType() string {
+	return "AccountBlocked"
+}
+
+// Handle processa o evento de conta bloqueada
+func (h *AccountBlockedHandler) Handle(ctx context.Context, eventData []byte) error {
+	var event account.AccountBlockedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return err
+	}
+
+	log.Printf("Processando bloqueio - Conta: %s, Motivo: %s",
+		event.AccountID, event.Reason)
+
+	// Exemplos de processamento:
+
+	// 1. Avisar o cliente sobre o bloqueio
+	// h.notificationService.SendAccountBlocked(event.AccountID, event.Reason)
+
+	// 2. Suspender cartões e débitos automáticos vinculados
+	// h.cardService.SuspendCards(event.AccountID)
+
+	// 3. Abrir um caso para a equipe de compliance
+	// h.complianceService.OpenCase(event.AccountID, event.Reason)
+
+	log.Printf("Bloqueio da conta %s processado com sucesso", event.AccountID)
+	return nil
+}
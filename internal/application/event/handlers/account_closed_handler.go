@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// AccountClosedHandler processa eventos de encerramento de conta
+type AccountClosedHandler struct {
+	// Dependências como serviços de notificação, arquivamento, etc.
+}
+
+// NewAccountClosedHandler cria um novo handler para eventos de conta encerrada
+func NewAccountClosedHandler() *AccountClosedHandler {
+	return &AccountClosedHandler{}
+}
+
+// EventType retorna o tipo de evento que este handler processa
+func (h *AccountClosedHandler) EventType() string {
+	return "AccountClosed"
+}
+
+// Handle processa o evento de conta encerrada
+func (h *AccountClosedHandler) Handle(ctx context.Context, eventData []byte) error {
+	var event account.AccountClosedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return err
+	}
+
+	log.Printf("Processando encerramento - Conta: %s", event.AccountID)
+
+	// Exemplos de processamento:
+
+	// 1. Enviar o comprovante de encerramento
+	// h.notificationService.SendAccountClosed(event.AccountID)
+
+	// 2. Cancelar cartões e produtos vinculados
+	// h.cardService.CancelCards(event.AccountID)
+
+	// 3. Remover a conta de caches e relatórios ativos
+	// h.cache.InvalidateAccount(event.AccountID)
+
+	log.Printf("Encerramento da conta %s processado com sucesso", event.AccountID)
+	return nil
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
 )
 
+// highDepositThreshold é o valor a partir do qual um depósito é considerado alto.
+// Depósitos em outras moedas não são comparados com ele.
+var highDepositThreshold = account.NewMoney(10000_00, account.DefaultCurrency)
+
 // AccountDepositedHandler processa eventos de depósito em conta
 type AccountDepositedHandler struct {
 	// Dependências como serviços de notificação, análise de fraude, etc.
@@ -30,14 +34,14 @@ func (h *AccountDepositedHandler) Handle(ctx context.Context, eventData []byte)
 		return err
 	}
 
-	log.Printf("Processando depósito - Conta: %s, Valor: %.2f, Saldo Atual: %.2f",
+	log.Printf("Processando depósito - Conta: %s, Valor: %s, Saldo Atual: %s",
 		event.AccountID, event.Amount, event.CurrentBalance)
 
 	// Exemplos de processamento:
 
 	// 1. Verificar depósitos suspeitos (anti-fraude)
-	if event.Amount > 10000 {
-		log.Printf("ALERTA: Depósito alto detectado na conta %s: %.2f",
+	if cmp, err := event.Amount.Cmp(highDepositThreshold); err == nil && cmp > 0 {
+		log.Printf("ALERTA: Depósito alto detectado na conta %s: %s",
 			event.AccountID, event.Amount)
 		// h.fraudService.AnalyzeDeposit(event.AccountID, event.Amount)
 	}
@@ -8,6 +8,10 @@ import (
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
 )
 
+// lowBalanceThreshold é o saldo abaixo do qual o cliente é avisado.
+// Contas em outras moedas não são comparadas com ele.
+var lowBalanceThreshold = account.NewMoney(100_00, account.DefaultCurrency)
+
 // AccountWithdrawnHandler processa eventos de saque em conta
 type AccountWithdrawnHandler struct {
 	// Dependências como serviços de notificação, limites, etc.
@@ -30,7 +34,7 @@ func (h *AccountWithdrawnHandler) Handle(ctx context.Context, eventData []byte)
 		return err
 	}
 
-	log.Printf("Processando saque - Conta: %s, Valor: %.2f, Saldo Atual: %.2f",
+	log.Printf("Processando saque - Conta: %s, Valor: %s, Saldo Atual: %s",
 		event.AccountID, event.Amount, event.CurrentBalance)
 
 	// Exemplos de processamento:
@@ -40,20 +44,24 @@ func (h *AccountWithdrawnHandler) Handle(ctx context.Context, eventData []byte)
 	//     h.securityService.FlagSuspiciousActivity(event.AccountID, event.Amount)
 	// }
 
-	// 2. Notificar sobre saldo baixo
-	if event.CurrentBalance < 100 {
-		log.Printf("AVISO: Saldo baixo na conta %s: %.2f",
+	// 2. Notificar sobre saldo baixo ou uso do cheque especial
+	if event.CurrentBalance.IsNegative() {
+		log.Printf("AVISO: Conta %s usando cheque especial: %s",
+			event.AccountID, event.CurrentBalance)
+		// h.notificationService.SendOverdraftAlert(event.AccountID, event.CurrentBalance.Neg())
+	} else if cmp, err := event.CurrentBalance.Cmp(lowBalanceThreshold); err == nil && cmp < 0 {
+		log.Printf("AVISO: Saldo baixo na conta %s: %s",
 			event.AccountID, event.CurrentBalance)
 		// h.notificationService.SendLowBalanceAlert(event.AccountID, event.CurrentBalance)
 	}
 
-	// 3. Atualizar limites diários
-	// h.limitService.UpdateDailyWithdrawal(event.AccountID, event.Amount)
+	// Os limites de saque são verificados e contabilizados pelo próprio
+	// comando de saque, na mesma transação, e não dependem deste handler
 
-	// 4. Enviar comprovante por email
+	// 3. Enviar comprovante por email
 	// h.emailService.SendWithdrawalReceipt(event.AccountID, event.Amount, event.CurrentBalance)
 
-	// 5. Atualizar estatísticas de uso
+	// 4. Atualizar estatísticas de uso
 	// h.analyticsService.TrackWithdrawal(event.AccountID, event.Amount, time.Now())
 
 	log.Printf("Saque da conta %s processado com sucesso", event.AccountID)
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// TransactionHistoryHandler projeta depósitos, saques, capturas de reservas, juros, tarifas e estornos no modelo de leitura do
+// histórico de transações. A projeção é idempotente: o ID do evento identifica
+// a transação, então reentregas do Kafka não geram linhas duplicadas.
+type TransactionHistoryHandler struct {
+	repository query.TransactionHistoryRepository
+	eventType  string
+}
+
+// NewDepositHistoryHandler cria o handler que projeta eventos AccountDeposited
+func NewDepositHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "AccountDeposited"}
+}
+
+// NewWithdrawalHistoryHandler cria o handler que projeta eventos AccountWithdrawn
+func NewWithdrawalHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "AccountWithdrawn"}
+}
+
+// NewCaptureHistoryHandler cria o handler que projeta eventos HoldCaptured
+func NewCaptureHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "HoldCaptured"}
+}
+
+// NewInterestHistoryHandler cria o handler que projeta eventos InterestCredited
+func NewInterestHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "InterestCredited"}
+}
+
+// NewFeeHistoryHandler cria o handler que projeta eventos FeeCharged
+func NewFeeHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "FeeCharged"}
+}
+
+// NewReversalHistoryHandler cria o handler que projeta eventos TransactionReversed
+func NewReversalHistoryHandler(repository query.TransactionHistoryRepository) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{repository: repository, eventType: "TransactionReversed"}
+}
+
+// EventType retorna o tipo de evento que este handler processa
+func (h *TransactionHistoryHandler) EventType() string {
+	return h.eventType
+}
+
+// Handle grava a transação correspondente ao evento
+func (h *TransactionHistoryHandler) Handle(ctx context.Context, eventData []byte) error {
+	var record query.TransactionRecord
+	switch h.eventType {
+	case "AccountDeposited":
+		var event account.AccountDepositedEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		record = newTransactionRecord(event.BaseEvent, query.TransactionTypeDeposit, event.Amount, event.CurrentBalance, event.TransferID)
+	case "HoldCaptured":
+		var event account.HoldCapturedEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		record = newTransactionRecord(event.BaseEvent, query.TransactionTypeCapture, event.Amount, event.CurrentBalance, "")
+	case "InterestCredited":
+		var event account.InterestCreditedEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		record = newTransactionRecord(event.BaseEvent, query.TransactionTypeInterest, event.Amount, event.CurrentBalance, "")
+	case "FeeCharged":
+		var event account.FeeChargedEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		record = newTransactionRecord(event.BaseEvent, query.TransactionTypeFee, event.Amount, event.CurrentBalance, "")
+	case "TransactionReversed":
+		var event account.TransactionReversedEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		transactionType := query.TransactionTypeWithdrawalReversal
+		switch event.OriginalEventType {
+		case "AccountDeposited":
+			transactionType = query.TransactionTypeDepositReversal
+		case "FeeCharged":
+			transactionType = query.TransactionTypeFeeReversal
+		}
+		record = newTransactionRecord(event.BaseEvent, transactionType, event.Amount, event.CurrentBalance, "")
+	default:
+		var event account.AccountWithdrawnEvent
+		if err := json.Unmarshal(eventData, &event); err != nil {
+			return err
+		}
+		record = newTransactionRecord(event.BaseEvent, query.TransactionTypeWithdrawal, event.Amount, event.CurrentBalance, event.TransferID)
+	}
+
+	if err := h.repository.Append(record); err != nil {
+		return err
+	}
+
+	log.Printf("Transação %s da conta %s registrada no histórico", record.EventID, record.AccountID)
+	return nil
+}
+
+// newTransactionRecord monta a linha do histórico; o saldo corrente é o saldo
+// resultante informado pelo próprio evento
+func newTransactionRecord(base account.BaseEvent, transactionType string, amount, balance account.Money, transferID string) query.TransactionRecord {
+	return query.TransactionRecord{
+		EventID:      base.ID,
+		AccountID:    base.AccountID,
+		Type:         transactionType,
+		Amount:       amount,
+		BalanceAfter: balance,
+		TransferID:   transferID,
+		OccurredAt:   base.Timestamp,
+	}
+}
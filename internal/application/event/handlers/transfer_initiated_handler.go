@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// TransferInitiatedHandler conclui transferências cuja segunda etapa não foi
+// executada pela API (por exemplo, se o processo caiu logo após o débito)
+type TransferInitiatedHandler struct {
+	processor *command.ProcessTransferHandler
+}
+
+// NewTransferInitiatedHandler cria um novo handler para eventos de transferência iniciada
+func NewTransferInitiatedHandler(processor *command.ProcessTransferHandler) *TransferInitiatedHandler {
+	return &TransferInitiatedHandler{processor: processor}
+}
+
+// EventType retorna o tipo de evento que este handler processa
+func (h *TransferInitiatedHandler) EventType() string {
+	return "TransferInitiated"
+}
+
+// Handle processa o evento de transferência iniciada
+func (h *TransferInitiatedHandler) Handle(ctx context.Context, eventData []byte) error {
+	var event transfer.TransferInitiatedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return err
+	}
+
+	log.Printf("Processando transferência iniciada - ID: %s, Origem: %s, Destino: %s, Valor: %s",
+		event.AggregateID(), event.SourceAccountID, event.DestinationAccountID, event.Amount)
+
+	// Idempotente: se a API já concluiu a transferência, nada é feito.
+	// Em caso de erro o offset não é commitado e o evento será reprocessado.
+	return h.processor.Handle(command.ProcessTransferCommand{TransferID: event.AggregateID()})
+}
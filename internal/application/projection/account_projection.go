@@ -0,0 +1,119 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// AccountProjectionName identifica a projeção de contas nos checkpoints
+const AccountProjectionName = "accounts"
+
+// rebuildPageSize é o tamanho das páginas lidas na reconstrução da projeção
+const rebuildPageSize = 200
+
+// accountEventTypes são os eventos que alteram o estado de uma conta
+var accountEventTypes = map[string]bool{
+	"AccountCreated":        true,
+	"AccountDeposited":      true,
+	"AccountWithdrawn":      true,
+	"AccountBlocked":        true,
+	"AccountActivated":      true,
+	"AccountClosed":         true,
+	"HoldPlaced":            true,
+	"HoldCaptured":          true,
+	"HoldReleased":          true,
+	"OverdraftLimitChanged": true,
+	"InterestCredited":      true,
+	"FeeCharged":            true,
+	"TransactionReversed":   true,
+}
+
+// AccountProjection mantém o modelo de leitura das contas consultado por
+// query.AccountQuery.
+//
+// Um mesmo evento pode chegar duas vezes, pelo caminho rápido e pelo outbox, e
+// eventos de uma conta podem chegar fora de ordem quando a publicação imediata de
+// um deles falha. Por isso a projeção não soma os eventos à visão: cada evento
+// faz a conta ser lida no modelo de escrita e a visão ser regravada, e a visão
+// só é substituída por uma versão mais recente da conta.
+type AccountProjection struct {
+	accounts account.Repository
+	views    query.AccountViewRepository
+}
+
+// NewAccountProjection cria a projeção de contas
+func NewAccountProjection(accounts account.Repository, views query.AccountViewRepository) *AccountProjection {
+	return &AccountProjection{
+		accounts: accounts,
+		views:    views,
+	}
+}
+
+// Name retorna o nome da projeção
+func (p *AccountProjection) Name() string {
+	return AccountProjectionName
+}
+
+// Handles indica se o evento altera o estado de uma conta
+func (p *AccountProjection) Handles(eventType string) bool {
+	return accountEventTypes[eventType]
+}
+
+// Project atualiza a visão da conta do evento
+func (p *AccountProjection) Project(ctx context.Context, msg Message) error {
+	var event account.BaseEvent
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return err
+	}
+	if event.AccountID == "" {
+		return fmt.Errorf("event %s has no account_id", event.ID)
+	}
+
+	return p.Refresh(event.AccountID)
+}
+
+// Refresh regrava a visão da conta a partir do seu estado atual
+func (p *AccountProjection) Refresh(accountID string) error {
+	acc, err := p.accounts.FindByID(accountID)
+	if err != nil {
+		return err
+	}
+	if acc == nil {
+		log.Printf("Conta %s não encontrada no modelo de escrita, visão não atualizada", accountID)
+		return nil
+	}
+
+	return p.views.Save(acc)
+}
+
+// Rebuild regrava as visões de todas as contas, percorrendo o modelo de escrita
+// em páginas, e retorna quantas foram gravadas
+func (p *AccountProjection) Rebuild(ctx context.Context) (int, error) {
+	criteria := account.Criteria{SortBy: account.SortByCreatedAt, Limit: rebuildPageSize}
+	rebuilt := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return rebuilt, err
+		}
+
+		page, err := p.accounts.Find(criteria)
+		if err != nil {
+			return rebuilt, err
+		}
+		for _, acc := range page.Accounts {
+			if err := p.views.Save(acc); err != nil {
+				return rebuilt, err
+			}
+			rebuilt++
+		}
+		if page.Next == nil {
+			return rebuilt, nil
+		}
+		criteria.After = page.Next
+	}
+}
@@ -0,0 +1,147 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Position é a posição de uma mensagem no tópico de eventos
+type Position struct {
+	Topic     string
+	Partition int
+	Offset    int64
+}
+
+// Message é um evento entregue às projeções, com sua posição no tópico
+type Message struct {
+	EventType string
+	Payload   []byte
+	Position  Position
+}
+
+// Projection mantém um modelo de leitura a partir dos eventos. Project deve ser
+// idempotente: a mesma mensagem pode ser entregue de novo se o worker parar
+// entre a projeção e a gravação do checkpoint.
+type Projection interface {
+	// Name identifica a projeção nos checkpoints
+	Name() string
+
+	// Handles indica se a projeção processa o tipo de evento
+	Handles(eventType string) bool
+
+	// Project aplica o evento ao modelo de leitura
+	Project(ctx context.Context, msg Message) error
+}
+
+// Rebuilder é implementado pelas projeções capazes de reconstruir o modelo de
+// leitura inteiro, usado quando a projeção ainda não tem checkpoints
+type Rebuilder interface {
+	Rebuild(ctx context.Context) (int, error)
+}
+
+// CheckpointRepository persiste, por projeção e partição, a posição da última
+// mensagem processada
+type CheckpointRepository interface {
+	// Load retorna o offset da última mensagem processada na partição; found é
+	// falso se a projeção ainda não processou mensagens dela
+	Load(projection, topic string, partition int) (offset int64, found bool, err error)
+
+	// Save registra a mensagem processada; um offset menor que o gravado é ignorado
+	Save(projection string, position Position) error
+
+	// Exists indica se a projeção tem algum checkpoint gravado
+	Exists(projection string) (bool, error)
+}
+
+// Projector entrega as mensagens do tópico de eventos às projeções, pulando as
+// que cada projeção já processou segundo seus checkpoints. Não é seguro para uso
+// concorrente: o consumidor entrega uma mensagem por vez.
+type Projector struct {
+	checkpoints CheckpointRepository
+	projections []Projection
+	offsets     map[checkpointKey]int64 // Checkpoints já lidos ou gravados
+}
+
+// checkpointKey identifica o checkpoint de uma projeção em uma partição
+type checkpointKey struct {
+	projection string
+	topic      string
+	partition  int
+}
+
+// NewProjector cria um novo projetor com as projeções informadas
+func NewProjector(checkpoints CheckpointRepository, projections ...Projection) *Projector {
+	return &Projector{
+		checkpoints: checkpoints,
+		projections: projections,
+		offsets:     make(map[checkpointKey]int64),
+	}
+}
+
+// Bootstrap reconstrói os modelos de leitura das projeções que ainda não têm
+// checkpoints, como na primeira execução, antes que o consumo comece
+func (p *Projector) Bootstrap(ctx context.Context) error {
+	for _, projection := range p.projections {
+		rebuilder, ok := projection.(Rebuilder)
+		if !ok {
+			continue
+		}
+		exists, err := p.checkpoints.Exists(projection.Name())
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		rebuilt, err := rebuilder.Rebuild(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao reconstruir a projeção %s: %w", projection.Name(), err)
+		}
+		log.Printf("Projeção %s reconstruída: %d registros", projection.Name(), rebuilt)
+	}
+	return nil
+}
+
+// Project entrega a mensagem a cada projeção que ainda não a processou e avança
+// o checkpoint de cada uma, inclusive quando a projeção ignora o tipo de evento
+func (p *Projector) Project(ctx context.Context, msg Message) error {
+	for _, projection := range p.projections {
+		key := checkpointKey{projection: projection.Name(), topic: msg.Position.Topic, partition: msg.Position.Partition}
+		offset, found, err := p.checkpoint(key)
+		if err != nil {
+			return err
+		}
+		if found && msg.Position.Offset <= offset {
+			continue
+		}
+
+		if projection.Handles(msg.EventType) {
+			if err := projection.Project(ctx, msg); err != nil {
+				return fmt.Errorf("erro na projeção %s: %w", projection.Name(), err)
+			}
+		}
+
+		if err := p.checkpoints.Save(projection.Name(), msg.Position); err != nil {
+			return err
+		}
+		p.offsets[key] = msg.Position.Offset
+	}
+	return nil
+}
+
+// checkpoint retorna o checkpoint da projeção na partição, lendo-o do
+// repositório apenas na primeira mensagem da partição
+func (p *Projector) checkpoint(key checkpointKey) (int64, bool, error) {
+	if offset, ok := p.offsets[key]; ok {
+		return offset, true, nil
+	}
+
+	offset, found, err := p.checkpoints.Load(key.projection, key.topic, key.partition)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	p.offsets[key] = offset
+	return offset, true, nil
+}
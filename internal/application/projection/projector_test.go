@@ -0,0 +1,263 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// MockCheckpointRepository é um mock dos checkpoints das projeções
+type MockCheckpointRepository struct {
+	mock.Mock
+}
+
+func (m *MockCheckpointRepository) Load(projection, topic string, partition int) (int64, bool, error) {
+	args := m.Called(projection, topic, partition)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
+func (m *MockCheckpointRepository) Save(projection string, position Position) error {
+	args := m.Called(projection, position)
+	return args.Error(0)
+}
+
+func (m *MockCheckpointRepository) Exists(projection string) (bool, error) {
+	args := m.Called(projection)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockProjection é um mock de uma projeção capaz de se reconstruir
+type MockProjection struct {
+	mock.Mock
+}
+
+func (m *MockProjection) Name() string {
+	return "mock"
+}
+
+func (m *MockProjection) Handles(eventType string) bool {
+	return eventType == "AccountDeposited"
+}
+
+func (m *MockProjection) Project(ctx context.Context, msg Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+func (m *MockProjection) Rebuild(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// MockAccountRepository é um mock do modelo de escrita das contas
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountRepository) Save(acc *account.Account) error {
+	args := m.Called(acc)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindByID(id string) (*account.Account, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByEmail(email string) (*account.Account, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*account.Page), args.Error(1)
+}
+
+func (m *MockAccountRepository) Update(acc *account.Account) error {
+	args := m.Called(acc)
+	return args.Error(0)
+}
+
+// MockAccountViewRepository é um mock do modelo de leitura das contas
+type MockAccountViewRepository struct {
+	MockAccountRepository
+}
+
+// message cria uma mensagem da partição 0 do tópico de eventos
+func message(eventType string, offset int64, payload []byte) Message {
+	return Message{
+		EventType: eventType,
+		Payload:   payload,
+		Position:  Position{Topic: "account-events", Partition: 0, Offset: offset},
+	}
+}
+
+func TestProjector_Project_SkipsProcessedMessages(t *testing.T) {
+	// Arrange
+	checkpoints := new(MockCheckpointRepository)
+	mockProjection := new(MockProjection)
+	projector := NewProjector(checkpoints, mockProjection)
+
+	checkpoints.On("Load", "mock", "account-events", 0).Return(int64(10), true, nil).Once()
+	checkpoints.On("Save", "mock", mock.Anything).Return(nil)
+	mockProjection.On("Project", mock.Anything).Return(nil)
+
+	// Act: a mensagem 10 já foi processada antes do reinício; a 11 não
+	err := projector.Project(context.Background(), message("AccountDeposited", 10, nil))
+	assert.NoError(t, err)
+	err = projector.Project(context.Background(), message("AccountDeposited", 11, nil))
+	assert.NoError(t, err)
+
+	// Assert: apenas a 11 é projetada, e o checkpoint é lido uma única vez
+	mockProjection.AssertNumberOfCalls(t, "Project", 1)
+	mockProjection.AssertCalled(t, "Project", message("AccountDeposited", 11, nil))
+	checkpoints.AssertCalled(t, "Save", "mock", Position{Topic: "account-events", Offset: 11})
+	checkpoints.AssertNumberOfCalls(t, "Load", 1)
+}
+
+func TestProjector_Project_AdvancesCheckpointOfIgnoredEvents(t *testing.T) {
+	// Arrange
+	checkpoints := new(MockCheckpointRepository)
+	mockProjection := new(MockProjection)
+	projector := NewProjector(checkpoints, mockProjection)
+
+	checkpoints.On("Load", "mock", "account-events", 0).Return(int64(0), false, nil)
+	checkpoints.On("Save", "mock", mock.Anything).Return(nil)
+
+	// Act: a projeção não processa transferências
+	err := projector.Project(context.Background(), message("TransferInitiated", 0, nil))
+
+	// Assert: o checkpoint avança mesmo assim
+	assert.NoError(t, err)
+	mockProjection.AssertNotCalled(t, "Project", mock.Anything)
+	checkpoints.AssertCalled(t, "Save", "mock", Position{Topic: "account-events", Offset: 0})
+}
+
+func TestProjector_Project_KeepsCheckpointOnError(t *testing.T) {
+	// Arrange
+	checkpoints := new(MockCheckpointRepository)
+	mockProjection := new(MockProjection)
+	projector := NewProjector(checkpoints, mockProjection)
+
+	checkpoints.On("Load", "mock", "account-events", 0).Return(int64(4), true, nil)
+	mockProjection.On("Project", mock.Anything).Return(errors.New("connection refused"))
+
+	// Act
+	err := projector.Project(context.Background(), message("AccountDeposited", 5, nil))
+
+	// Assert: a mensagem será projetada de novo na próxima tentativa
+	assert.Error(t, err)
+	checkpoints.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestProjector_Bootstrap(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		rebuild bool
+	}{
+		{"sem checkpoints reconstrói", false, true},
+		{"com checkpoints não reconstrói", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			checkpoints := new(MockCheckpointRepository)
+			mockProjection := new(MockProjection)
+			projector := NewProjector(checkpoints, mockProjection)
+
+			checkpoints.On("Exists", "mock").Return(tt.exists, nil)
+			mockProjection.On("Rebuild").Return(3, nil)
+
+			// Act
+			err := projector.Bootstrap(context.Background())
+
+			// Assert
+			assert.NoError(t, err)
+			if tt.rebuild {
+				mockProjection.AssertCalled(t, "Rebuild")
+			} else {
+				mockProjection.AssertNotCalled(t, "Rebuild")
+			}
+		})
+	}
+}
+
+func TestAccountProjection_Project_RefreshesView(t *testing.T) {
+	// Arrange
+	accounts := new(MockAccountRepository)
+	views := new(MockAccountViewRepository)
+	accountProjection := NewAccountProjection(accounts, views)
+
+	current := &account.Account{ID: "account-123", Balance: account.NewMoney(15000, account.DefaultCurrency), Version: 3}
+	accounts.On("FindByID", "account-123").Return(current, nil)
+	views.On("Save", current).Return(nil)
+
+	payload, _ := json.Marshal(account.BaseEvent{ID: "event-1", AccountID: "account-123", EventType: "AccountDeposited"})
+
+	// Act: o evento pode ser antigo; a visão recebe o estado atual da conta
+	err := accountProjection.Project(context.Background(), message("AccountDeposited", 7, payload))
+
+	// Assert
+	assert.NoError(t, err)
+	views.AssertExpectations(t)
+}
+
+func TestAccountProjection_Project_AccountNotFound(t *testing.T) {
+	// Arrange
+	accounts := new(MockAccountRepository)
+	views := new(MockAccountViewRepository)
+	accountProjection := NewAccountProjection(accounts, views)
+
+	accounts.On("FindByID", "account-123").Return(nil, nil)
+	payload, _ := json.Marshal(account.BaseEvent{ID: "event-1", AccountID: "account-123", EventType: "AccountCreated"})
+
+	// Act
+	err := accountProjection.Project(context.Background(), message("AccountCreated", 0, payload))
+
+	// Assert: nada a gravar, e o consumo não é bloqueado
+	assert.NoError(t, err)
+	views.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAccountProjection_Rebuild(t *testing.T) {
+	// Arrange
+	accounts := new(MockAccountRepository)
+	views := new(MockAccountViewRepository)
+	accountProjection := NewAccountProjection(accounts, views)
+
+	first := &account.Account{ID: "account-1"}
+	second := &account.Account{ID: "account-2"}
+	cursor := &account.Cursor{Value: "2026-10-01 00:00:00", ID: "account-1"}
+	accounts.On("Find", account.Criteria{SortBy: account.SortByCreatedAt, Limit: rebuildPageSize}).
+		Return(&account.Page{Accounts: []*account.Account{first}, Next: cursor}, nil)
+	accounts.On("Find", account.Criteria{SortBy: account.SortByCreatedAt, Limit: rebuildPageSize, After: cursor}).
+		Return(&account.Page{Accounts: []*account.Account{second}}, nil)
+	views.On("Save", mock.Anything).Return(nil)
+
+	// Act
+	rebuilt, err := accountProjection.Rebuild(context.Background())
+
+	// Assert: as duas páginas são gravadas
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rebuilt)
+	views.AssertCalled(t, "Save", first)
+	views.AssertCalled(t, "Save", second)
+}
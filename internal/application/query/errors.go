@@ -4,5 +4,13 @@ import "errors"
 
 // Erros comuns para consultas
 var (
-	ErrAccountNotFound = errors.New("account not found")
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrTransferNotFound    = errors.New("transfer not found")
+	ErrScheduleNotFound    = errors.New("transfer schedule not found")
+	ErrInvalidCursor       = errors.New("invalid pagination cursor")
+	ErrInvalidTimeRange    = errors.New("from must be before to")
+	ErrBalanceAtInFuture   = errors.New("at must not be in the future")
+	ErrInvalidStatusFilter = errors.New("status must be active, inactive, blocked or closed")
+	ErrInvalidBalanceRange = errors.New("min_balance must not exceed max_balance")
+	ErrInvalidCreatedRange = errors.New("created_from must not be after created_to")
 )
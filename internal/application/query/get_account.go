@@ -1,42 +1,159 @@
 package query
 
-import "github.com/viniciuslima/account-EDA/internal/domain/account"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
 
-// AccountQuery representa o serviço de consulta para contas
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// AccountQuery representa o serviço de consulta para contas. As consultas leem o
+// modelo de leitura mantido pela projeção de contas do worker e podem estar
+// alguns instantes atrás das operações confirmadas; apenas GetCurrent e
+// GetBalanceAt, que consulta o razão, leem o modelo de escrita.
 type AccountQuery interface {
 	// GetByID busca uma conta pelo ID
 	GetByID(id string) (*AccountDTO, error)
 
+	// GetCurrent busca o estado atual da conta no modelo de escrita, para as
+	// respostas que precisam refletir uma operação recém-confirmada
+	GetCurrent(id string) (*AccountDTO, error)
+
 	// GetByEmail busca uma conta pelo email
 	GetByEmail(email string) (*AccountDTO, error)
 
-	// GetAll busca todas as contas
-	GetAll() ([]*AccountDTO, error)
+	// GetAll busca uma página das contas que atendem aos filtros
+	GetAll(params AccountListParams) (*AccountPageDTO, error)
+
+	// GetBalanceAt retorna o saldo da conta no instante at
+	GetBalanceAt(id string, at time.Time) (*BalanceDTO, error)
 }
 
-// AccountDTO é o objeto de transferência de dados para a entidade Account
+// AccountDTO é o objeto de transferência de dados para a entidade Account.
+// Balance é mantido por compatibilidade e é igual a LedgerBalance.
 type AccountDTO struct {
-	ID      string  `json:"id"`
-	Name    string  `json:"name"`
-	Email   string  `json:"email"`
-	Balance float64 `json:"balance"`
-	Status  string  `json:"status"`
+	ID               string        `json:"id"`
+	Name             string        `json:"name"`
+	Email            string        `json:"email"`
+	Type             string        `json:"type"`     // Tipo da conta: checking ou savings
+	Currency         string        `json:"currency"` // Moeda da conta (ISO 4217)
+	Balance          account.Money `json:"balance"`
+	LedgerBalance    account.Money `json:"ledger_balance"`    // Saldo contábil, incluindo valores reservados
+	AvailableBalance account.Money `json:"available_balance"` // Saldo contábil mais o cheque especial, menos as reservas ativas
+	OverdraftLimit   account.Money `json:"overdraft_limit"`   // Quanto o saldo pode ficar negativo
+	Holds            []HoldDTO     `json:"holds"`
+	Status           string        `json:"status"`
+	Version          int64         `json:"version"`
+}
+
+// HoldDTO é o objeto de transferência de dados para uma reserva de saldo
+type HoldDTO struct {
+	ID        string        `json:"id"`
+	Amount    account.Money `json:"amount"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Limites de paginação da listagem de contas
+const (
+	DefaultAccountPageSize = 50
+	MaxAccountPageSize     = 200
+)
+
+// AccountListParams são os parâmetros da listagem de contas. Sort é um campo de
+// account.SortField, prefixado com "-" para ordem decrescente (padrão:
+// created_at). MinBalance e MaxBalance são valores na moeda Currency, ou na
+// moeda padrão se Currency estiver vazia.
+type AccountListParams struct {
+	Status      string
+	Email       string
+	Name        string
+	Currency    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinBalance  string
+	MaxBalance  string
+	Sort        string
+	Cursor      string
+	Limit       int
+}
+
+// AccountPageDTO é uma página da listagem de contas
+type AccountPageDTO struct {
+	Accounts      []*AccountDTO `json:"accounts"`
+	NextCursor    string        `json:"next_cursor,omitempty"`
+	TotalEstimate int64         `json:"total_estimate"` // Estimativa do total de contas que atendem aos filtros
+}
+
+// accountCursor é o cursor opaco da listagem: a ordenação em que foi gerado e a
+// posição da última conta da página
+type accountCursor struct {
+	Sort     string `json:"s"`
+	Value    string `json:"v"`
+	Currency string `json:"c,omitempty"`
+	ID       string `json:"id"`
+}
+
+// BalanceDTO é o saldo de uma conta em um instante passado
+type BalanceDTO struct {
+	AccountID string        `json:"account_id"`
+	Currency  string        `json:"currency"`
+	Balance   account.Money `json:"balance"`
+	At        time.Time     `json:"at"`
+}
+
+// AccountViewRepository persiste e consulta o modelo de leitura das contas,
+// alimentado pela projeção de contas a partir dos eventos
+type AccountViewRepository interface {
+	// Save grava a visão da conta; uma visão de versão igual ou mais recente é mantida
+	Save(acc *account.Account) error
+
+	// FindByID busca a visão da conta; retorna nil se não existir
+	FindByID(id string) (*account.Account, error)
+
+	// FindByEmail busca a visão da conta pelo e-mail; retorna nil se não existir
+	FindByEmail(email string) (*account.Account, error)
+
+	// Find retorna uma página das visões que atendem aos critérios
+	Find(criteria account.Criteria) (*account.Page, error)
 }
 
 // AccountQueryHandler implementa AccountQuery
 type AccountQueryHandler struct {
+	views      AccountViewRepository
 	repository account.Repository
+	ledger     ledger.Repository
 }
 
-// NewAccountQueryHandler cria um novo manipulador de consultas de conta
-func NewAccountQueryHandler(repository account.Repository) *AccountQueryHandler {
+// NewAccountQueryHandler cria um novo manipulador de consultas de conta sobre o
+// modelo de leitura; repository é o modelo de escrita, usado apenas por
+// GetCurrent e GetBalanceAt
+func NewAccountQueryHandler(views AccountViewRepository, repository account.Repository, ledger ledger.Repository) *AccountQueryHandler {
 	return &AccountQueryHandler{
+		views:      views,
 		repository: repository,
+		ledger:     ledger,
 	}
 }
 
 // GetByID busca uma conta pelo ID
 func (h *AccountQueryHandler) GetByID(id string) (*AccountDTO, error) {
+	acc, err := h.views.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	return mapToDTO(acc), nil
+}
+
+// GetCurrent busca o estado atual da conta no modelo de escrita
+func (h *AccountQueryHandler) GetCurrent(id string) (*AccountDTO, error) {
 	acc, err := h.repository.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -50,7 +167,7 @@ func (h *AccountQueryHandler) GetByID(id string) (*AccountDTO, error) {
 
 // GetByEmail busca uma conta pelo email
 func (h *AccountQueryHandler) GetByEmail(email string) (*AccountDTO, error) {
-	acc, err := h.repository.FindByEmail(email)
+	acc, err := h.views.FindByEmail(email)
 	if err != nil {
 		return nil, err
 	}
@@ -61,28 +178,209 @@ func (h *AccountQueryHandler) GetByEmail(email string) (*AccountDTO, error) {
 	return mapToDTO(acc), nil
 }
 
-// GetAll busca todas as contas
-func (h *AccountQueryHandler) GetAll() ([]*AccountDTO, error) {
-	accounts, err := h.repository.FindAll()
+// GetAll busca uma página das contas que atendem aos filtros, na ordenação
+// pedida, a partir do cursor da página anterior
+func (h *AccountQueryHandler) GetAll(params AccountListParams) (*AccountPageDTO, error) {
+	criteria, err := accountCriteria(params)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := h.views.Find(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &AccountPageDTO{
+		Accounts:      make([]*AccountDTO, 0, len(page.Accounts)),
+		TotalEstimate: page.TotalEstimate,
+	}
+	for _, acc := range page.Accounts {
+		dto.Accounts = append(dto.Accounts, mapToDTO(acc))
+	}
+	if page.Next != nil {
+		dto.NextCursor = encodeAccountCursor(accountCursor{Sort: sortParam(criteria), Value: page.Next.Value, Currency: page.Next.Currency, ID: page.Next.ID})
+	}
+
+	return dto, nil
+}
+
+// accountCriteria valida os parâmetros da listagem e os converte nos critérios
+// do repositório
+func accountCriteria(params AccountListParams) (account.Criteria, error) {
+	criteria := account.Criteria{
+		Status:      account.AccountStatus(params.Status),
+		Email:       params.Email,
+		Name:        params.Name,
+		Currency:    params.Currency,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		SortBy:      account.SortByCreatedAt,
+		Limit:       params.Limit,
+	}
+
+	switch criteria.Status {
+	case "", account.StatusActive, account.StatusInactive, account.StatusBlocked, account.StatusClosed:
+	default:
+		return criteria, ErrInvalidStatusFilter
+	}
+	if criteria.Currency != "" {
+		if err := account.ValidateCurrency(criteria.Currency); err != nil {
+			return criteria, err
+		}
+	}
+	if params.CreatedFrom != nil && params.CreatedTo != nil && params.CreatedFrom.After(*params.CreatedTo) {
+		return criteria, ErrInvalidCreatedRange
+	}
+
+	currency := criteria.Currency
+	if currency == "" {
+		currency = account.DefaultCurrency
+	}
+	var err error
+	if criteria.MinBalance, err = parseBalanceParam(params.MinBalance, currency); err != nil {
+		return criteria, err
+	}
+	if criteria.MaxBalance, err = parseBalanceParam(params.MaxBalance, currency); err != nil {
+		return criteria, err
+	}
+	if criteria.MinBalance != nil && criteria.MaxBalance != nil {
+		if cmp, _ := criteria.MinBalance.Cmp(*criteria.MaxBalance); cmp > 0 {
+			return criteria, ErrInvalidBalanceRange
+		}
+	}
+
+	if params.Sort != "" {
+		criteria.Descending = strings.HasPrefix(params.Sort, "-")
+		criteria.SortBy = account.SortField(strings.TrimPrefix(params.Sort, "-"))
+		if err := account.ValidateSortField(criteria.SortBy); err != nil {
+			return criteria, err
+		}
+	}
+
+	if criteria.Limit <= 0 {
+		criteria.Limit = DefaultAccountPageSize
+	}
+	if criteria.Limit > MaxAccountPageSize {
+		criteria.Limit = MaxAccountPageSize
+	}
+
+	// O cursor só vale para a ordenação em que foi gerado; na ordenação por
+	// saldo, que agrupa as contas por moeda, ele traz também a moeda
+	if params.Cursor != "" {
+		cursor, err := decodeAccountCursor(params.Cursor)
+		if err != nil || cursor.Sort != sortParam(criteria) {
+			return criteria, ErrInvalidCursor
+		}
+		if criteria.SortBy == account.SortByBalance && cursor.Currency == "" {
+			return criteria, ErrInvalidCursor
+		}
+		criteria.After = &account.Cursor{Value: cursor.Value, Currency: cursor.Currency, ID: cursor.ID}
+	}
+
+	return criteria, nil
+}
+
+// parseBalanceParam interpreta um limite de saldo da listagem; vazio significa sem limite
+func parseBalanceParam(value, currency string) (*account.Money, error) {
+	if value == "" {
+		return nil, nil
+	}
+	balance, err := account.ParseMoney(value, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// sortParam representa a ordenação dos critérios no formato do parâmetro Sort
+func sortParam(criteria account.Criteria) string {
+	if criteria.Descending {
+		return "-" + string(criteria.SortBy)
+	}
+	return string(criteria.SortBy)
+}
+
+// encodeAccountCursor gera o cursor opaco entregue ao cliente
+func encodeAccountCursor(cursor accountCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeAccountCursor lê um cursor gerado por encodeAccountCursor
+func decodeAccountCursor(value string) (*accountCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
 	if err != nil {
 		return nil, err
 	}
+	var cursor accountCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
 
-	var dtos []*AccountDTO
-	for _, acc := range accounts {
-		dtos = append(dtos, mapToDTO(acc))
+// GetBalanceAt retorna o saldo da conta segundo o razão, considerando todos os
+// lançamentos até at, inclusive. Cada estorno é um lançamento próprio, com a
+// data em que foi feito: um estorno posterior a at não altera o saldo em at.
+// A conta é lida do modelo de escrita, como o razão: uma conta recém-aberta
+// ainda ausente do modelo de leitura já tem saldo a consultar.
+func (h *AccountQueryHandler) GetBalanceAt(id string, at time.Time) (*BalanceDTO, error) {
+	if at.After(time.Now()) {
+		return nil, ErrBalanceAtInFuture
 	}
 
-	return dtos, nil
+	acc, err := h.repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	// BalanceAt considera os lançamentos anteriores ao instante; o razão guarda
+	// as datas com fuso (TIMESTAMPTZ) e precisão de microssegundos
+	before := at.Truncate(time.Microsecond).Add(time.Microsecond)
+	balance, err := h.ledger.BalanceAt(acc.ID, acc.Currency(), before)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceDTO{
+		AccountID: acc.ID,
+		Currency:  acc.Currency(),
+		Balance:   balance,
+		At:        at,
+	}, nil
 }
 
 // mapToDTO converte uma entidade Account para AccountDTO
 func mapToDTO(acc *account.Account) *AccountDTO {
+	holds := make([]HoldDTO, 0, len(acc.Holds))
+	for _, h := range acc.Holds {
+		holds = append(holds, HoldDTO{
+			ID:        h.ID,
+			Amount:    h.Amount,
+			ExpiresAt: h.ExpiresAt,
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
 	return &AccountDTO{
-		ID:      acc.ID,
-		Name:    acc.Name,
-		Email:   acc.Email,
-		Balance: acc.Balance,
-		Status:  string(acc.Status),
+		ID:               acc.ID,
+		Name:             acc.Name,
+		Email:            acc.Email,
+		Type:             string(acc.Type),
+		Currency:         acc.Currency(),
+		Balance:          acc.Balance,
+		LedgerBalance:    acc.Balance,
+		AvailableBalance: acc.AvailableBalance(),
+		OverdraftLimit:   acc.OverdraftLimit,
+		Holds:            holds,
+		Status:           string(acc.Status),
+		Version:          acc.Version,
 	}
 }
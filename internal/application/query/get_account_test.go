@@ -3,6 +3,7 @@ package query
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -36,12 +37,12 @@ func (m *MockRepository) FindByEmail(email string) (*account.Account, error) {
 	return args.Get(0).(*account.Account), args.Error(1)
 }
 
-func (m *MockRepository) FindAll() ([]*account.Account, error) {
-	args := m.Called()
+func (m *MockRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	args := m.Called(criteria)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*account.Account), args.Error(1)
+	return args.Get(0).(*account.Page), args.Error(1)
 }
 
 func (m *MockRepository) Update(acc *account.Account) error {
@@ -49,22 +50,17 @@ func (m *MockRepository) Update(acc *account.Account) error {
 	return args.Error(0)
 }
 
-func (m *MockRepository) Delete(id string) error {
-	args := m.Called(id)
-	return args.Error(0)
-}
-
 func TestAccountQueryHandler_GetByID_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	accountID := "account-123"
 	expectedAccount := &account.Account{
 		ID:      accountID,
 		Name:    "João Silva",
 		Email:   "joao@example.com",
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -80,7 +76,7 @@ func TestAccountQueryHandler_GetByID_Success(t *testing.T) {
 	assert.Equal(t, accountID, result.ID)
 	assert.Equal(t, "João Silva", result.Name)
 	assert.Equal(t, "joao@example.com", result.Email)
-	assert.Equal(t, 100.0, result.Balance)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), result.Balance)
 	assert.Equal(t, "active", result.Status)
 
 	mockRepo.AssertExpectations(t)
@@ -89,7 +85,7 @@ func TestAccountQueryHandler_GetByID_Success(t *testing.T) {
 func TestAccountQueryHandler_GetByID_AccountNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	accountID := "non-existent-account"
 
@@ -110,7 +106,7 @@ func TestAccountQueryHandler_GetByID_AccountNotFound(t *testing.T) {
 func TestAccountQueryHandler_GetByID_AccountNotFound_NilReturn(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	accountID := "non-existent-account"
 
@@ -131,14 +127,14 @@ func TestAccountQueryHandler_GetByID_AccountNotFound_NilReturn(t *testing.T) {
 func TestAccountQueryHandler_GetByEmail_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	email := "joao@example.com"
 	expectedAccount := &account.Account{
 		ID:      "account-123",
 		Name:    "João Silva",
 		Email:   email,
-		Balance: 100.0,
+		Balance: account.NewMoney(10000, account.DefaultCurrency),
 		Status:  account.StatusActive,
 	}
 
@@ -154,7 +150,7 @@ func TestAccountQueryHandler_GetByEmail_Success(t *testing.T) {
 	assert.Equal(t, "account-123", result.ID)
 	assert.Equal(t, "João Silva", result.Name)
 	assert.Equal(t, email, result.Email)
-	assert.Equal(t, 100.0, result.Balance)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), result.Balance)
 	assert.Equal(t, "active", result.Status)
 
 	mockRepo.AssertExpectations(t)
@@ -163,7 +159,7 @@ func TestAccountQueryHandler_GetByEmail_Success(t *testing.T) {
 func TestAccountQueryHandler_GetByEmail_AccountNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	email := "nonexistent@example.com"
 
@@ -184,7 +180,7 @@ func TestAccountQueryHandler_GetByEmail_AccountNotFound(t *testing.T) {
 func TestAccountQueryHandler_GetByEmail_AccountNotFound_NilReturn(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	email := "nonexistent@example.com"
 
@@ -205,48 +201,52 @@ func TestAccountQueryHandler_GetByEmail_AccountNotFound_NilReturn(t *testing.T)
 func TestAccountQueryHandler_GetAll_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	expectedAccounts := []*account.Account{
 		{
 			ID:      "account-1",
 			Name:    "João Silva",
 			Email:   "joao@example.com",
-			Balance: 100.0,
+			Balance: account.NewMoney(10000, account.DefaultCurrency),
 			Status:  account.StatusActive,
 		},
 		{
 			ID:      "account-2",
 			Name:    "Maria Santos",
 			Email:   "maria@example.com",
-			Balance: 200.0,
+			Balance: account.NewMoney(20000, account.DefaultCurrency),
 			Status:  account.StatusActive,
 		},
 	}
 
-	// Mock: buscar todas as contas
-	mockRepo.On("FindAll").Return(expectedAccounts, nil)
+	// Mock: buscar a primeira página de contas
+	mockRepo.On("Find", account.Criteria{SortBy: account.SortByCreatedAt, Limit: DefaultAccountPageSize}).
+		Return(&account.Page{Accounts: expectedAccounts, TotalEstimate: 2}, nil)
 
 	// Act
-	result, err := handler.GetAll()
+	page, err := handler.GetAll(AccountListParams{})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
+	assert.NotNil(t, page)
+	assert.Equal(t, int64(2), page.TotalEstimate)
+	assert.Empty(t, page.NextCursor)
+	result := page.Accounts
 	assert.Len(t, result, 2)
 
 	// Verificar primeira conta
 	assert.Equal(t, "account-1", result[0].ID)
 	assert.Equal(t, "João Silva", result[0].Name)
 	assert.Equal(t, "joao@example.com", result[0].Email)
-	assert.Equal(t, 100.0, result[0].Balance)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), result[0].Balance)
 	assert.Equal(t, "active", result[0].Status)
 
 	// Verificar segunda conta
 	assert.Equal(t, "account-2", result[1].ID)
 	assert.Equal(t, "Maria Santos", result[1].Name)
 	assert.Equal(t, "maria@example.com", result[1].Email)
-	assert.Equal(t, 200.0, result[1].Balance)
+	assert.Equal(t, account.NewMoney(20000, account.DefaultCurrency), result[1].Balance)
 	assert.Equal(t, "active", result[1].Status)
 
 	mockRepo.AssertExpectations(t)
@@ -255,21 +255,18 @@ func TestAccountQueryHandler_GetAll_Success(t *testing.T) {
 func TestAccountQueryHandler_GetAll_EmptyList(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
-	// Mock: lista vazia (slice vazia, não nil)
-	emptySlice := make([]*account.Account, 0)
-	mockRepo.On("FindAll").Return(emptySlice, nil)
+	// Mock: nenhuma conta encontrada
+	mockRepo.On("Find", mock.AnythingOfType("account.Criteria")).Return(&account.Page{}, nil)
 
 	// Act
-	result, err := handler.GetAll()
+	page, err := handler.GetAll(AccountListParams{})
 
-	// Assert
+	// Assert: a lista vazia é serializada como [], não null
 	assert.NoError(t, err)
-	// result pode ser nil ou slice vazia, ambos são válidos para lista vazia
-	if result != nil {
-		assert.Len(t, result, 0)
-	}
+	assert.NotNil(t, page.Accounts)
+	assert.Len(t, page.Accounts, 0)
 
 	mockRepo.AssertExpectations(t)
 }
@@ -277,13 +274,13 @@ func TestAccountQueryHandler_GetAll_EmptyList(t *testing.T) {
 func TestAccountQueryHandler_GetAll_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
-	handler := NewAccountQueryHandler(mockRepo)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
 
 	// Mock: erro no repositório
-	mockRepo.On("FindAll").Return(nil, errors.New("database error"))
+	mockRepo.On("Find", mock.AnythingOfType("account.Criteria")).Return(nil, errors.New("database error"))
 
 	// Act
-	result, err := handler.GetAll()
+	result, err := handler.GetAll(AccountListParams{})
 
 	// Assert
 	assert.Error(t, err)
@@ -293,6 +290,139 @@ func TestAccountQueryHandler_GetAll_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAccountQueryHandler_GetAll_Criteria(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
+
+	createdFrom := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	minBalance := account.NewMoney(1000, "USD")
+	maxBalance := account.NewMoney(500000, "USD")
+	mockRepo.On("Find", account.Criteria{
+		Status:      account.StatusBlocked,
+		Email:       "@example.com",
+		Name:        "silva",
+		Currency:    "USD",
+		CreatedFrom: &createdFrom,
+		MinBalance:  &minBalance,
+		MaxBalance:  &maxBalance,
+		SortBy:      account.SortByBalance,
+		Descending:  true,
+		Limit:       MaxAccountPageSize,
+	}).Return(&account.Page{}, nil)
+
+	// Act: o limite acima do máximo é reduzido
+	_, err := handler.GetAll(AccountListParams{
+		Status:      "blocked",
+		Email:       "@example.com",
+		Name:        "silva",
+		Currency:    "USD",
+		CreatedFrom: &createdFrom,
+		MinBalance:  "10.00",
+		MaxBalance:  "5000",
+		Sort:        "-balance",
+		Limit:       1000,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAccountQueryHandler_GetAll_Cursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
+
+	next := &account.Cursor{Value: "João Silva", ID: "account-1"}
+	mockRepo.On("Find", account.Criteria{SortBy: account.SortByName, Limit: 1}).
+		Return(&account.Page{Accounts: []*account.Account{{ID: "account-1", Name: "João Silva"}}, Next: next, TotalEstimate: 2}, nil)
+	mockRepo.On("Find", account.Criteria{SortBy: account.SortByName, After: next, Limit: 1}).
+		Return(&account.Page{Accounts: []*account.Account{{ID: "account-2", Name: "Maria Santos"}}, TotalEstimate: 2}, nil)
+
+	// Act
+	first, err := handler.GetAll(AccountListParams{Sort: "name", Limit: 1})
+	assert.NoError(t, err)
+	second, err := handler.GetAll(AccountListParams{Sort: "name", Limit: 1, Cursor: first.NextCursor})
+
+	// Assert: a segunda página continua depois da última conta da primeira
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Equal(t, "account-2", second.Accounts[0].ID)
+	assert.Empty(t, second.NextCursor)
+
+	// Act: o cursor não vale para outra ordenação
+	_, err = handler.GetAll(AccountListParams{Sort: "-name", Limit: 1, Cursor: first.NextCursor})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAccountQueryHandler_GetAll_BalanceCursorKeepsCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
+
+	// A ordenação por saldo agrupa as contas por moeda: a posição inclui a moeda
+	next := &account.Cursor{Value: "150.00", Currency: "BRL", ID: "account-1"}
+	mockRepo.On("Find", account.Criteria{SortBy: account.SortByBalance, Limit: 1}).
+		Return(&account.Page{Accounts: []*account.Account{{ID: "account-1", Balance: account.NewMoney(15000, "BRL")}}, Next: next, TotalEstimate: 2}, nil)
+	mockRepo.On("Find", account.Criteria{SortBy: account.SortByBalance, After: next, Limit: 1}).
+		Return(&account.Page{Accounts: []*account.Account{{ID: "account-2", Balance: account.NewMoney(1000, "USD")}}, TotalEstimate: 2}, nil)
+
+	// Act
+	first, err := handler.GetAll(AccountListParams{Sort: "balance", Limit: 1})
+	assert.NoError(t, err)
+	second, err := handler.GetAll(AccountListParams{Sort: "balance", Limit: 1, Cursor: first.NextCursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "account-2", second.Accounts[0].ID)
+	mockRepo.AssertExpectations(t)
+
+	// Act: um cursor de saldo sem a moeda não indica a posição
+	withoutCurrency := encodeAccountCursor(accountCursor{Sort: "balance", Value: "150.00", ID: "account-1"})
+	_, err = handler.GetAll(AccountListParams{Sort: "balance", Limit: 1, Cursor: withoutCurrency})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestAccountQueryHandler_GetAll_InvalidParams(t *testing.T) {
+	createdFrom := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		params   AccountListParams
+		expected error
+	}{
+		{"status desconhecido", AccountListParams{Status: "frozen"}, ErrInvalidStatusFilter},
+		{"campo de ordenação desconhecido", AccountListParams{Sort: "-id"}, account.ErrInvalidSortField},
+		{"moeda desconhecida", AccountListParams{Currency: "XYZ"}, account.ErrUnsupportedCurrency},
+		{"saldo inválido", AccountListParams{MinBalance: "1,50"}, account.ErrInvalidMoney},
+		{"saldo mínimo acima do máximo", AccountListParams{MinBalance: "100", MaxBalance: "10"}, ErrInvalidBalanceRange},
+		{"criação fora de ordem", AccountListParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo}, ErrInvalidCreatedRange},
+		{"cursor inválido", AccountListParams{Cursor: "not-a-cursor"}, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockRepository)
+			handler := NewAccountQueryHandler(mockRepo, nil, new(MockLedgerRepository))
+
+			// Act
+			_, err := handler.GetAll(tt.params)
+
+			// Assert: o repositório não é consultado
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Find", mock.Anything)
+		})
+	}
+}
+
 func TestMapToDTO_AllStatuses(t *testing.T) {
 	// Teste para verificar se o mapeamento funciona para todos os status
 	testCases := []struct {
@@ -324,7 +454,7 @@ func TestMapToDTO_AllStatuses(t *testing.T) {
 				ID:      "account-123",
 				Name:    "Test User",
 				Email:   "test@example.com",
-				Balance: 100.0,
+				Balance: account.NewMoney(10000, account.DefaultCurrency),
 				Status:  tc.accountStatus,
 			}
 
@@ -335,8 +465,157 @@ func TestMapToDTO_AllStatuses(t *testing.T) {
 			assert.Equal(t, "account-123", result.ID)
 			assert.Equal(t, "Test User", result.Name)
 			assert.Equal(t, "test@example.com", result.Email)
-			assert.Equal(t, 100.0, result.Balance)
+			assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), result.Balance)
 			assert.Equal(t, tc.expectedStatus, result.Status)
 		})
 	}
 }
+
+func TestAccountQueryHandler_GetBalanceAt_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockLedger := new(MockLedgerRepository)
+	handler := NewAccountQueryHandler(new(MockRepository), mockRepo, mockLedger)
+
+	at := time.Date(2026, time.September, 30, 23, 59, 59, 0, time.UTC)
+	mockRepo.On("FindByID", "account-123").Return(&account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(50000, account.DefaultCurrency),
+	}, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, at.Add(time.Microsecond)).
+		Return(account.NewMoney(12000, account.DefaultCurrency), nil)
+
+	// Act
+	balance, err := handler.GetBalanceAt("account-123", at)
+
+	// Assert: o saldo vem do razão e inclui os lançamentos do próprio instante
+	assert.NoError(t, err)
+	assert.Equal(t, "account-123", balance.AccountID)
+	assert.Equal(t, account.DefaultCurrency, balance.Currency)
+	assert.Equal(t, account.NewMoney(12000, account.DefaultCurrency), balance.Balance)
+	assert.Equal(t, at, balance.At)
+	mockLedger.AssertExpectations(t)
+}
+
+func TestAccountQueryHandler_GetBalanceAt_NonUTCInstant(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockLedger := new(MockLedgerRepository)
+	handler := NewAccountQueryHandler(new(MockRepository), mockRepo, mockLedger)
+
+	// 30/09/2026 20:59:59 em São Paulo (UTC-3) é 23:59:59 em UTC
+	saoPaulo := time.FixedZone("UTC-3", -3*60*60)
+	at := time.Date(2026, time.September, 30, 20, 59, 59, 0, saoPaulo)
+	mockRepo.On("FindByID", "account-123").Return(&account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(50000, account.DefaultCurrency),
+	}, nil)
+
+	var before time.Time
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			before = args.Get(2).(time.Time)
+		}).
+		Return(account.NewMoney(12000, account.DefaultCurrency), nil)
+
+	// Act
+	balance, err := handler.GetBalanceAt("account-123", at)
+
+	// Assert: o razão recebe o mesmo instante, seja qual for o fuso informado
+	assert.NoError(t, err)
+	assert.True(t, before.Equal(time.Date(2026, time.September, 30, 23, 59, 59, 1000, time.UTC)))
+	assert.Equal(t, at, balance.At)
+}
+
+func TestAccountQueryHandler_GetBalanceAt_ReadsWriteModel(t *testing.T) {
+	// Arrange
+	mockViews := new(MockRepository)
+	mockRepo := new(MockRepository)
+	mockLedger := new(MockLedgerRepository)
+	handler := NewAccountQueryHandler(mockViews, mockRepo, mockLedger)
+
+	at := time.Now().Add(-time.Minute)
+	// A conta recém-aberta ainda não chegou ao modelo de leitura
+	mockViews.On("FindByID", "account-123").Return(nil, nil)
+	mockRepo.On("FindByID", "account-123").Return(&account.Account{
+		ID:      "account-123",
+		Balance: account.NewMoney(5000, account.DefaultCurrency),
+	}, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, mock.AnythingOfType("time.Time")).
+		Return(account.NewMoney(5000, account.DefaultCurrency), nil)
+
+	// Act
+	balance, err := handler.GetBalanceAt("account-123", at)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, account.NewMoney(5000, account.DefaultCurrency), balance.Balance)
+	mockViews.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestAccountQueryHandler_GetBalanceAt_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	mockLedger := new(MockLedgerRepository)
+	handler := NewAccountQueryHandler(new(MockRepository), mockRepo, mockLedger)
+
+	mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	balance, err := handler.GetBalanceAt("missing", time.Now().Add(-time.Hour))
+
+	// Assert
+	assert.Nil(t, balance)
+	assert.Equal(t, ErrAccountNotFound, err)
+	mockLedger.AssertNotCalled(t, "BalanceAt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccountQueryHandler_GetBalanceAt_Future(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(new(MockRepository), mockRepo, new(MockLedgerRepository))
+
+	// Act
+	balance, err := handler.GetBalanceAt("account-123", time.Now().Add(time.Hour))
+
+	// Assert: nada é consultado
+	assert.Nil(t, balance)
+	assert.Equal(t, ErrBalanceAtInFuture, err)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestAccountQueryHandler_GetCurrent_ReadsWriteModel(t *testing.T) {
+	// Arrange
+	mockViews := new(MockRepository)
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(mockViews, mockRepo, new(MockLedgerRepository))
+
+	// A visão ainda não recebeu o depósito recém-confirmado
+	mockViews.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: account.NewMoney(10000, account.DefaultCurrency), Version: 1}, nil)
+	mockRepo.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: account.NewMoney(15000, account.DefaultCurrency), Version: 2}, nil)
+
+	// Act
+	current, err := handler.GetCurrent("account-123")
+	view, viewErr := handler.GetByID("account-123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, viewErr)
+	assert.Equal(t, account.NewMoney(15000, account.DefaultCurrency), current.Balance)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), view.Balance)
+}
+
+func TestAccountQueryHandler_GetCurrent_AccountNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRepository)
+	handler := NewAccountQueryHandler(new(MockRepository), mockRepo, new(MockLedgerRepository))
+
+	mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	result, err := handler.GetCurrent("missing")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, ErrAccountNotFound, err)
+}
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+)
+
+// ScheduleQuery representa o serviço de consulta para transferências agendadas
+type ScheduleQuery interface {
+	// GetByAccount lista os agendamentos da conta de origem
+	GetByAccount(accountID string) ([]*ScheduleDTO, error)
+
+	// GetByID busca um agendamento da conta de origem pelo ID
+	GetByID(accountID, scheduleID string) (*ScheduleDTO, error)
+}
+
+// ScheduleDTO é o objeto de transferência de dados para uma transferência agendada
+type ScheduleDTO struct {
+	ID                   string        `json:"id"`
+	SourceAccountID      string        `json:"source_account_id"`
+	DestinationAccountID string        `json:"destination_account_id"`
+	Amount               account.Money `json:"amount"`
+	Frequency            string        `json:"frequency"`
+	StartDate            string        `json:"start_date"`
+	EndDate              string        `json:"end_date,omitempty"`
+	OnInsufficientFunds  string        `json:"on_insufficient_funds"`
+	MaxAttempts          int           `json:"max_attempts"`
+	Status               string        `json:"status"`
+	NextRunDate          string        `json:"next_run_date,omitempty"` // Vazio quando o agendamento não está ativo
+	Attempts             int           `json:"attempts"`
+	RetryAt              *time.Time    `json:"retry_at,omitempty"`
+	LastTransferID       string        `json:"last_transfer_id,omitempty"`
+	LastError            string        `json:"last_error,omitempty"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+// ScheduleQueryHandler implementa ScheduleQuery
+type ScheduleQueryHandler struct {
+	repository schedule.Repository
+}
+
+// NewScheduleQueryHandler cria um novo manipulador de consultas de agendamentos
+func NewScheduleQueryHandler(repository schedule.Repository) *ScheduleQueryHandler {
+	return &ScheduleQueryHandler{
+		repository: repository,
+	}
+}
+
+// GetByAccount lista os agendamentos da conta de origem, dos mais recentes aos mais antigos
+func (h *ScheduleQueryHandler) GetByAccount(accountID string) ([]*ScheduleDTO, error) {
+	schedules, err := h.repository.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*ScheduleDTO, 0, len(schedules))
+	for _, s := range schedules {
+		dtos = append(dtos, mapScheduleToDTO(s))
+	}
+	return dtos, nil
+}
+
+// GetByID busca um agendamento da conta de origem pelo ID
+func (h *ScheduleQueryHandler) GetByID(accountID, scheduleID string) (*ScheduleDTO, error) {
+	s, err := h.repository.FindByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || s.SourceAccountID != accountID {
+		return nil, ErrScheduleNotFound
+	}
+	return mapScheduleToDTO(s), nil
+}
+
+// mapScheduleToDTO converte um agendamento para ScheduleDTO
+func mapScheduleToDTO(s *schedule.Schedule) *ScheduleDTO {
+	dto := &ScheduleDTO{
+		ID:                   s.ID,
+		SourceAccountID:      s.SourceAccountID,
+		DestinationAccountID: s.DestinationAccountID,
+		Amount:               s.Amount,
+		Frequency:            string(s.Frequency),
+		StartDate:            s.StartDate.Format(schedule.DateLayout),
+		OnInsufficientFunds:  s.OnInsufficientFunds,
+		MaxAttempts:          s.MaxAttempts,
+		Status:               string(s.Status),
+		Attempts:             s.Attempts,
+		RetryAt:              s.RetryAt,
+		LastTransferID:       s.LastTransferID,
+		LastError:            s.LastError,
+		CreatedAt:            s.CreatedAt,
+		UpdatedAt:            s.UpdatedAt,
+	}
+	if s.EndDate != nil {
+		dto.EndDate = s.EndDate.Format(schedule.DateLayout)
+	}
+	if s.Status == schedule.StatusActive {
+		dto.NextRunDate = s.NextRunAt.Format(schedule.DateLayout)
+	}
+	return dto
+}
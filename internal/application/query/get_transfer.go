@@ -0,0 +1,66 @@
+package query
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// TransferQuery representa o serviço de consulta para transferências
+type TransferQuery interface {
+	// GetByID busca uma transferência pelo ID
+	GetByID(id string) (*TransferDTO, error)
+}
+
+// TransferDTO é o objeto de transferência de dados para a entidade Transfer
+type TransferDTO struct {
+	ID                   string        `json:"id"`
+	SourceAccountID      string        `json:"source_account_id"`
+	DestinationAccountID string        `json:"destination_account_id"`
+	Amount               account.Money `json:"amount"`
+	DestinationAmount    account.Money `json:"destination_amount"`
+	ExchangeRate         string        `json:"exchange_rate,omitempty"`
+	QuoteID              string        `json:"quote_id,omitempty"`
+	Status               string        `json:"status"`
+	FailureReason        string        `json:"failure_reason,omitempty"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+// TransferQueryHandler implementa TransferQuery
+type TransferQueryHandler struct {
+	repository transfer.Repository
+}
+
+// NewTransferQueryHandler cria um novo manipulador de consultas de transferência
+func NewTransferQueryHandler(repository transfer.Repository) *TransferQueryHandler {
+	return &TransferQueryHandler{
+		repository: repository,
+	}
+}
+
+// GetByID busca uma transferência pelo ID
+func (h *TransferQueryHandler) GetByID(id string) (*TransferDTO, error) {
+	t, err := h.repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	return &TransferDTO{
+		ID:                   t.ID,
+		SourceAccountID:      t.SourceAccountID,
+		DestinationAccountID: t.DestinationAccountID,
+		Amount:               t.Amount,
+		DestinationAmount:    t.DestinationAmount,
+		ExchangeRate:         t.ExchangeRate,
+		QuoteID:              t.QuoteID,
+		Status:               string(t.Status),
+		FailureReason:        t.FailureReason,
+		CreatedAt:            t.CreatedAt,
+		UpdatedAt:            t.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// MockTransferRepository é um mock do repositório de transferências
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferRepository) Save(t *transfer.Transfer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTransferRepository) FindByID(id string) (*transfer.Transfer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transfer.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) Update(t *transfer.Transfer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTransferRepository) HasPendingFrom(accountID string) (bool, error) {
+	args := m.Called(accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestTransferQueryHandler_GetByID_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTransferRepository)
+	handler := NewTransferQueryHandler(mockRepo)
+
+	mockRepo.On("FindByID", "transfer-1").Return(&transfer.Transfer{
+		ID:                   "transfer-1",
+		SourceAccountID:      "source",
+		DestinationAccountID: "destination",
+		Amount:               account.NewMoney(3000, account.DefaultCurrency),
+		Status:               transfer.StatusFailed,
+		FailureReason:        "account is not active",
+	}, nil)
+
+	// Act
+	result, err := handler.GetByID("transfer-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer-1", result.ID)
+	assert.Equal(t, "source", result.SourceAccountID)
+	assert.Equal(t, "destination", result.DestinationAccountID)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), result.Amount)
+	assert.Equal(t, "failed", result.Status)
+	assert.Equal(t, "account is not active", result.FailureReason)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransferQueryHandler_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTransferRepository)
+	handler := NewTransferQueryHandler(mockRepo)
+
+	mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+	// Act
+	result, err := handler.GetByID("missing")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, ErrTransferNotFound, err)
+}
+
+func TestTransferQueryHandler_GetByID_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTransferRepository)
+	handler := NewTransferQueryHandler(mockRepo)
+
+	mockRepo.On("FindByID", "transfer-1").Return(nil, errors.New("database error"))
+
+	// Act
+	result, err := handler.GetByID("transfer-1")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "database error")
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// LedgerQuery representa o serviço de consulta do razão contábil
+type LedgerQuery interface {
+	// Reconcile compara o saldo armazenado de cada conta com o saldo do razão
+	Reconcile() (*ReconciliationDTO, error)
+}
+
+// DiscrepancyDTO descreve uma conta cujo saldo armazenado diverge do razão
+type DiscrepancyDTO struct {
+	AccountID     string        `json:"account_id"`
+	StoredBalance account.Money `json:"stored_balance"`
+	LedgerBalance account.Money `json:"ledger_balance"`
+}
+
+// ReconciliationDTO é o resultado de uma conciliação entre contas e razão
+type ReconciliationDTO struct {
+	Balanced      bool             `json:"balanced"`
+	Discrepancies []DiscrepancyDTO `json:"discrepancies"`
+	CheckedAt     time.Time        `json:"checked_at"`
+}
+
+// LedgerQueryHandler implementa LedgerQuery
+type LedgerQueryHandler struct {
+	repository ledger.Repository
+}
+
+// NewLedgerQueryHandler cria um novo manipulador de consultas do razão
+func NewLedgerQueryHandler(repository ledger.Repository) *LedgerQueryHandler {
+	return &LedgerQueryHandler{
+		repository: repository,
+	}
+}
+
+// Reconcile compara o saldo armazenado de cada conta com o saldo do razão
+func (h *LedgerQueryHandler) Reconcile() (*ReconciliationDTO, error) {
+	discrepancies, err := h.repository.Reconcile()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconciliationDTO{
+		Balanced:      len(discrepancies) == 0,
+		Discrepancies: make([]DiscrepancyDTO, 0, len(discrepancies)),
+		CheckedAt:     time.Now(),
+	}
+	for _, d := range discrepancies {
+		result.Discrepancies = append(result.Discrepancies, DiscrepancyDTO{
+			AccountID:     d.AccountID,
+			StoredBalance: d.StoredBalance,
+			LedgerBalance: d.LedgerBalance,
+		})
+	}
+
+	return result, nil
+}
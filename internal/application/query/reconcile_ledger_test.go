@@ -0,0 +1,117 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// MockLedgerRepository é um mock do razão contábil
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) Append(entry *ledger.JournalEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) FindByEventID(eventID string) (*ledger.JournalEntry, error) {
+	args := m.Called(eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.JournalEntry), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Reversed(eventID string) (bool, error) {
+	args := m.Called(eventID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Balance(accountID, currency string) (account.Money, error) {
+	args := m.Called(accountID, currency)
+	return args.Get(0).(account.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) BalanceAt(accountID, currency string, at time.Time) (account.Money, error) {
+	args := m.Called(accountID, currency, at)
+	return args.Get(0).(account.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Reconcile() ([]ledger.Discrepancy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ledger.Discrepancy), args.Error(1)
+}
+
+func TestLedgerQueryHandler_Reconcile_Balanced(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLedgerRepository)
+	handler := NewLedgerQueryHandler(mockRepo)
+
+	mockRepo.On("Reconcile").Return(nil, nil)
+
+	// Act
+	result, err := handler.Reconcile()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Balanced)
+	assert.NotNil(t, result.Discrepancies)
+	assert.Empty(t, result.Discrepancies)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLedgerQueryHandler_Reconcile_Discrepancies(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLedgerRepository)
+	handler := NewLedgerQueryHandler(mockRepo)
+
+	mockRepo.On("Reconcile").Return([]ledger.Discrepancy{
+		{
+			AccountID:     "account-123",
+			StoredBalance: account.NewMoney(15000, account.DefaultCurrency),
+			LedgerBalance: account.NewMoney(10000, account.DefaultCurrency),
+		},
+	}, nil)
+
+	// Act
+	result, err := handler.Reconcile()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.Balanced)
+	assert.Len(t, result.Discrepancies, 1)
+	assert.Equal(t, "account-123", result.Discrepancies[0].AccountID)
+	assert.Equal(t, account.NewMoney(15000, account.DefaultCurrency), result.Discrepancies[0].StoredBalance)
+	assert.Equal(t, account.NewMoney(10000, account.DefaultCurrency), result.Discrepancies[0].LedgerBalance)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLedgerQueryHandler_Reconcile_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLedgerRepository)
+	handler := NewLedgerQueryHandler(mockRepo)
+
+	mockRepo.On("Reconcile").Return(nil, errors.New("database error"))
+
+	// Act
+	result, err := handler.Reconcile()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,311 @@
+package query
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// MaxStatementPeriod é o maior intervalo coberto por um extrato
+const MaxStatementPeriod = 366 * 24 * time.Hour
+
+// StatementSettleDelay é quanto a geração antecipada aguarda após o fim do mês,
+// para que o worker termine de projetar no histórico os eventos do mês
+const StatementSettleDelay = time.Hour
+
+// PeriodLayout é o formato do mês de um extrato mensal
+const PeriodLayout = "2006-01"
+
+// ErrStatementPeriodTooLong indica um extrato com intervalo maior que MaxStatementPeriod
+var ErrStatementPeriodTooLong = errors.New("statement period must not exceed 366 days")
+
+// ErrStatementNotSettled indica que o histórico de transações ainda não reflete
+// todas as movimentações do razão no intervalo, e o extrato não fecharia com o
+// saldo contábil
+var ErrStatementNotSettled = errors.New("statement period is still being processed, try again later")
+
+// StatementLineDTO é uma movimentação do extrato. Amount tem sinal: créditos
+// são positivos e débitos, negativos.
+type StatementLineDTO struct {
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	Amount       account.Money `json:"amount"`
+	BalanceAfter account.Money `json:"balance_after"`
+	TransferID   string        `json:"transfer_id,omitempty"`
+	OccurredAt   time.Time     `json:"occurred_at"`
+}
+
+// StatementDTO é o extrato de uma conta no intervalo [From, To): o saldo de
+// abertura, as movimentações em ordem cronológica, os totais e o saldo de
+// fechamento. Period é preenchido quando o extrato cobre um mês inteiro.
+type StatementDTO struct {
+	AccountID      string             `json:"account_id"`
+	Currency       string             `json:"currency"`
+	Period         string             `json:"period,omitempty"`
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	OpeningBalance account.Money      `json:"opening_balance"`
+	TotalCredits   account.Money      `json:"total_credits"`
+	TotalDebits    account.Money      `json:"total_debits"`
+	TotalFees      account.Money      `json:"total_fees"`
+	TotalInterest  account.Money      `json:"total_interest"`
+	ClosingBalance account.Money      `json:"closing_balance"`
+	Transactions   []StatementLineDTO `json:"transactions"`
+	GeneratedAt    time.Time          `json:"generated_at"`
+}
+
+// StatementRepository persiste os extratos mensais gerados antecipadamente
+type StatementRepository interface {
+	// Save grava o extrato mensal; um extrato já gravado para a conta e o mês é mantido
+	Save(statement *StatementDTO) error
+
+	// FindByPeriod busca o extrato mensal da conta; retorna nil se não existir
+	FindByPeriod(accountID, period string) (*StatementDTO, error)
+
+	// FindPending retorna, em ordem de ID, até limit contas com ID maior que
+	// afterID abertas antes de openedBefore e ainda sem extrato do mês
+	FindPending(period string, openedBefore time.Time, afterID string, limit int) ([]string, error)
+}
+
+// StatementQuery representa o serviço de consulta de extratos
+type StatementQuery interface {
+	// GetStatement monta o extrato da conta no intervalo [from, to)
+	GetStatement(accountID string, from, to time.Time) (*StatementDTO, error)
+}
+
+// StatementQueryHandler implementa StatementQuery. Os saldos de abertura e de
+// fechamento vêm do razão e as movimentações, do histórico de transações
+// projetado dos eventos, que precisa somar a diferença entre os dois saldos.
+type StatementQueryHandler struct {
+	accounts     account.Repository
+	ledger       ledger.Repository
+	transactions TransactionHistoryRepository
+	statements   StatementRepository
+}
+
+// NewStatementQueryHandler cria um novo manipulador de extratos
+func NewStatementQueryHandler(accounts account.Repository, ledger ledger.Repository, transactions TransactionHistoryRepository, statements StatementRepository) *StatementQueryHandler {
+	return &StatementQueryHandler{
+		accounts:     accounts,
+		ledger:       ledger,
+		transactions: transactions,
+		statements:   statements,
+	}
+}
+
+// GetStatement monta o extrato da conta no intervalo [from, to). Um mês inteiro
+// já gerado pelo worker é servido a partir do extrato gravado.
+func (h *StatementQueryHandler) GetStatement(accountID string, from, to time.Time) (*StatementDTO, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidTimeRange
+	}
+	if to.Sub(from) > MaxStatementPeriod {
+		return nil, ErrStatementPeriodTooLong
+	}
+
+	acc, err := h.accounts.FindByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	if period, ok := monthPeriod(from, to); ok {
+		stored, err := h.statements.FindByPeriod(accountID, period)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			return stored, nil
+		}
+	}
+
+	return h.build(acc, from, to)
+}
+
+// GenerateMonthlyStatements grava os extratos do mês anterior a now das contas
+// abertas até o fim desse mês, em lotes de batchSize, e retorna quantos foram
+// gerados. Nada é gerado antes de StatementSettleDelay após a virada do mês.
+func (h *StatementQueryHandler) GenerateMonthlyStatements(now time.Time, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	now = now.UTC()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if now.Before(to.Add(StatementSettleDelay)) {
+		return 0, nil
+	}
+	from := to.AddDate(0, -1, 0)
+	period := from.Format(PeriodLayout)
+
+	generated := 0
+	afterID := ""
+	for {
+		ids, err := h.statements.FindPending(period, to, afterID, batchSize)
+		if err != nil {
+			return generated, err
+		}
+		if len(ids) == 0 {
+			return generated, nil
+		}
+
+		for _, id := range ids {
+			if err := h.generate(id, from, to); err != nil {
+				log.Printf("Erro ao gerar o extrato %s da conta %s: %v", period, id, err)
+				continue
+			}
+			generated++
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// generate monta e grava o extrato mensal de uma conta
+func (h *StatementQueryHandler) generate(accountID string, from, to time.Time) error {
+	acc, err := h.accounts.FindByID(accountID)
+	if err != nil {
+		return err
+	}
+	if acc == nil {
+		return ErrAccountNotFound
+	}
+
+	statement, err := h.build(acc, from, to)
+	if err != nil {
+		return err
+	}
+	return h.statements.Save(statement)
+}
+
+// build calcula o extrato a partir do saldo do razão em from e das transações
+// do histórico no intervalo, percorrendo todas as páginas. O histórico é
+// projetado de forma assíncrona: se o saldo de fechamento calculado diverge do
+// saldo do razão em to, alguma movimentação ainda não chegou a ele, e o extrato
+// é recusado com ErrStatementNotSettled em vez de sair incompleto.
+func (h *StatementQueryHandler) build(acc *account.Account, from, to time.Time) (*StatementDTO, error) {
+	currency := acc.Currency()
+	opening, err := h.ledger.BalanceAt(acc.ID, currency, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []TransactionRecord
+	var after *TransactionCursor
+	for {
+		page, err := h.transactions.FindByAccount(TransactionFilter{
+			AccountID: acc.ID,
+			From:      &from,
+			To:        &to,
+			After:     after,
+			Limit:     MaxTransactionPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+		if len(page) < MaxTransactionPageSize {
+			break
+		}
+		last := page[len(page)-1]
+		after = &TransactionCursor{OccurredAt: last.OccurredAt, EventID: last.EventID}
+	}
+
+	statement := &StatementDTO{
+		AccountID:      acc.ID,
+		Currency:       currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		TotalCredits:   account.Zero(currency),
+		TotalDebits:    account.Zero(currency),
+		TotalFees:      account.Zero(currency),
+		TotalInterest:  account.Zero(currency),
+		ClosingBalance: opening,
+		Transactions:   make([]StatementLineDTO, 0, len(records)),
+		GeneratedAt:    time.Now(),
+	}
+	if period, ok := monthPeriod(from, to); ok {
+		statement.Period = period
+	}
+
+	// O histórico vem do mais recente para o mais antigo; o extrato é cronológico
+	for i := len(records) - 1; i >= 0; i-- {
+		if err := statement.add(records[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	closing, err := h.ledger.BalanceAt(acc.ID, currency, to)
+	if err != nil {
+		return nil, err
+	}
+	if statement.ClosingBalance != closing {
+		return nil, ErrStatementNotSettled
+	}
+	return statement, nil
+}
+
+// add lança uma transação do histórico no extrato, atualizando os totais
+func (s *StatementDTO) add(r TransactionRecord) error {
+	amount := r.Amount
+	var err error
+	if isCreditTransaction(r.Type) {
+		s.TotalCredits, err = s.TotalCredits.Add(r.Amount)
+	} else {
+		s.TotalDebits, err = s.TotalDebits.Add(r.Amount)
+		amount = r.Amount.Neg()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tarifas estornadas são descontadas do total de tarifas
+	switch r.Type {
+	case TransactionTypeFee:
+		s.TotalFees, err = s.TotalFees.Add(r.Amount)
+	case TransactionTypeFeeReversal:
+		s.TotalFees, err = s.TotalFees.Sub(r.Amount)
+	case TransactionTypeInterest:
+		s.TotalInterest, err = s.TotalInterest.Add(r.Amount)
+	}
+	if err != nil {
+		return err
+	}
+	if s.ClosingBalance, err = s.ClosingBalance.Add(amount); err != nil {
+		return err
+	}
+
+	s.Transactions = append(s.Transactions, StatementLineDTO{
+		ID:           r.EventID,
+		Type:         r.Type,
+		Amount:       amount,
+		BalanceAfter: r.BalanceAfter,
+		TransferID:   r.TransferID,
+		OccurredAt:   r.OccurredAt,
+	})
+	return nil
+}
+
+// isCreditTransaction indica se o tipo de transação do histórico aumenta o saldo
+func isCreditTransaction(transactionType string) bool {
+	switch transactionType {
+	case TransactionTypeDeposit, TransactionTypeInterest, TransactionTypeWithdrawalReversal, TransactionTypeFeeReversal:
+		return true
+	}
+	return false
+}
+
+// monthPeriod indica se [from, to) é exatamente um mês do calendário em UTC,
+// retornando o mês no formato PeriodLayout
+func monthPeriod(from, to time.Time) (string, bool) {
+	from, to = from.UTC(), to.UTC()
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if !from.Equal(start) || !to.Equal(start.AddDate(0, 1, 0)) {
+		return "", false
+	}
+	return start.Format(PeriodLayout), true
+}
@@ -0,0 +1,241 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// MockStatementRepository é um mock dos extratos mensais gravados
+type MockStatementRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatementRepository) Save(statement *StatementDTO) error {
+	args := m.Called(statement)
+	return args.Error(0)
+}
+
+func (m *MockStatementRepository) FindByPeriod(accountID, period string) (*StatementDTO, error) {
+	args := m.Called(accountID, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StatementDTO), args.Error(1)
+}
+
+func (m *MockStatementRepository) FindPending(period string, openedBefore time.Time, afterID string, limit int) ([]string, error) {
+	args := m.Called(period, openedBefore, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Setembro de 2026, o mês dos extratos nos testes
+var (
+	statementFrom = time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	statementTo   = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// brl cria um valor em reais a partir de centavos
+func brl(cents int64) account.Money {
+	return account.NewMoney(cents, account.DefaultCurrency)
+}
+
+// statementRecords são as transações de setembro, da mais recente para a mais
+// antiga, sobre um saldo de abertura de R$ 100,00
+func statementRecords() []TransactionRecord {
+	day := func(d int) time.Time { return statementFrom.AddDate(0, 0, d-1).Add(12 * time.Hour) }
+	return []TransactionRecord{
+		{EventID: "interest", Type: TransactionTypeInterest, Amount: brl(50), BalanceAfter: brl(16760), OccurredAt: day(30)},
+		{EventID: "fee", Type: TransactionTypeFee, Amount: brl(200), BalanceAfter: brl(16710), OccurredAt: day(20)},
+		{EventID: "withdrawal", Type: TransactionTypeWithdrawal, Amount: brl(3000), BalanceAfter: brl(16910), OccurredAt: day(20)},
+		{EventID: "reversal", Type: TransactionTypeWithdrawalReversal, Amount: brl(910), BalanceAfter: brl(19910), OccurredAt: day(10)},
+		{EventID: "deposit", Type: TransactionTypeDeposit, Amount: brl(9000), BalanceAfter: brl(19000), OccurredAt: day(5)},
+	}
+}
+
+// newStatementTestHandler monta o manipulador de extratos sobre os mocks
+func newStatementTestHandler() (*StatementQueryHandler, *MockRepository, *MockLedgerRepository, *MockTransactionHistoryRepository, *MockStatementRepository) {
+	mockAccounts := new(MockRepository)
+	mockLedger := new(MockLedgerRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	mockStatements := new(MockStatementRepository)
+	handler := NewStatementQueryHandler(mockAccounts, mockLedger, mockHistory, mockStatements)
+	return handler, mockAccounts, mockLedger, mockHistory, mockStatements
+}
+
+func TestStatementQueryHandler_GetStatement_BuildsFromHistory(t *testing.T) {
+	// Arrange
+	handler, mockAccounts, mockLedger, mockHistory, mockStatements := newStatementTestHandler()
+
+	from := statementFrom.AddDate(0, 0, 1)
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: brl(0)}, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, from).Return(brl(10000), nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementTo).Return(brl(16760), nil)
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", From: &from, To: &statementTo, Limit: MaxTransactionPageSize}).
+		Return(statementRecords(), nil)
+
+	// Act: o intervalo não é um mês inteiro
+	statement, err := handler.GetStatement("account-123", from, statementTo)
+
+	// Assert: movimentações em ordem cronológica, débitos com sinal negativo
+	assert.NoError(t, err)
+	assert.Empty(t, statement.Period)
+	assert.Equal(t, brl(10000), statement.OpeningBalance)
+	assert.Len(t, statement.Transactions, 5)
+	assert.Equal(t, "deposit", statement.Transactions[0].ID)
+	assert.Equal(t, "withdrawal", statement.Transactions[2].ID)
+	assert.Equal(t, brl(-3000), statement.Transactions[2].Amount)
+	assert.Equal(t, brl(910), statement.Transactions[1].Amount)
+
+	// Os totais fecham com o saldo final, que é o saldo do razão em to
+	assert.Equal(t, brl(9960), statement.TotalCredits)
+	assert.Equal(t, brl(3200), statement.TotalDebits)
+	assert.Equal(t, brl(200), statement.TotalFees)
+	assert.Equal(t, brl(50), statement.TotalInterest)
+	assert.Equal(t, brl(16760), statement.ClosingBalance)
+	mockStatements.AssertNotCalled(t, "FindByPeriod", mock.Anything, mock.Anything)
+	mockLedger.AssertExpectations(t)
+}
+
+func TestStatementQueryHandler_GetStatement_FollowsPages(t *testing.T) {
+	// Arrange
+	handler, mockAccounts, mockLedger, mockHistory, mockStatements := newStatementTestHandler()
+
+	full := make([]TransactionRecord, MaxTransactionPageSize)
+	for i := range full {
+		full[i] = TransactionRecord{EventID: "event", Type: TransactionTypeDeposit, Amount: brl(100), OccurredAt: statementTo.Add(-time.Duration(i+1) * time.Minute)}
+	}
+	last := full[len(full)-1]
+
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: brl(0)}, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementFrom).Return(brl(0), nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementTo).Return(brl(int64(MaxTransactionPageSize)*100+9000), nil)
+	mockStatements.On("FindByPeriod", "account-123", "2026-09").Return(nil, nil)
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", From: &statementFrom, To: &statementTo, Limit: MaxTransactionPageSize}).
+		Return(full, nil)
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", From: &statementFrom, To: &statementTo,
+		After: &TransactionCursor{OccurredAt: last.OccurredAt, EventID: last.EventID}, Limit: MaxTransactionPageSize}).
+		Return(statementRecords()[4:], nil)
+
+	// Act
+	statement, err := handler.GetStatement("account-123", statementFrom, statementTo)
+
+	// Assert: as duas páginas entram no extrato do mês
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09", statement.Period)
+	assert.Len(t, statement.Transactions, MaxTransactionPageSize+1)
+	assert.Equal(t, brl(int64(MaxTransactionPageSize)*100+9000), statement.ClosingBalance)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestStatementQueryHandler_GetStatement_ServesStoredMonth(t *testing.T) {
+	// Arrange
+	handler, mockAccounts, mockLedger, mockHistory, mockStatements := newStatementTestHandler()
+
+	stored := &StatementDTO{AccountID: "account-123", Period: "2026-09", ClosingBalance: brl(16760)}
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: brl(0)}, nil)
+	mockStatements.On("FindByPeriod", "account-123", "2026-09").Return(stored, nil)
+
+	// Act: setembro em outro fuso, mas no mesmo instante
+	brt := time.FixedZone("BRT", -3*60*60)
+	statement, err := handler.GetStatement("account-123", statementFrom.In(brt), statementTo.In(brt))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Same(t, stored, statement)
+	mockLedger.AssertNotCalled(t, "BalanceAt", mock.Anything, mock.Anything, mock.Anything)
+	mockHistory.AssertNotCalled(t, "FindByAccount", mock.Anything)
+}
+
+func TestStatementQueryHandler_GetStatement_InvalidPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected error
+	}{
+		{"fim antes do início", statementTo, statementFrom, ErrInvalidTimeRange},
+		{"mais de um ano", statementFrom, statementFrom.AddDate(1, 0, 2), ErrStatementPeriodTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler, mockAccounts, _, _, _ := newStatementTestHandler()
+
+			// Act
+			_, err := handler.GetStatement("account-123", tt.from, tt.to)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			mockAccounts.AssertNotCalled(t, "FindByID", mock.Anything)
+		})
+	}
+}
+
+func TestStatementQueryHandler_GenerateMonthlyStatements(t *testing.T) {
+	// Arrange
+	handler, mockAccounts, mockLedger, mockHistory, mockStatements := newStatementTestHandler()
+
+	mockStatements.On("FindPending", "2026-09", statementTo, "", 100).Return([]string{"account-123"}, nil)
+	mockStatements.On("FindPending", "2026-09", statementTo, "account-123", 100).Return([]string{}, nil)
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: brl(0)}, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementFrom).Return(brl(10000), nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementTo).Return(brl(16760), nil)
+	mockHistory.On("FindByAccount", mock.Anything).Return(statementRecords(), nil)
+
+	var saved *StatementDTO
+	mockStatements.On("Save", mock.AnythingOfType("*query.StatementDTO")).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*StatementDTO)
+	}).Return(nil)
+
+	// Act: logo após a virada do mês, nada é gerado
+	generated, err := handler.GenerateMonthlyStatements(statementTo.Add(time.Minute), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, generated)
+	mockStatements.AssertNotCalled(t, "FindPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Act: passado o intervalo de acomodação
+	generated, err = handler.GenerateMonthlyStatements(statementTo.Add(StatementSettleDelay), 100)
+
+	// Assert: o extrato de setembro é gravado
+	assert.NoError(t, err)
+	assert.Equal(t, 1, generated)
+	assert.Equal(t, "2026-09", saved.Period)
+	assert.Equal(t, brl(10000), saved.OpeningBalance)
+	assert.Equal(t, brl(16760), saved.ClosingBalance)
+}
+
+func TestStatementQueryHandler_GetStatement_HistoryBehindLedger(t *testing.T) {
+	// Arrange: o razão já tem um depósito de R$ 2,40 que o histórico ainda não projetou
+	handler, mockAccounts, mockLedger, mockHistory, mockStatements := newStatementTestHandler()
+
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123", Balance: brl(0)}, nil)
+	mockStatements.On("FindByPeriod", "account-123", "2026-09").Return(nil, nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementFrom).Return(brl(10000), nil)
+	mockLedger.On("BalanceAt", "account-123", account.DefaultCurrency, statementTo).Return(brl(17000), nil)
+	mockHistory.On("FindByAccount", mock.Anything).Return(statementRecords(), nil)
+
+	// Act
+	statement, err := handler.GetStatement("account-123", statementFrom, statementTo)
+
+	// Assert: o extrato incompleto é recusado
+	assert.ErrorIs(t, err, ErrStatementNotSettled)
+	assert.Nil(t, statement)
+
+	// Act: na geração mensal, o extrato não é gravado e fica para a próxima execução
+	mockStatements.On("FindPending", "2026-09", statementTo, "", 100).Return([]string{"account-123"}, nil)
+	mockStatements.On("FindPending", "2026-09", statementTo, "account-123", 100).Return([]string{}, nil)
+	generated, err := handler.GenerateMonthlyStatements(statementTo.Add(StatementSettleDelay), 100)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, generated)
+	mockStatements.AssertNotCalled(t, "Save", mock.Anything)
+}
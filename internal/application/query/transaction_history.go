@@ -0,0 +1,197 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Limites de paginação do histórico de transações
+const (
+	DefaultTransactionPageSize = 50
+	MaxTransactionPageSize     = 200
+)
+
+// Tipos de transação do histórico
+const (
+	TransactionTypeDeposit    = "deposit"
+	TransactionTypeWithdrawal = "withdrawal"
+	TransactionTypeCapture    = "capture"
+	TransactionTypeInterest   = "interest"
+	TransactionTypeFee        = "fee"
+
+	// Estornos: o de um depósito debita a conta e os de um saque e de sua tarifa a creditam
+	TransactionTypeDepositReversal    = "deposit_reversal"
+	TransactionTypeWithdrawalReversal = "withdrawal_reversal"
+	TransactionTypeFeeReversal        = "fee_reversal"
+)
+
+// TransactionRecord é uma linha do modelo de leitura de transações, projetada a
+// partir de um evento AccountDeposited, AccountWithdrawn, HoldCaptured,
+// InterestCredited, FeeCharged ou TransactionReversed
+type TransactionRecord struct {
+	EventID      string
+	AccountID    string
+	Type         string
+	Amount       account.Money
+	BalanceAfter account.Money
+	TransferID   string
+	OccurredAt   time.Time
+}
+
+// TransactionCursor é a posição, no histórico ordenado do mais recente para o
+// mais antigo, da última transação de uma página
+type TransactionCursor struct {
+	OccurredAt time.Time `json:"t"`
+	EventID    string    `json:"id"`
+}
+
+// TransactionFilter restringe a busca no histórico de uma conta
+type TransactionFilter struct {
+	AccountID string
+	From      *time.Time         // Inclusivo
+	To        *time.Time         // Exclusivo
+	After     *TransactionCursor // Retorna transações anteriores ao cursor
+	Limit     int
+}
+
+// TransactionHistoryRepository persiste e consulta o modelo de leitura de transações
+type TransactionHistoryRepository interface {
+	// Append grava uma transação; gravar de novo o mesmo evento não tem efeito
+	Append(record TransactionRecord) error
+
+	// FindByAccount retorna as transações da conta, da mais recente para a mais antiga
+	FindByAccount(filter TransactionFilter) ([]TransactionRecord, error)
+}
+
+// TransactionHistoryQuery representa o serviço de consulta do histórico de transações
+type TransactionHistoryQuery interface {
+	// GetByAccount busca uma página do histórico de transações de uma conta
+	GetByAccount(params TransactionHistoryParams) (*TransactionPageDTO, error)
+}
+
+// TransactionHistoryParams são os parâmetros de consulta do histórico
+type TransactionHistoryParams struct {
+	AccountID string
+	From      *time.Time
+	To        *time.Time
+	Cursor    string
+	Limit     int
+}
+
+// TransactionDTO é o objeto de transferência de dados de uma transação do histórico
+type TransactionDTO struct {
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	Amount       account.Money `json:"amount"`
+	BalanceAfter account.Money `json:"balance_after"`
+	TransferID   string        `json:"transfer_id,omitempty"`
+	OccurredAt   time.Time     `json:"occurred_at"`
+}
+
+// TransactionPageDTO é uma página do histórico de transações
+type TransactionPageDTO struct {
+	Transactions []TransactionDTO `json:"transactions"`
+	NextCursor   string           `json:"next_cursor,omitempty"`
+}
+
+// TransactionHistoryQueryHandler implementa TransactionHistoryQuery
+type TransactionHistoryQueryHandler struct {
+	accounts     account.Repository
+	transactions TransactionHistoryRepository
+}
+
+// NewTransactionHistoryQueryHandler cria um novo manipulador de consultas do histórico
+func NewTransactionHistoryQueryHandler(accounts account.Repository, transactions TransactionHistoryRepository) *TransactionHistoryQueryHandler {
+	return &TransactionHistoryQueryHandler{
+		accounts:     accounts,
+		transactions: transactions,
+	}
+}
+
+// GetByAccount busca uma página do histórico de transações de uma conta
+func (h *TransactionHistoryQueryHandler) GetByAccount(params TransactionHistoryParams) (*TransactionPageDTO, error) {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultTransactionPageSize
+	}
+	if limit > MaxTransactionPageSize {
+		limit = MaxTransactionPageSize
+	}
+
+	var after *TransactionCursor
+	if params.Cursor != "" {
+		cursor, err := decodeTransactionCursor(params.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		after = cursor
+	}
+
+	acc, err := h.accounts.FindByID(params.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	// Busca um registro além do limite para saber se existe próxima página
+	records, err := h.transactions.FindByAccount(TransactionFilter{
+		AccountID: params.AccountID,
+		From:      params.From,
+		To:        params.To,
+		After:     after,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &TransactionPageDTO{Transactions: make([]TransactionDTO, 0, limit)}
+	if len(records) > limit {
+		records = records[:limit]
+		last := records[limit-1]
+		page.NextCursor = encodeTransactionCursor(TransactionCursor{OccurredAt: last.OccurredAt, EventID: last.EventID})
+	}
+	for _, r := range records {
+		page.Transactions = append(page.Transactions, TransactionDTO{
+			ID:           r.EventID,
+			Type:         r.Type,
+			Amount:       r.Amount,
+			BalanceAfter: r.BalanceAfter,
+			TransferID:   r.TransferID,
+			OccurredAt:   r.OccurredAt,
+		})
+	}
+
+	return page, nil
+}
+
+// encodeTransactionCursor gera o cursor opaco entregue ao cliente
+func encodeTransactionCursor(cursor TransactionCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeTransactionCursor lê um cursor gerado por encodeTransactionCursor
+func decodeTransactionCursor(value string) (*TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor TransactionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.EventID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,183 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// MockTransactionHistoryRepository é um mock do modelo de leitura de transações
+type MockTransactionHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionHistoryRepository) Append(record TransactionRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockTransactionHistoryRepository) FindByAccount(filter TransactionFilter) ([]TransactionRecord, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]TransactionRecord), args.Error(1)
+}
+
+// newTransactionRecords gera n transações, da mais recente para a mais antiga
+func newTransactionRecords(n int) []TransactionRecord {
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	records := make([]TransactionRecord, 0, n)
+	for i := 0; i < n; i++ {
+		records = append(records, TransactionRecord{
+			EventID:      string(rune('a'+n-i)) + "-event",
+			AccountID:    "account-123",
+			Type:         TransactionTypeDeposit,
+			Amount:       account.NewMoney(1000, account.DefaultCurrency),
+			BalanceAfter: account.NewMoney(int64(n-i)*1000, account.DefaultCurrency),
+			OccurredAt:   start.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	return records
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_FirstPage(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	records := newTransactionRecords(3)
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123"}, nil)
+
+	// Mock: o repositório recebe o limite + 1 para detectar a próxima página
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", Limit: 3}).Return(records, nil)
+
+	// Act
+	page, err := handler.GetByAccount(TransactionHistoryParams{AccountID: "account-123", Limit: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, records[0].EventID, page.Transactions[0].ID)
+	assert.Equal(t, account.NewMoney(3000, account.DefaultCurrency), page.Transactions[0].BalanceAfter)
+	assert.NotEmpty(t, page.NextCursor)
+
+	cursor, err := decodeTransactionCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, records[1].EventID, cursor.EventID)
+	assert.True(t, records[1].OccurredAt.Equal(cursor.OccurredAt))
+
+	mockAccounts.AssertExpectations(t)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_NextPage(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	records := newTransactionRecords(3)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	cursor := TransactionCursor{OccurredAt: records[1].OccurredAt, EventID: records[1].EventID}
+
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123"}, nil)
+	mockHistory.On("FindByAccount", mock.MatchedBy(func(f TransactionFilter) bool {
+		return f.After != nil && f.After.EventID == cursor.EventID && f.After.OccurredAt.Equal(cursor.OccurredAt) &&
+			f.From.Equal(from) && f.To.Equal(to) && f.Limit == 3
+	})).Return(records[2:], nil)
+
+	// Act
+	page, err := handler.GetByAccount(TransactionHistoryParams{
+		AccountID: "account-123",
+		From:      &from,
+		To:        &to,
+		Cursor:    encodeTransactionCursor(cursor),
+		Limit:     2,
+	})
+
+	// Assert: última página, sem próximo cursor
+	assert.NoError(t, err)
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, records[2].EventID, page.Transactions[0].ID)
+	assert.Empty(t, page.NextCursor)
+
+	mockHistory.AssertExpectations(t)
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_DefaultAndMaxLimit(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	mockAccounts.On("FindByID", "account-123").Return(&account.Account{ID: "account-123"}, nil)
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", Limit: DefaultTransactionPageSize + 1}).Return(nil, nil)
+	mockHistory.On("FindByAccount", TransactionFilter{AccountID: "account-123", Limit: MaxTransactionPageSize + 1}).Return(nil, nil)
+
+	// Act
+	defaultPage, err1 := handler.GetByAccount(TransactionHistoryParams{AccountID: "account-123"})
+	maxPage, err2 := handler.GetByAccount(TransactionHistoryParams{AccountID: "account-123", Limit: 10000})
+
+	// Assert: páginas vazias são serializadas como lista vazia
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotNil(t, defaultPage.Transactions)
+	assert.Empty(t, maxPage.Transactions)
+
+	mockHistory.AssertExpectations(t)
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_AccountNotFound(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	mockAccounts.On("FindByID", "non-existent").Return(nil, nil)
+
+	// Act
+	page, err := handler.GetByAccount(TransactionHistoryParams{AccountID: "non-existent"})
+
+	// Assert
+	assert.Equal(t, ErrAccountNotFound, err)
+	assert.Nil(t, page)
+	mockHistory.AssertNotCalled(t, "FindByAccount", mock.Anything)
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_InvalidCursor(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	// Act
+	page, err := handler.GetByAccount(TransactionHistoryParams{AccountID: "account-123", Cursor: "not-a-cursor"})
+
+	// Assert
+	assert.Equal(t, ErrInvalidCursor, err)
+	assert.Nil(t, page)
+}
+
+func TestTransactionHistoryQueryHandler_GetByAccount_InvalidTimeRange(t *testing.T) {
+	// Arrange
+	mockAccounts := new(MockRepository)
+	mockHistory := new(MockTransactionHistoryRepository)
+	handler := NewTransactionHistoryQueryHandler(mockAccounts, mockHistory)
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	page, err := handler.GetByAccount(TransactionHistoryParams{AccountID: "account-123", From: &from, To: &to})
+
+	// Assert
+	assert.Equal(t, ErrInvalidTimeRange, err)
+	assert.Nil(t, page)
+}
@@ -9,13 +9,20 @@ import (
 
 // Account representa a entidade de domínio Conta
 type Account struct {
-	ID        string
-	Name      string
-	Email     string
-	Balance   float64
-	Status    AccountStatus
+	ID             string
+	Name           string
+	Email          string
+	Type           AccountType
+	Balance        Money
+	OverdraftLimit Money // Quanto o saldo pode ficar negativo (cheque especial)
+	Status         AccountStatus
+	Holds          []Hold // Reservas ativas sobre o saldo
+	Version        int64  // Versão persistida, usada no controle de concorrência otimista
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	changes []Event // Eventos gerados desde a última gravação
 }
 
 // AccountStatus é o tipo para o status da conta
@@ -25,23 +32,59 @@ const (
 	StatusActive   AccountStatus = "active"
 	StatusInactive AccountStatus = "inactive"
 	StatusBlocked  AccountStatus = "blocked"
+	StatusClosed   AccountStatus = "closed" // Conta encerrada; o status é definitivo
+)
+
+// Erros de regras de status da conta
+var (
+	ErrAccountNotActive      = errors.New("account is not active")
+	ErrAccountAlreadyBlocked = errors.New("account is already blocked")
+	ErrAccountAlreadyActive  = errors.New("account is already active")
+	ErrAccountAlreadyClosed  = errors.New("account is already closed")
+	ErrAccountClosed         = errors.New("account is closed")
+	ErrAccountHasBalance     = errors.New("account balance must be zero to close")
+	ErrAccountHasTransfers   = errors.New("account has pending outgoing transfers")
 )
 
-func NewAccount(name, email string) (*Account, error) {
+// NewAccount cria uma conta ativa do tipo informado, com saldo zero na moeda
+// informada (ISO 4217)
+func NewAccount(name, email, currency string, accountType AccountType) (*Account, error) {
 	if err := validateAccount(name, email); err != nil {
 		return nil, err
 	}
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+	if err := ValidateAccountType(accountType); err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
-	return &Account{
-		ID:        uuid.New().String(),
-		Name:      name,
-		Email:     email,
-		Balance:   0,
-		Status:    StatusActive,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}, nil
+	a := &Account{
+		ID:             uuid.New().String(),
+		Name:           name,
+		Email:          email,
+		Type:           accountType,
+		Balance:        Zero(currency),
+		OverdraftLimit: Zero(currency),
+		Status:         StatusActive,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	a.record(AccountCreatedEvent{
+		BaseEvent:   a.newBaseEvent("AccountCreated", now),
+		Name:        name,
+		Email:       email,
+		Currency:    currency,
+		AccountType: accountType,
+	})
+	return a, nil
+}
+
+// Currency retorna a moeda da conta; todos os valores movimentados nela
+// precisam estar nessa moeda
+func (a *Account) Currency() string {
+	return a.Balance.Currency()
 }
 
 func validateAccount(name, email string) error {
@@ -54,49 +97,213 @@ func validateAccount(name, email string) error {
 	return nil
 }
 
-func (a *Account) Deposit(amount float64) error {
-	if amount <= 0 {
+func (a *Account) Deposit(amount Money) error {
+	return a.deposit(amount, "")
+}
+
+// TransferIn credita na conta o valor recebido por uma transferência
+func (a *Account) TransferIn(amount Money, transferID string) error {
+	return a.deposit(amount, transferID)
+}
+
+func (a *Account) deposit(amount Money, transferID string) error {
+	if !amount.IsPositive() {
 		return errors.New("deposit amount must be positive")
 	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
 	if a.Status != StatusActive {
-		return errors.New("account is not active")
+		return ErrAccountNotActive
+	}
+
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
 	}
 
-	a.Balance += amount
+	a.Balance = balance
 	a.UpdatedAt = time.Now()
+	a.record(AccountDepositedEvent{
+		BaseEvent:      a.newBaseEvent("AccountDeposited", a.UpdatedAt),
+		Amount:         amount,
+		CurrentBalance: a.Balance,
+		TransferID:     transferID,
+	})
 	return nil
 }
 
-func (a *Account) Withdraw(amount float64) error {
-	if amount <= 0 {
+func (a *Account) Withdraw(amount Money) error {
+	return a.withdraw(amount, "")
+}
+
+// TransferOut debita da conta o valor enviado por uma transferência
+func (a *Account) TransferOut(amount Money, transferID string) error {
+	return a.withdraw(amount, transferID)
+}
+
+// SettleOut debita da conta o saldo transferido na liquidação do seu
+// encerramento. Ao contrário de TransferOut, é aceito em contas bloqueadas: o
+// bloqueio impede que o titular movimente a conta, mas não que o saldo seja
+// liquidado para que ela seja encerrada.
+func (a *Account) SettleOut(amount Money, transferID string) error {
+	if !amount.IsPositive() {
 		return errors.New("withdraw amount must be positive")
 	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	return a.debit(amount, transferID)
+}
+
+func (a *Account) withdraw(amount Money, transferID string) error {
+	if !amount.IsPositive() {
+		return errors.New("withdraw amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
 	if a.Status != StatusActive {
-		return errors.New("account is not active")
+		return ErrAccountNotActive
+	}
+	return a.debit(amount, transferID)
+}
+
+// debit retira o valor do saldo disponível, sem verificar o status da conta
+func (a *Account) debit(amount Money, transferID string) error {
+	// O saque só pode usar o saldo disponível: fora das reservas e, se o saldo
+	// ficar negativo, dentro do limite de cheque especial
+	available, err := a.AvailableBalance().Sub(amount)
+	if err != nil {
+		return err
 	}
-	if a.Balance < amount {
+	if available.IsNegative() {
 		return errors.New("insufficient funds")
 	}
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
 
-	a.Balance -= amount
+	a.Balance = balance
 	a.UpdatedAt = time.Now()
+	a.record(AccountWithdrawnEvent{
+		BaseEvent:      a.newBaseEvent("AccountWithdrawn", a.UpdatedAt),
+		Amount:         amount,
+		CurrentBalance: a.Balance,
+		TransferID:     transferID,
+	})
 	return nil
 }
 
-func (a *Account) Block() error {
+// Refund devolve à conta um valor debitado anteriormente, como na compensação de
+// uma transferência que não pôde ser concluída. Ao contrário de Deposit, é aceito
+// mesmo que a conta tenha sido bloqueada depois do débito: o valor não pode ser
+// perdido. Contas com transferências pendentes não podem ser encerradas (veja
+// CloseAccountHandler), então a devolução nunca chega a uma conta encerrada.
+func (a *Account) Refund(amount Money, transferID string) error {
+	if !amount.IsPositive() {
+		return errors.New("refund amount must be positive")
+	}
+
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	a.record(AccountDepositedEvent{
+		BaseEvent:      a.newBaseEvent("AccountDeposited", a.UpdatedAt),
+		Amount:         amount,
+		CurrentBalance: a.Balance,
+		TransferID:     transferID,
+	})
+	return nil
+}
+
+// Block bloqueia a conta, impedindo movimentações até que seja reativada
+func (a *Account) Block(reason string) error {
 	if a.Status == StatusBlocked {
-		return errors.New("account is already blocked")
+		return ErrAccountAlreadyBlocked
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
 	}
 	a.Status = StatusBlocked
 	a.UpdatedAt = time.Now()
+	a.record(AccountBlockedEvent{
+		BaseEvent: a.newBaseEvent("AccountBlocked", a.UpdatedAt),
+		Reason:    reason,
+	})
 	return nil
 }
 
+// Activate reativa uma conta bloqueada ou inativa. Contas encerradas não podem
+// ser reativadas.
 func (a *Account) Activate() error {
 	if a.Status == StatusActive {
-		return errors.New("account is already active")
+		return ErrAccountAlreadyActive
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
 	}
 	a.Status = StatusActive
 	a.UpdatedAt = time.Now()
+	a.record(AccountActivatedEvent{
+		BaseEvent: a.newBaseEvent("AccountActivated", a.UpdatedAt),
+	})
+	return nil
+}
+
+// Close encerra a conta definitivamente. Só contas com saldo zero podem ser
+// encerradas, para que nenhum valor fique retido numa conta sem movimentação;
+// o saldo restante deve ser transferido antes. A conta não é removida: ela
+// permanece com status StatusClosed e recusa qualquer operação posterior.
+// Reservas vencidas que ainda não foram liberadas são liberadas antes; só as
+// reservas válidas impedem o encerramento.
+func (a *Account) Close() error {
+	if a.Status == StatusClosed {
+		return ErrAccountAlreadyClosed
+	}
+	now := time.Now()
+	a.ExpireHolds(now)
+	if len(a.Holds) > 0 {
+		return ErrAccountHasHolds
+	}
+	if !a.Balance.IsZero() {
+		return ErrAccountHasBalance
+	}
+	a.Status = StatusClosed
+	a.UpdatedAt = now
+	a.record(AccountClosedEvent{
+		BaseEvent: a.newBaseEvent("AccountClosed", a.UpdatedAt),
+	})
 	return nil
 }
+
+// Changes retorna uma cópia dos eventos gerados pela conta desde a última gravação
+func (a *Account) Changes() []Event {
+	return append([]Event(nil), a.changes...)
+}
+
+// ClearChanges descarta os eventos pendentes depois que eles foram persistidos
+func (a *Account) ClearChanges() {
+	a.changes = nil
+}
+
+// record registra um evento gerado por uma operação de domínio
+func (a *Account) record(event Event) {
+	a.changes = append(a.changes, event)
+}
+
+// newBaseEvent preenche os campos comuns de um evento desta conta
+func (a *Account) newBaseEvent(eventType string, at time.Time) BaseEvent {
+	return BaseEvent{
+		ID:        uuid.New().String(),
+		AccountID: a.ID,
+		EventType: eventType,
+		Timestamp: at,
+		AggrID:    a.ID,
+	}
+}
@@ -4,6 +4,7 @@ import "time"
 
 // Event é a interface base para todos os eventos de domínio
 type Event interface {
+	EventID() string
 	EventName() string
 	AggregateID() string
 	OccurredAt() time.Time
@@ -18,6 +19,11 @@ type BaseEvent struct {
 	AggrID    string    `json:"aggregate_id"`
 }
 
+// EventID retorna o identificador único do evento
+func (e BaseEvent) EventID() string {
+	return e.ID
+}
+
 // EventName retorna o nome do evento
 func (e BaseEvent) EventName() string {
 	return e.EventType
@@ -36,22 +42,26 @@ func (e BaseEvent) OccurredAt() time.Time {
 // AccountCreatedEvent é emitido quando uma conta é criada
 type AccountCreatedEvent struct {
 	BaseEvent
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name        string      `json:"name"`
+	Email       string      `json:"email"`
+	Currency    string      `json:"currency"`
+	AccountType AccountType `json:"account_type"`
 }
 
 // AccountDepositedEvent é emitido quando um depósito é feito
 type AccountDepositedEvent struct {
 	BaseEvent
-	Amount         float64 `json:"amount"`
-	CurrentBalance float64 `json:"current_balance"`
+	Amount         Money  `json:"amount"`
+	CurrentBalance Money  `json:"current_balance"`
+	TransferID     string `json:"transfer_id,omitempty"` // Preenchido quando o movimento faz parte de uma transferência
 }
 
 // AccountWithdrawnEvent é emitido quando um saque é feito
 type AccountWithdrawnEvent struct {
 	BaseEvent
-	Amount         float64 `json:"amount"`
-	CurrentBalance float64 `json:"current_balance"`
+	Amount         Money  `json:"amount"`
+	CurrentBalance Money  `json:"current_balance"`
+	TransferID     string `json:"transfer_id,omitempty"` // Preenchido quando o movimento faz parte de uma transferência
 }
 
 // AccountBlockedEvent é emitido quando uma conta é bloqueada
@@ -64,3 +74,77 @@ type AccountBlockedEvent struct {
 type AccountActivatedEvent struct {
 	BaseEvent
 }
+
+// AccountClosedEvent é emitido quando uma conta é encerrada
+type AccountClosedEvent struct {
+	BaseEvent
+}
+
+// HoldPlacedEvent é emitido quando parte do saldo é reservada
+type HoldPlacedEvent struct {
+	BaseEvent
+	HoldID           string    `json:"hold_id"`
+	Amount           Money     `json:"amount"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	AvailableBalance Money     `json:"available_balance"`
+}
+
+// HoldCapturedEvent é emitido quando uma reserva é capturada, debitando a conta.
+// ReleasedAmount é a parte da reserva devolvida ao saldo disponível numa captura parcial.
+type HoldCapturedEvent struct {
+	BaseEvent
+	HoldID         string `json:"hold_id"`
+	Amount         Money  `json:"amount"`
+	ReleasedAmount Money  `json:"released_amount"`
+	CurrentBalance Money  `json:"current_balance"`
+}
+
+// HoldReleasedEvent é emitido quando uma reserva é liberada sem captura,
+// manualmente ou por expiração
+type HoldReleasedEvent struct {
+	BaseEvent
+	HoldID string `json:"hold_id"`
+	Amount Money  `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+// OverdraftLimitChangedEvent é emitido quando o limite de cheque especial da conta muda
+type OverdraftLimitChangedEvent struct {
+	BaseEvent
+	Limit         Money `json:"limit"`
+	PreviousLimit Money `json:"previous_limit"`
+}
+
+// InterestCreditedEvent é emitido quando os juros apurados num período são
+// creditados na conta
+type InterestCreditedEvent struct {
+	BaseEvent
+	Amount         Money  `json:"amount"`
+	CurrentBalance Money  `json:"current_balance"`
+	Period         string `json:"period"`
+}
+
+// FeeChargedEvent é emitido quando uma tarifa é cobrada da conta. Reference
+// identifica a operação tarifada (o evento do saque ou a transferência) e
+// Period, o mês de uma tarifa de manutenção (ex.: "2026-09").
+type FeeChargedEvent struct {
+	BaseEvent
+	Amount         Money  `json:"amount"`
+	CurrentBalance Money  `json:"current_balance"`
+	Kind           string `json:"kind"`
+	Reference      string `json:"reference,omitempty"`
+	Period         string `json:"period,omitempty"`
+}
+
+// TransactionReversedEvent é emitido quando um depósito, saque ou tarifa é estornado.
+// OriginalEventID e OriginalEventType identificam a movimentação estornada:
+// o estorno de um AccountDeposited debita a conta e o de um AccountWithdrawn
+// ou FeeCharged a credita.
+type TransactionReversedEvent struct {
+	BaseEvent
+	OriginalEventID   string `json:"original_event_id"`
+	OriginalEventType string `json:"original_event_type"`
+	Amount            Money  `json:"amount"`
+	CurrentBalance    Money  `json:"current_balance"`
+	Reason            string `json:"reason"`
+}
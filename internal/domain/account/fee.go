@@ -0,0 +1,36 @@
+package account
+
+import (
+	"errors"
+	"time"
+)
+
+// ChargeFee debita da conta uma tarifa do tipo kind. O saldo não é verificado:
+// as tarifas de operações são verificadas junto com a própria operação, e a
+// tarifa de manutenção é devida mesmo que deixe a conta negativa. Contas
+// bloqueadas continuam sendo tarifadas; apenas contas encerradas recusam.
+func (a *Account) ChargeFee(amount Money, kind, reference, period string) error {
+	if !amount.IsPositive() {
+		return errors.New("fee amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	a.record(FeeChargedEvent{
+		BaseEvent:      a.newBaseEvent("FeeCharged", a.UpdatedAt),
+		Amount:         amount,
+		CurrentBalance: a.Balance,
+		Kind:           kind,
+		Reference:      reference,
+		Period:         period,
+	})
+	return nil
+}
@@ -0,0 +1,196 @@
+package account
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Erros de reservas de saldo
+var (
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds held amount")
+	ErrAccountHasHolds    = errors.New("account has pending holds")
+	ErrInvalidHoldExpiry  = errors.New("hold expiry must be in the future")
+)
+
+// Motivos de liberação de uma reserva
+const (
+	HoldReleasedManually = "released"
+	HoldReleasedExpired  = "expired"
+)
+
+// Hold é uma reserva (pré-autorização) de parte do saldo da conta. O valor
+// reservado continua no saldo contábil, mas deixa de estar disponível até que
+// a reserva seja capturada, liberada ou expire.
+type Hold struct {
+	ID        string    `json:"id"`
+	Amount    Money     `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Expired indica se a reserva já expirou no instante informado
+func (h Hold) Expired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// AvailableBalance retorna o saldo que pode ser movimentado: o saldo contábil
+// mais o limite de cheque especial, menos as reservas ainda não expiradas
+func (a *Account) AvailableBalance() Money {
+	return a.availableAt(time.Now())
+}
+
+func (a *Account) availableAt(now time.Time) Money {
+	available := a.Balance.MinorUnits() + a.overdraftLimit().MinorUnits()
+	for _, h := range a.Holds {
+		if !h.Expired(now) {
+			available -= h.Amount.MinorUnits()
+		}
+	}
+	return NewMoney(available, a.Balance.Currency())
+}
+
+// PlaceHold reserva um valor do saldo disponível até expiresAt
+func (a *Account) PlaceHold(amount Money, expiresAt time.Time) (Hold, error) {
+	if !amount.IsPositive() {
+		return Hold{}, errors.New("hold amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return Hold{}, ErrAccountClosed
+	}
+	if a.Status != StatusActive {
+		return Hold{}, ErrAccountNotActive
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return Hold{}, ErrInvalidHoldExpiry
+	}
+	available, err := a.availableAt(now).Sub(amount)
+	if err != nil {
+		return Hold{}, err
+	}
+	if available.IsNegative() {
+		return Hold{}, errors.New("insufficient funds")
+	}
+
+	hold := Hold{
+		ID:        uuid.New().String(),
+		Amount:    amount,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	a.Holds = append(a.Holds, hold)
+	a.UpdatedAt = now
+	a.record(HoldPlacedEvent{
+		BaseEvent:        a.newBaseEvent("HoldPlaced", now),
+		HoldID:           hold.ID,
+		Amount:           amount,
+		ExpiresAt:        expiresAt,
+		AvailableBalance: available,
+	})
+	return hold, nil
+}
+
+// CaptureHold debita da conta até o valor reservado. O restante da reserva,
+// numa captura parcial, é liberado. A captura é aceita mesmo que a conta tenha
+// sido bloqueada depois da reserva, pois o valor já estava comprometido.
+func (a *Account) CaptureHold(holdID string, amount Money) error {
+	if !amount.IsPositive() {
+		return errors.New("capture amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+
+	hold, ok := a.FindHold(holdID)
+	if !ok {
+		return ErrHoldNotFound
+	}
+	now := time.Now()
+	if hold.Expired(now) {
+		return ErrHoldExpired
+	}
+	remainder, err := hold.Amount.Sub(amount)
+	if err != nil {
+		return err
+	}
+	if remainder.IsNegative() {
+		return ErrCaptureExceedsHold
+	}
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.removeHold(holdID)
+	a.UpdatedAt = now
+	a.record(HoldCapturedEvent{
+		BaseEvent:      a.newBaseEvent("HoldCaptured", now),
+		HoldID:         holdID,
+		Amount:         amount,
+		ReleasedAmount: remainder,
+		CurrentBalance: a.Balance,
+	})
+	return nil
+}
+
+// ReleaseHold cancela a reserva, devolvendo o valor ao saldo disponível
+func (a *Account) ReleaseHold(holdID string) error {
+	hold, ok := a.FindHold(holdID)
+	if !ok {
+		return ErrHoldNotFound
+	}
+	a.releaseHold(hold, HoldReleasedManually, time.Now())
+	return nil
+}
+
+// ExpireHolds libera as reservas vencidas até o instante informado e retorna
+// quantas foram liberadas
+func (a *Account) ExpireHolds(now time.Time) int {
+	var expired []Hold
+	for _, h := range a.Holds {
+		if h.Expired(now) {
+			expired = append(expired, h)
+		}
+	}
+	for _, h := range expired {
+		a.releaseHold(h, HoldReleasedExpired, now)
+	}
+	return len(expired)
+}
+
+func (a *Account) releaseHold(hold Hold, reason string, now time.Time) {
+	a.removeHold(hold.ID)
+	a.UpdatedAt = now
+	a.record(HoldReleasedEvent{
+		BaseEvent: a.newBaseEvent("HoldReleased", now),
+		HoldID:    hold.ID,
+		Amount:    hold.Amount,
+		Reason:    reason,
+	})
+}
+
+// FindHold busca uma reserva ativa da conta pelo ID
+func (a *Account) FindHold(holdID string) (Hold, bool) {
+	for _, h := range a.Holds {
+		if h.ID == holdID {
+			return h, true
+		}
+	}
+	return Hold{}, false
+}
+
+func (a *Account) removeHold(holdID string) {
+	var holds []Hold
+	for _, h := range a.Holds {
+		if h.ID != holdID {
+			holds = append(holds, h)
+		}
+	}
+	a.Holds = holds
+}
@@ -0,0 +1,62 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AccountType é o tipo de produto da conta
+type AccountType string
+
+const (
+	TypeChecking AccountType = "checking" // Conta corrente, sem rendimento
+	TypeSavings  AccountType = "savings"  // Conta poupança, remunerada com juros
+)
+
+// ErrUnsupportedAccountType indica um tipo de conta desconhecido
+var ErrUnsupportedAccountType = errors.New("unsupported account type")
+
+// ValidateAccountType recusa tipos de conta desconhecidos
+func ValidateAccountType(accountType AccountType) error {
+	switch accountType {
+	case TypeChecking, TypeSavings:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedAccountType, accountType)
+}
+
+// CreditInterest credita na conta os juros de um período (ex.: "2026-09").
+// Como os juros já foram apurados sobre o saldo, o crédito é aceito mesmo
+// que a conta tenha sido bloqueada; apenas contas encerradas o recusam.
+func (a *Account) CreditInterest(amount Money, period string) error {
+	if !amount.IsPositive() {
+		return errors.New("interest amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	a.record(InterestCreditedEvent{
+		BaseEvent:      a.newBaseEvent("InterestCredited", a.UpdatedAt),
+		Amount:         amount,
+		CurrentBalance: a.Balance,
+		Period:         period,
+	})
+	return nil
+}
+
+// accountType retorna o tipo da conta; contas anteriores aos tipos são correntes
+func (a *Account) accountType() AccountType {
+	if a.Type == "" {
+		return TypeChecking
+	}
+	return a.Type
+}
@@ -0,0 +1,62 @@
+package account
+
+import (
+	"errors"
+	"time"
+)
+
+// SortField é o campo de ordenação da listagem de contas
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByName      SortField = "name"
+	SortByEmail     SortField = "email"
+	SortByBalance   SortField = "balance"
+)
+
+// ErrInvalidSortField indica um campo de ordenação desconhecido
+var ErrInvalidSortField = errors.New("sort field must be created_at, name, email or balance")
+
+// ValidateSortField verifica se o campo de ordenação é suportado
+func ValidateSortField(field SortField) error {
+	switch field {
+	case SortByCreatedAt, SortByName, SortByEmail, SortByBalance:
+		return nil
+	}
+	return ErrInvalidSortField
+}
+
+// Cursor é a posição da última conta de uma página: o valor do campo de
+// ordenação, no formato textual do banco, e o ID, que desempata valores iguais.
+// Na ordenação por saldo, que agrupa as contas por moeda, a moeda da conta
+// também faz parte da posição.
+type Cursor struct {
+	Value    string
+	Currency string
+	ID       string
+}
+
+// Criteria filtra, ordena e pagina a listagem de contas. Filtros vazios são
+// ignorados; os intervalos incluem os dois extremos.
+type Criteria struct {
+	Status      AccountStatus
+	Email       string // Trecho do e-mail, sem diferenciar maiúsculas
+	Name        string // Trecho do nome, sem diferenciar maiúsculas
+	Currency    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinBalance  *Money // Restringe também à moeda do valor
+	MaxBalance  *Money // Restringe também à moeda do valor
+	SortBy      SortField
+	Descending  bool
+	After       *Cursor // Retorna as contas posteriores ao cursor na ordenação
+	Limit       int
+}
+
+// Page é uma página da listagem de contas
+type Page struct {
+	Accounts      []*Account
+	Next          *Cursor // Posição da última conta; nil se não houver próxima página
+	TotalEstimate int64   // Estimativa do total de contas que atendem aos filtros
+}
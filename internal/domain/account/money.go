@@ -0,0 +1,259 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency é a moeda usada quando nenhuma outra é informada
+const DefaultCurrency = "BRL"
+
+// Erros de valores monetários
+var (
+	ErrInvalidMoney        = errors.New("invalid money amount")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// currencyExponents lista as moedas ISO 4217 aceitas e o número de casas
+// decimais de cada uma, que define a unidade menor (centavo, fils, ...)
+var currencyExponents = map[string]int{
+	"BRL": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"MXN": 2,
+	"ARS": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"JOD": 3,
+	"OMR": 3,
+}
+
+// ValidateCurrency verifica se o código é uma moeda ISO 4217 suportada
+func ValidateCurrency(currency string) error {
+	if _, ok := currencyExponents[currency]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return nil
+}
+
+// Money é o objeto de valor que representa uma quantia monetária exata,
+// armazenada em unidades menores (centavos, ienes, fils) junto ao código da moeda
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// NewMoney cria um valor a partir da quantidade de unidades menores
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{amount: minorUnits, currency: currency}
+}
+
+// Zero retorna o valor zero na moeda informada
+func Zero(currency string) Money {
+	return Money{currency: currency}
+}
+
+// ParseMoney interpreta um valor decimal como "150", "150.5" ou "-10.25".
+//
+// Regras de interpretação:
+//   - o texto é tratado como decimal exato, nunca passa por ponto flutuante;
+//   - notação científica, separador de milhar e vírgula decimal são rejeitados;
+//   - casas decimais além da precisão da moeda (duas para BRL, nenhuma para
+//     JPY, três para BHD) só são aceitas se forem zeros ("10.500" vale
+//     10,50 BRL); qualquer outra casa extra é rejeitada em vez de arredondada,
+//     para que o valor debitado seja exatamente o solicitado.
+func ParseMoney(value, currency string) (Money, error) {
+	return parseMoney(value, currency, CurrencyExponent(currency))
+}
+
+// ParseDecimal interpreta um valor pelas regras de ParseMoney antes de se saber
+// em que moeda ele será movimentado, aceitando a precisão da moeda mais precisa.
+// O valor retornado não tem moeda: serve para validar o formato e o sinal, e
+// deve ser interpretado de novo com ParseMoney quando a moeda for conhecida.
+func ParseDecimal(value string) (Money, error) {
+	return parseMoney(value, "", maxCurrencyExponent())
+}
+
+func parseMoney(value, currency string, exponent int) (Money, error) {
+	s := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if intPart == "" || (hasPoint && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+
+	if len(fracPart) > exponent {
+		if strings.TrimRight(fracPart[exponent:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidMoney, value, exponent)
+		}
+		fracPart = fracPart[:exponent]
+	}
+	fracPart += strings.Repeat("0", exponent-len(fracPart))
+
+	minor, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{amount: minor, currency: currency}, nil
+}
+
+// MinorUnits retorna o valor em unidades menores
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+// Currency retorna o código da moeda
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// IsZero indica se o valor é zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive indica se o valor é maior que zero
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative indica se o valor é menor que zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Neg retorna o valor com o sinal invertido
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Add soma dois valores da mesma moeda
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, fmt.Errorf("%w: overflow", ErrInvalidMoney)
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub subtrai dois valores da mesma moeda
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: overflow", ErrInvalidMoney)
+	}
+	return m.Add(other.Neg())
+}
+
+// Cmp compara dois valores da mesma moeda, retornando -1, 0 ou 1
+func (m Money) Cmp(other Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// String formata o valor como decimal com a precisão da moeda (ex.: "150.00")
+func (m Money) String() string {
+	exponent := CurrencyExponent(m.currency)
+	sign := ""
+	abs := uint64(m.amount)
+	if m.amount < 0 {
+		sign = "-"
+		abs = uint64(-m.amount)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// moneyJSON é a representação JSON de Money; o valor viaja como string
+// decimal para não perder precisão em clientes que usam ponto flutuante
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON serializa o valor como {"amount":"150.00","currency":"BRL"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.String(), Currency: m.currency})
+}
+
+// UnmarshalJSON lê o formato produzido por MarshalJSON
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// CurrencyExponent retorna o número de casas decimais da moeda. Códigos
+// desconhecidos (inclusive o vazio dos valores zerados) usam duas casas.
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// maxCurrencyExponent retorna o maior número de casas decimais entre as moedas aceitas
+func maxCurrencyExponent() int {
+	max := 0
+	for _, exponent := range currencyExponents {
+		if exponent > max {
+			max = exponent
+		}
+	}
+	return max
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package account
+
+import (
+	"errors"
+	"time"
+)
+
+// Erros do limite de cheque especial
+var (
+	ErrInvalidOverdraftLimit  = errors.New("overdraft limit must not be negative")
+	ErrOverdraftLimitBelowUse = errors.New("overdraft limit is lower than the amount already overdrawn")
+)
+
+// SetOverdraftLimit define até quanto o saldo da conta pode ficar negativo.
+// O limite não pode ser menor que o valor já utilizado do cheque especial.
+func (a *Account) SetOverdraftLimit(limit Money) error {
+	if limit.IsNegative() {
+		return ErrInvalidOverdraftLimit
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	if a.Balance.Neg().MinorUnits() > limit.MinorUnits() {
+		return ErrOverdraftLimitBelowUse
+	}
+
+	previous := a.overdraftLimit()
+	a.OverdraftLimit = limit
+	a.UpdatedAt = time.Now()
+	a.record(OverdraftLimitChangedEvent{
+		BaseEvent:     a.newBaseEvent("OverdraftLimitChanged", a.UpdatedAt),
+		Limit:         limit,
+		PreviousLimit: previous,
+	})
+	return nil
+}
+
+// overdraftLimit retorna o limite de cheque especial, zero se nunca foi definido
+func (a *Account) overdraftLimit() Money {
+	if a.OverdraftLimit.Currency() == "" {
+		return Zero(a.Balance.Currency())
+	}
+	return a.OverdraftLimit
+}
@@ -0,0 +1,105 @@
+package account
+
+import "fmt"
+
+// Rehydrate reconstrói uma conta a partir do seu histórico de eventos, na ordem
+// em que foram gravados. A versão da conta passa a ser o número de eventos aplicados.
+func Rehydrate(events []Event) (*Account, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	a := &Account{}
+	for _, event := range events {
+		if err := a.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Apply aplica um evento já ocorrido ao estado da conta. Ao contrário das
+// operações de domínio, não valida regras de negócio nem gera novos eventos:
+// o evento é um fato e apenas é reproduzido.
+func (a *Account) Apply(event Event) error {
+	switch e := event.(type) {
+	case AccountCreatedEvent:
+		a.ID = e.AggrID
+		a.Name = e.Name
+		a.Email = e.Email
+		// Eventos gravados antes das contas multimoeda não trazem a moeda
+		currency := e.Currency
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		a.Balance = Zero(currency)
+		a.OverdraftLimit = Zero(currency)
+		// Eventos anteriores aos tipos de conta não trazem o tipo: são contas correntes
+		a.Type = e.AccountType
+		if a.Type == "" {
+			a.Type = TypeChecking
+		}
+		a.Status = StatusActive
+		a.CreatedAt = e.Timestamp
+	case AccountDepositedEvent:
+		balance, err := a.Balance.Add(e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+	case AccountWithdrawnEvent:
+		balance, err := a.Balance.Sub(e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+	case AccountBlockedEvent:
+		a.Status = StatusBlocked
+	case AccountActivatedEvent:
+		a.Status = StatusActive
+	case AccountClosedEvent:
+		a.Status = StatusClosed
+	case HoldPlacedEvent:
+		a.Holds = append(a.Holds, Hold{
+			ID:        e.HoldID,
+			Amount:    e.Amount,
+			ExpiresAt: e.ExpiresAt,
+			CreatedAt: e.Timestamp,
+		})
+	case HoldCapturedEvent:
+		balance, err := a.Balance.Sub(e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+		a.removeHold(e.HoldID)
+	case HoldReleasedEvent:
+		a.removeHold(e.HoldID)
+	case OverdraftLimitChangedEvent:
+		a.OverdraftLimit = e.Limit
+	case InterestCreditedEvent:
+		balance, err := a.Balance.Add(e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+	case FeeChargedEvent:
+		balance, err := a.Balance.Sub(e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+	case TransactionReversedEvent:
+		balance, err := reverse(a.Balance, e.OriginalEventType, e.Amount)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+	default:
+		return fmt.Errorf("cannot apply event %s to account", event.EventName())
+	}
+
+	a.UpdatedAt = event.OccurredAt()
+	a.Version++
+	return nil
+}
@@ -1,11 +1,19 @@
 package account
 
+import "errors"
+
+// ErrVersionConflict indica que a conta foi alterada por outra transação
+// entre a leitura e a gravação
+var ErrVersionConflict = errors.New("account was modified concurrently")
+
 // Repository define a interface para operações de persistência com a entidade Account
 type Repository interface {
 	Save(account *Account) error
 	FindByID(id string) (*Account, error)
 	FindByEmail(email string) (*Account, error)
-	FindAll() ([]*Account, error)
+	// Find retorna uma página das contas que atendem aos critérios
+	Find(criteria Criteria) (*Page, error)
+	// Update persiste as alterações da conta se a versão armazenada ainda for
+	// account.Version, incrementando-a; caso contrário retorna ErrVersionConflict
 	Update(account *Account) error
-	Delete(id string) error
 }
@@ -0,0 +1,52 @@
+package account
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotReversible indica que a movimentação não é um depósito, saque ou tarifa que possa ser estornado
+var ErrNotReversible = errors.New("transaction cannot be reversed")
+
+// ReverseTransaction estorna um depósito (AccountDeposited), saque
+// (AccountWithdrawn) ou tarifa (FeeCharged) já lançado na conta, lançando o
+// movimento oposto. A tarifa só é estornada junto com o saque que a gerou. O saldo
+// não é verificado aqui: quem estorna um depósito decide se exige saldo
+// disponível. Como correção administrativa, o estorno é aceito em contas
+// bloqueadas; apenas contas encerradas recusam.
+func (a *Account) ReverseTransaction(originalEventID, originalEventType string, amount Money, reason string) error {
+	if !amount.IsPositive() {
+		return errors.New("reversal amount must be positive")
+	}
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+
+	balance, err := reverse(a.Balance, originalEventType, amount)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	a.record(TransactionReversedEvent{
+		BaseEvent:         a.newBaseEvent("TransactionReversed", a.UpdatedAt),
+		OriginalEventID:   originalEventID,
+		OriginalEventType: originalEventType,
+		Amount:            amount,
+		CurrentBalance:    a.Balance,
+		Reason:            reason,
+	})
+	return nil
+}
+
+// reverse aplica ao saldo o movimento oposto ao do evento original
+func reverse(balance Money, originalEventType string, amount Money) (Money, error) {
+	switch originalEventType {
+	case "AccountDeposited":
+		return balance.Sub(amount)
+	case "AccountWithdrawn", "FeeCharged":
+		return balance.Add(amount)
+	}
+	return Money{}, ErrNotReversible
+}
@@ -0,0 +1,122 @@
+package account
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// accountHistory gera um fluxo de eventos longo, como o de uma conta antiga
+func accountHistory(t *testing.T) []Event {
+	acc, err := NewAccount("João Silva", "joao@example.com", DefaultCurrency, TypeChecking)
+	assert.NoError(t, err)
+
+	assert.NoError(t, acc.SetOverdraftLimit(NewMoney(1000, DefaultCurrency)))
+	for i := 1; i <= 40; i++ {
+		assert.NoError(t, acc.Deposit(NewMoney(int64(i*100), DefaultCurrency)))
+		if i%3 == 0 {
+			assert.NoError(t, acc.Withdraw(NewMoney(150, DefaultCurrency)))
+		}
+		if i%10 == 0 {
+			assert.NoError(t, acc.Block("análise de fraude"))
+			assert.NoError(t, acc.Activate())
+		}
+		if i%15 == 0 {
+			captured, err := acc.PlaceHold(NewMoney(300, DefaultCurrency), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			released, err := acc.PlaceHold(NewMoney(200, DefaultCurrency), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.NoError(t, acc.CaptureHold(captured.ID, NewMoney(250, DefaultCurrency)))
+			assert.NoError(t, acc.ReleaseHold(released.ID))
+		}
+	}
+	assert.NoError(t, acc.TransferOut(NewMoney(500, DefaultCurrency), "transfer-1"))
+	assert.NoError(t, acc.Refund(NewMoney(500, DefaultCurrency), "transfer-1"))
+	assert.NoError(t, acc.Withdraw(acc.Balance))
+	assert.NoError(t, acc.Close())
+
+	return acc.Changes()
+}
+
+func TestRehydrateFromSnapshot_EqualsFullReplay(t *testing.T) {
+	// Arrange
+	events := accountHistory(t)
+	full, err := Rehydrate(events)
+	assert.NoError(t, err)
+
+	for version := 1; version <= len(events); version++ {
+		// Snapshot tirado na versão informada
+		atVersion, err := Rehydrate(events[:version])
+		assert.NoError(t, err)
+		snapshot, err := atVersion.Snapshot()
+		assert.NoError(t, err)
+
+		// Act
+		restored, err := RehydrateFromSnapshot(snapshot, events[version:])
+
+		// Assert: snapshot + eventos posteriores reproduzem o fluxo completo
+		assert.NoError(t, err)
+		assert.Equal(t, full, restored, "snapshot na versão %d", version)
+	}
+}
+
+func TestRehydrateFromSnapshot_SerializedSnapshot(t *testing.T) {
+	// Arrange
+	events := accountHistory(t)
+	full, err := Rehydrate(events)
+	assert.NoError(t, err)
+
+	atVersion, err := Rehydrate(events[:50])
+	assert.NoError(t, err)
+	snapshot, err := atVersion.Snapshot()
+	assert.NoError(t, err)
+
+	// Simula a gravação e a leitura do snapshot na tabela
+	payload, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+	var stored Snapshot
+	assert.NoError(t, json.Unmarshal(payload, &stored))
+
+	// Act
+	restored, err := RehydrateFromSnapshot(stored, events[50:])
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, full.ID, restored.ID)
+	assert.Equal(t, full.Name, restored.Name)
+	assert.Equal(t, full.Email, restored.Email)
+	assert.Equal(t, full.Balance, restored.Balance)
+	assert.Equal(t, full.Status, restored.Status)
+	assert.Equal(t, full.Version, restored.Version)
+	assert.True(t, full.CreatedAt.Equal(restored.CreatedAt))
+	assert.True(t, full.UpdatedAt.Equal(restored.UpdatedAt))
+}
+
+func TestRehydrateFromSnapshot_EmptyTail(t *testing.T) {
+	// Arrange
+	full, err := Rehydrate(accountHistory(t))
+	assert.NoError(t, err)
+	snapshot, err := full.Snapshot()
+	assert.NoError(t, err)
+
+	// Act
+	restored, err := RehydrateFromSnapshot(snapshot, nil)
+
+	// Assert: sem eventos posteriores, o snapshot é o estado atual
+	assert.NoError(t, err)
+	assert.Equal(t, full, restored)
+}
+
+func TestAccount_Snapshot_UnsavedChanges(t *testing.T) {
+	// Arrange
+	acc, err := NewAccount("João Silva", "joao@example.com", DefaultCurrency, TypeChecking)
+	assert.NoError(t, err)
+
+	// Act
+	_, err = acc.Snapshot()
+
+	// Assert: o snapshot não pode conter eventos que ainda não estão no fluxo
+	assert.Error(t, err)
+}
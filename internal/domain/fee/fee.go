@@ -0,0 +1,81 @@
+package fee
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Tipos de tarifa
+const (
+	KindWithdrawal  = "withdrawal"  // Cobrada a cada saque
+	KindTransfer    = "transfer"    // Cobrada da origem a cada transferência
+	KindMaintenance = "maintenance" // Cobrada uma vez por mês
+)
+
+// KindAll é usado nas isenções para isentar a conta de todas as tarifas
+const KindAll = "all"
+
+// ErrUnknownKind indica um tipo de tarifa desconhecido
+var ErrUnknownKind = errors.New("unknown fee kind")
+
+// ValidateWaiverKind recusa tipos de isenção desconhecidos
+func ValidateWaiverKind(kind string) error {
+	switch kind {
+	case KindWithdrawal, KindTransfer, KindMaintenance, KindAll:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+}
+
+// Schedule são as tarifas aplicáveis a uma conta, por tipo, na moeda da conta.
+// Tipos ausentes não são cobrados.
+type Schedule map[string]account.Money
+
+// For retorna a tarifa do tipo informado, se houver uma a cobrar
+func (s Schedule) For(kind string) (account.Money, bool) {
+	amount, ok := s[kind]
+	if !ok || !amount.IsPositive() {
+		return account.Money{}, false
+	}
+	return amount, true
+}
+
+// Waiver isenta uma conta de um tipo de tarifa (ou de todas, com KindAll)
+// até ExpiresAt; sem vencimento, a isenção vale até ser removida
+type Waiver struct {
+	AccountID string     `json:"account_id"`
+	Kind      string     `json:"kind"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Active indica se a isenção vale no instante informado
+func (w Waiver) Active(at time.Time) bool {
+	return w.ExpiresAt == nil || at.Before(*w.ExpiresAt)
+}
+
+// Charge é uma tarifa cobrada, identificada pelo evento FeeCharged
+type Charge struct {
+	EventID   string
+	AccountID string
+	Kind      string
+	Amount    account.Money
+	Reference string
+	Period    string
+	ChargedAt time.Time
+}
+
+// PreviousMonth retorna o início, em UTC, do mês anterior ao de t
+func PreviousMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Period retorna o mês de t no formato usado nas tarifas de manutenção ("2006-01")
+func Period(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
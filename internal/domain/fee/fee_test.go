@@ -0,0 +1,114 @@
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+func TestSchedule_For(t *testing.T) {
+	schedule := Schedule{
+		KindWithdrawal:  account.NewMoney(250, "BRL"),
+		KindTransfer:    account.Zero("BRL"),
+		KindMaintenance: account.NewMoney(-100, "BRL"),
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		expected account.Money
+		charged  bool
+	}{
+		{"tarifa definida", KindWithdrawal, account.NewMoney(250, "BRL"), true},
+		{"tarifa zero não é cobrada", KindTransfer, account.Money{}, false},
+		{"tarifa negativa não é cobrada", KindMaintenance, account.Money{}, false},
+		{"tipo ausente não é cobrado", "unknown", account.Money{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			amount, charged := schedule.For(tt.kind)
+
+			// Assert
+			assert.Equal(t, tt.charged, charged)
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+}
+
+func TestValidateWaiverKind(t *testing.T) {
+	tests := []struct {
+		kind  string
+		valid bool
+	}{
+		{KindWithdrawal, true},
+		{KindTransfer, true},
+		{KindMaintenance, true},
+		{KindAll, true},
+		{"deposit", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			// Act
+			err := ValidateWaiverKind(tt.kind)
+
+			// Assert
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrUnknownKind)
+		})
+	}
+}
+
+func TestWaiver_Active(t *testing.T) {
+	expiresAt := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		waiver   Waiver
+		at       time.Time
+		expected bool
+	}{
+		{"sem vencimento", Waiver{Kind: KindAll}, expiresAt.AddDate(10, 0, 0), true},
+		{"antes do vencimento", Waiver{Kind: KindAll, ExpiresAt: &expiresAt}, expiresAt.Add(-time.Second), true},
+		{"no vencimento", Waiver{Kind: KindAll, ExpiresAt: &expiresAt}, expiresAt, false},
+		{"depois do vencimento", Waiver{Kind: KindAll, ExpiresAt: &expiresAt}, expiresAt.Add(time.Second), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.waiver.Active(tt.at))
+		})
+	}
+}
+
+func TestPreviousMonthAndPeriod(t *testing.T) {
+	saoPaulo := time.FixedZone("UTC-3", -3*60*60)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		previous time.Time
+		period   string
+	}{
+		{"meio do mês", time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC), time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), "2026-03"},
+		{"virada do ano", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), "2026-01"},
+		// 31/03/2026 22h em São Paulo já é 01/04/2026 em UTC
+		{"mês em UTC", time.Date(2026, time.March, 31, 22, 0, 0, 0, saoPaulo), time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), "2026-04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.previous, PreviousMonth(tt.at))
+			assert.Equal(t, tt.period, Period(tt.at))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package fee
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Repository define a interface para persistência das tarifas e das isenções
+type Repository interface {
+	// FindSchedule retorna as tarifas do tipo de conta na moeda informada,
+	// sem as tarifas de que a conta está isenta em at
+	FindSchedule(accountID string, accountType account.AccountType, currency string, at time.Time) (Schedule, error)
+
+	// SaveWaiver grava a isenção, substituindo a do mesmo tipo, se houver
+	SaveWaiver(w Waiver) error
+
+	// DeleteWaiver remove a isenção, informando se ela existia
+	DeleteWaiver(accountID, kind string) (bool, error)
+
+	// Record registra uma tarifa cobrada. Uma segunda tarifa de manutenção no
+	// mesmo período da conta é recusada.
+	Record(c Charge) error
+
+	// Charged indica se a conta já foi tarifada no período pelo tipo informado
+	Charged(accountID, kind, period string) (bool, error)
+
+	// FindCharge busca a tarifa do tipo informado cobrada da conta pela
+	// operação reference; retorna nil se a operação não foi tarifada
+	FindCharge(accountID, kind, reference string) (*Charge, error)
+}
@@ -0,0 +1,64 @@
+package fx
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Erros de cotações
+var (
+	ErrQuoteExpired     = errors.New("exchange quote has expired")
+	ErrQuoteAlreadyUsed = errors.New("exchange quote has already been used")
+	ErrAmountTooSmall   = errors.New("converted amount rounds to zero")
+)
+
+// Quote é uma cotação de câmbio: fixa a taxa e os dois valores de uma
+// conversão até ExpiresAt, para que o cliente veja o valor que será creditado
+// antes de confirmar a transferência. Cada cotação só pode ser usada uma vez.
+type Quote struct {
+	ID                string
+	Rate              Rate
+	SourceAmount      account.Money
+	DestinationAmount account.Money
+	TransferID        string // Transferência que usou a cotação; vazio enquanto disponível
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+}
+
+// NewQuote cota a conversão de amount pela taxa informada, válida por ttl
+func NewQuote(rate Rate, amount account.Money, ttl time.Duration) (*Quote, error) {
+	if !amount.IsPositive() {
+		return nil, errors.New("quote amount must be positive")
+	}
+	converted, err := rate.Convert(amount)
+	if err != nil {
+		return nil, err
+	}
+	if !converted.IsPositive() {
+		return nil, ErrAmountTooSmall
+	}
+
+	now := time.Now()
+	return &Quote{
+		ID:                uuid.New().String(),
+		Rate:              rate,
+		SourceAmount:      amount,
+		DestinationAmount: converted,
+		ExpiresAt:         now.Add(ttl),
+		CreatedAt:         now,
+	}, nil
+}
+
+// Expired indica se a cotação já venceu no instante informado
+func (q *Quote) Expired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// Used indica se a cotação já foi usada por uma transferência
+func (q *Quote) Used() bool {
+	return q.TransferID != ""
+}
@@ -0,0 +1,133 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// RateScale é o número de casas decimais das taxas de câmbio
+const RateScale = 8
+
+// Erros de taxas de câmbio
+var (
+	ErrInvalidRate  = errors.New("invalid exchange rate")
+	ErrRateNotFound = errors.New("exchange rate not available")
+	ErrSameCurrency = errors.New("conversion requires two different currencies")
+)
+
+// rateUnit é o valor de 1,0 na escala das taxas
+var rateUnit = big.NewInt(100_000_000)
+
+// Rate é a taxa de câmbio de From para To: uma unidade de From vale Rate
+// unidades de To. O valor é guardado com RateScale casas decimais exatas,
+// de modo que a taxa registrada é exatamente a taxa aplicada.
+type Rate struct {
+	From  string
+	To    string
+	units int64 // Valor da taxa multiplicado por 10^RateScale
+}
+
+// ParseRate interpreta uma taxa decimal como "5.0125". Casas além de
+// RateScale só são aceitas se forem zeros.
+func ParseRate(from, to, value string) (Rate, error) {
+	if err := account.ValidateCurrency(from); err != nil {
+		return Rate{}, err
+	}
+	if err := account.ValidateCurrency(to); err != nil {
+		return Rate{}, err
+	}
+	if from == to {
+		return Rate{}, ErrSameCurrency
+	}
+
+	s := strings.TrimSpace(value)
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if intPart == "" || (hasPoint && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	if len(fracPart) > RateScale {
+		if strings.TrimRight(fracPart[RateScale:], "0") != "" {
+			return Rate{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidRate, value, RateScale)
+		}
+		fracPart = fracPart[:RateScale]
+	}
+	fracPart += strings.Repeat("0", RateScale-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil || units <= 0 {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	return Rate{From: from, To: to, units: units}, nil
+}
+
+// Inverse retorna a taxa no sentido oposto, arredondada para RateScale casas
+func (r Rate) Inverse() Rate {
+	// 1 / taxa, na escala: 10^(2*RateScale) / units
+	numerator := new(big.Int).Mul(rateUnit, rateUnit)
+	return Rate{From: r.To, To: r.From, units: roundDiv(numerator, big.NewInt(r.units)).Int64()}
+}
+
+// Convert converte um valor na moeda From para a moeda To, arredondando para a
+// unidade menor mais próxima da moeda de destino (metade para cima)
+func (r Rate) Convert(amount account.Money) (account.Money, error) {
+	if amount.Currency() != r.From {
+		return account.Money{}, fmt.Errorf("%w: rate is from %s, amount is in %s", account.ErrCurrencyMismatch, r.From, amount.Currency())
+	}
+
+	// valor convertido = valor × taxa, ajustado pela diferença de casas decimais das moedas
+	numerator := new(big.Int).Mul(big.NewInt(amount.MinorUnits()), big.NewInt(r.units))
+	numerator.Mul(numerator, pow10(account.CurrencyExponent(r.To)))
+	denominator := new(big.Int).Mul(rateUnit, pow10(account.CurrencyExponent(r.From)))
+
+	converted := roundDiv(numerator, denominator)
+	if !converted.IsInt64() {
+		return account.Money{}, fmt.Errorf("%w: overflow", account.ErrInvalidMoney)
+	}
+	return account.NewMoney(converted.Int64(), r.To), nil
+}
+
+// String formata a taxa como decimal, sem zeros à direita (ex.: "5.0125")
+func (r Rate) String() string {
+	digits := strconv.FormatInt(r.units, 10)
+	if len(digits) <= RateScale {
+		digits = strings.Repeat("0", RateScale-len(digits)+1) + digits
+	}
+	point := len(digits) - RateScale
+	frac := strings.TrimRight(digits[point:], "0")
+	if frac == "" {
+		return digits[:point]
+	}
+	return digits[:point] + "." + frac
+}
+
+// IsZero indica se a taxa não foi definida
+func (r Rate) IsZero() bool {
+	return r.units == 0
+}
+
+// roundDiv divide dois inteiros não negativos arredondando a metade para cima
+func roundDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// mustRate interpreta uma taxa válida
+func mustRate(t *testing.T, from, to, value string) Rate {
+	rate, err := ParseRate(from, to, value)
+	assert.NoError(t, err)
+	return rate
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		value    string
+		expected string
+		err      error
+	}{
+		{"taxa decimal", "USD", "BRL", "5.0125", "5.0125", nil},
+		{"taxa inteira", "USD", "JPY", "150", "150", nil},
+		{"oito casas decimais", "JPY", "USD", "0.00666667", "0.00666667", nil},
+		{"zeros além da escala", "USD", "BRL", "5.012500000", "5.0125", nil},
+		{"casa significativa além da escala", "USD", "BRL", "5.012500001", "", ErrInvalidRate},
+		{"taxa zero", "USD", "BRL", "0", "", ErrInvalidRate},
+		{"taxa negativa", "USD", "BRL", "-5", "", ErrInvalidRate},
+		{"notação científica", "USD", "BRL", "5e2", "", ErrInvalidRate},
+		{"vírgula decimal", "USD", "BRL", "5,01", "", ErrInvalidRate},
+		{"mesma moeda", "BRL", "BRL", "1", "", ErrSameCurrency},
+		{"moeda não suportada", "XYZ", "BRL", "1", "", account.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rate, err := ParseRate(tt.from, tt.to, tt.value)
+
+			// Assert
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rate.String())
+		})
+	}
+}
+
+func TestRate_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     Rate
+		amount   account.Money
+		expected account.Money
+	}{
+		{"mesma precisão", mustRate(t, "USD", "BRL", "5.0125"), account.NewMoney(10000, "USD"), account.NewMoney(50125, "BRL")},
+		{"arredonda a metade para cima", mustRate(t, "USD", "BRL", "5.0125"), account.NewMoney(2, "USD"), account.NewMoney(10, "BRL")},
+		{"arredonda para baixo abaixo da metade", mustRate(t, "USD", "BRL", "5.0124"), account.NewMoney(1, "USD"), account.NewMoney(5, "BRL")},
+		{"para moeda sem casas decimais", mustRate(t, "USD", "JPY", "149.555"), account.NewMoney(1000, "USD"), account.NewMoney(1496, "JPY")},
+		{"de moeda sem casas decimais", mustRate(t, "JPY", "USD", "0.00666667"), account.NewMoney(1500, "JPY"), account.NewMoney(1000, "USD")},
+		{"para moeda com três casas", mustRate(t, "USD", "BHD", "0.376"), account.NewMoney(12345, "USD"), account.NewMoney(46417, "BHD")},
+		{"valor pequeno arredonda para a unidade menor", mustRate(t, "JPY", "USD", "0.00666667"), account.NewMoney(1, "JPY"), account.NewMoney(1, "USD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			converted, err := tt.rate.Convert(tt.amount)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
+
+func TestRate_Convert_CurrencyMismatch(t *testing.T) {
+	// Act
+	_, err := mustRate(t, "USD", "BRL", "5.0125").Convert(account.NewMoney(100, "EUR"))
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrCurrencyMismatch)
+}
+
+func TestRate_Inverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     string
+		expected string
+	}{
+		{"inverso exato", "4", "0.25"},
+		{"arredonda para oito casas", "3", "0.33333333"},
+		{"arredonda a metade para cima", "1.5", "0.66666667"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			inverse := mustRate(t, "USD", "BRL", tt.rate).Inverse()
+
+			// Assert
+			assert.Equal(t, "BRL", inverse.From)
+			assert.Equal(t, "USD", inverse.To)
+			assert.Equal(t, tt.expected, inverse.String())
+		})
+	}
+}
+
+func TestNewQuote(t *testing.T) {
+	rate := mustRate(t, "JPY", "USD", "0.00666667")
+
+	tests := []struct {
+		name     string
+		amount   account.Money
+		valid    bool
+		expected account.Money
+	}{
+		{"fixa o valor convertido", account.NewMoney(1500, "JPY"), true, account.NewMoney(1000, "USD")},
+		{"valor zero", account.Zero("JPY"), false, account.Money{}},
+		{"valor negativo", account.NewMoney(-1500, "JPY"), false, account.Money{}},
+		{"moeda diferente da taxa", account.NewMoney(1500, "EUR"), false, account.Money{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			quote, err := NewQuote(rate, tt.amount, time.Minute)
+
+			// Assert
+			if !tt.valid {
+				assert.Error(t, err)
+				assert.Nil(t, quote)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.amount, quote.SourceAmount)
+			assert.Equal(t, tt.expected, quote.DestinationAmount)
+			assert.False(t, quote.Used())
+			assert.False(t, quote.Expired(quote.CreatedAt))
+			assert.True(t, quote.Expired(quote.ExpiresAt))
+		})
+	}
+}
+
+func TestNewQuote_AmountTooSmall(t *testing.T) {
+	// Act: 0,01 BRL a 0,1 USD/BRL vale 0,001 USD, que arredonda para zero
+	quote, err := NewQuote(mustRate(t, "BRL", "USD", "0.1"), account.NewMoney(1, "BRL"), time.Minute)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAmountTooSmall)
+	assert.Nil(t, quote)
+}
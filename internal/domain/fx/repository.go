@@ -0,0 +1,11 @@
+package fx
+
+// QuoteRepository define a interface para persistência das cotações
+type QuoteRepository interface {
+	Save(quote *Quote) error
+	FindByID(id string) (*Quote, error)
+
+	// MarkUsed vincula a cotação à transferência que a usou. Retorna
+	// ErrQuoteAlreadyUsed se outra transferência a usou antes.
+	MarkUsed(id, transferID string) error
+}
@@ -0,0 +1,145 @@
+package interest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// RateScale é o número de casas decimais das taxas anuais (0.065 = 6,5% ao ano)
+const RateScale = 6
+
+// DaysInYear é a base de dias da apuração diária (juros exatos, ano de 365 dias)
+const DaysInYear = 365
+
+// MicroUnits é a quantidade de frações em que cada menor unidade da moeda é
+// dividida na apuração diária. Os juros de um dia costumam ser frações de
+// centavo; eles são somados nessa precisão e arredondados só no crédito.
+const MicroUnits = 1_000_000
+
+// ErrInvalidRate indica uma taxa de juros anual inválida
+var ErrInvalidRate = errors.New("invalid annual interest rate")
+
+// AnnualRate é uma taxa de juros anual, guardada em milionésimos (RateScale casas)
+type AnnualRate int64
+
+// ParseAnnualRate interpreta uma taxa anual decimal, como "0.065" para 6,5% ao ano
+func ParseAnnualRate(value string) (AnnualRate, error) {
+	s := strings.TrimSpace(value)
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if intPart == "" || (hasPoint && fracPart == "") || len(fracPart) > RateScale ||
+		strings.Trim(intPart, "0123456789") != "" || strings.Trim(fracPart, "0123456789") != "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	fracPart += strings.Repeat("0", RateScale-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	return AnnualRate(units), nil
+}
+
+// String formata a taxa como decimal, sem zeros à direita (ex.: "0.065")
+func (r AnnualRate) String() string {
+	digits := fmt.Sprintf("%0*d", RateScale+1, int64(r))
+	point := len(digits) - RateScale
+	frac := strings.TrimRight(digits[point:], "0")
+	if frac == "" {
+		return digits[:point]
+	}
+	return digits[:point] + "." + frac
+}
+
+// Rates são as taxas anuais de cada tipo de conta; tipos sem taxa não rendem juros
+type Rates map[account.AccountType]AnnualRate
+
+// For retorna a taxa anual do tipo de conta
+func (r Rates) For(accountType account.AccountType) AnnualRate {
+	return r[accountType]
+}
+
+// Accrual é a apuração dos juros de uma conta num dia, sobre o saldo do fim do dia
+type Accrual struct {
+	AccountID string
+	Day       time.Time     // Início do dia apurado, em UTC
+	Balance   account.Money // Saldo no fim do dia
+	Rate      AnnualRate
+	Interest  int64 // Juros do dia, em MicroUnits da menor unidade da moeda
+}
+
+// Accrue apura os juros de um dia: saldo × taxa anual / DaysInYear. Saldos
+// zerados ou negativos (cheque especial) não rendem juros.
+func Accrue(accountID string, day time.Time, balance account.Money, rate AnnualRate) Accrual {
+	a := Accrual{AccountID: accountID, Day: Day(day), Balance: balance, Rate: rate}
+	if !balance.IsPositive() || rate <= 0 {
+		return a
+	}
+
+	// Com a taxa em milionésimos e os juros em MicroUnits, as duas escalas se
+	// cancelam: juros = saldo (menor unidade) × taxa / DaysInYear
+	numerator := new(big.Int).Mul(big.NewInt(balance.MinorUnits()), big.NewInt(int64(rate)))
+	a.Interest = roundDiv(numerator, big.NewInt(DaysInYear)).Int64()
+	return a
+}
+
+// MonthlyInterest é o total de juros apurado numa conta em um mês
+type MonthlyInterest struct {
+	Period string        // Mês de referência, no formato "2006-01"
+	End    time.Time     // Início do mês seguinte; as apurações anteriores a ele pertencem ao período
+	Amount account.Money // Total do mês, arredondado para a menor unidade da moeda
+}
+
+// Monthly agrupa as apurações por mês, em ordem cronológica. O total de cada
+// mês é arredondado para a menor unidade da moeda (metade para cima); a fração
+// restante não é creditada.
+func Monthly(accruals []Accrual, currency string) []MonthlyInterest {
+	totals := make(map[time.Time]int64)
+	for _, a := range accruals {
+		totals[MonthStart(a.Day)] += a.Interest
+	}
+
+	months := make([]time.Time, 0, len(totals))
+	for month := range totals {
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+
+	result := make([]MonthlyInterest, 0, len(months))
+	for _, month := range months {
+		minor := roundDiv(big.NewInt(totals[month]), big.NewInt(MicroUnits)).Int64()
+		result = append(result, MonthlyInterest{
+			Period: month.Format("2006-01"),
+			End:    month.AddDate(0, 1, 0),
+			Amount: account.NewMoney(minor, currency),
+		})
+	}
+	return result
+}
+
+// Day retorna o início do dia de t, em UTC
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthStart retorna o início do mês de t, em UTC
+func MonthStart(t time.Time) time.Time {
+	y, m, _ := t.UTC().Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// roundDiv divide dois inteiros não negativos arredondando a metade para cima
+func roundDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
@@ -0,0 +1,168 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+func TestParseAnnualRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected AnnualRate
+		valid    bool
+	}{
+		{"taxa decimal", "0.065", 65000, true},
+		{"seis casas decimais", "0.000001", 1, true},
+		{"taxa inteira", "1", 1000000, true},
+		{"taxa zero", "0", 0, true},
+		{"mais de seis casas", "0.0000001", 0, false},
+		{"percentual", "6.5%", 0, false},
+		{"negativa", "-0.05", 0, false},
+		{"vírgula decimal", "0,05", 0, false},
+		{"vazia", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rate, err := ParseAnnualRate(tt.value)
+
+			// Assert
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidRate)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rate)
+		})
+	}
+}
+
+func TestAnnualRate_String(t *testing.T) {
+	tests := []struct {
+		rate     AnnualRate
+		expected string
+	}{
+		{65000, "0.065"},
+		{1, "0.000001"},
+		{1000000, "1"},
+		{0, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rate.String())
+		})
+	}
+}
+
+func TestAccrue(t *testing.T) {
+	day := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		balance  account.Money
+		rate     AnnualRate
+		expected int64 // Em MicroUnits da menor unidade da moeda
+	}{
+		// R$ 1.000,00 a 5% ao ano: 100000 × 0,05 / 365 = 13,698630137 centavos
+		{"saldo positivo", account.NewMoney(100000, "BRL"), 50000, 13698630},
+		// 183 × 0,000001 / 365 = 0,5014 micro-centavo
+		{"arredonda a metade para cima", account.NewMoney(183, "BRL"), 1, 1},
+		// 182 × 0,000001 / 365 = 0,4986 micro-centavo
+		{"arredonda para baixo abaixo da metade", account.NewMoney(182, "BRL"), 1, 0},
+		// 1.000.000 ienes a 0,5% ao ano: 1000000 × 0,005 / 365 = 13,698630137 ienes
+		{"moeda sem casas decimais", account.NewMoney(1000000, "JPY"), 5000, 13698630},
+		{"saldo zero", account.Zero("BRL"), 50000, 0},
+		{"saldo negativo no cheque especial", account.NewMoney(-50000, "BRL"), 50000, 0},
+		{"taxa zero", account.NewMoney(100000, "BRL"), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			accrual := Accrue("account-123", day, tt.balance, tt.rate)
+
+			// Assert
+			assert.Equal(t, "account-123", accrual.AccountID)
+			assert.Equal(t, time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC), accrual.Day)
+			assert.Equal(t, tt.balance, accrual.Balance)
+			assert.Equal(t, tt.rate, accrual.Rate)
+			assert.Equal(t, tt.expected, accrual.Interest)
+		})
+	}
+}
+
+func TestDay_UsesUTC(t *testing.T) {
+	// 31/01/2026 23h em São Paulo (UTC-3) já é 01/02/2026 em UTC
+	saoPaulo := time.FixedZone("UTC-3", -3*60*60)
+	at := time.Date(2026, time.January, 31, 23, 0, 0, 0, saoPaulo)
+
+	// Act & Assert
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), Day(at))
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), MonthStart(at))
+}
+
+func TestMonthly(t *testing.T) {
+	accrual := func(month time.Month, day int, interest int64) Accrual {
+		return Accrual{Day: time.Date(2026, month, day, 0, 0, 0, 0, time.UTC), Interest: interest}
+	}
+
+	tests := []struct {
+		name     string
+		accruals []Accrual
+		expected []MonthlyInterest
+	}{
+		{
+			name:     "sem apurações",
+			accruals: nil,
+			expected: []MonthlyInterest{},
+		},
+		{
+			name: "frações somadas antes do arredondamento",
+			accruals: []Accrual{
+				accrual(time.January, 1, 400_000),
+				accrual(time.January, 2, 400_000),
+				accrual(time.January, 3, 400_000),
+			},
+			expected: []MonthlyInterest{
+				{Period: "2026-01", End: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), Amount: account.NewMoney(1, "BRL")},
+			},
+		},
+		{
+			name: "meses em ordem cronológica, cada um arredondado",
+			accruals: []Accrual{
+				accrual(time.March, 1, 1_499_999),
+				accrual(time.February, 28, 1_500_000),
+				accrual(time.February, 1, 0),
+			},
+			expected: []MonthlyInterest{
+				{Period: "2026-02", End: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), Amount: account.NewMoney(2, "BRL")},
+				{Period: "2026-03", End: time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), Amount: account.NewMoney(1, "BRL")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := Monthly(tt.accruals, "BRL")
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRates_For(t *testing.T) {
+	rates := Rates{account.TypeSavings: 50000}
+
+	// Act & Assert: tipos sem taxa não rendem juros
+	assert.Equal(t, AnnualRate(50000), rates.For(account.TypeSavings))
+	assert.Equal(t, AnnualRate(0), rates.For(account.TypeChecking))
+}
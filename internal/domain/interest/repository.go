@@ -0,0 +1,19 @@
+package interest
+
+import "time"
+
+// Repository define a interface para persistência das apurações de juros
+type Repository interface {
+	// LastAccrualDay retorna o último dia apurado da conta; zero se nenhum foi
+	LastAccrualDay(accountID string) (time.Time, error)
+
+	// Record grava a apuração de um dia. Um dia já apurado não é gravado de novo.
+	Record(accrual Accrual) error
+
+	// Unposted retorna as apurações da conta anteriores a before ainda não creditadas
+	Unposted(accountID string, before time.Time) ([]Accrual, error)
+
+	// MarkPosted vincula as apurações da conta anteriores a before, ainda não
+	// creditadas, ao evento que as creditou
+	MarkPosted(accountID string, before time.Time, eventID string) error
+}
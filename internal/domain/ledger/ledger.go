@@ -0,0 +1,217 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Contas de sistema, contrapartidas dos movimentos das contas de clientes
+const (
+	// CashInAccount recebe a contrapartida dos depósitos
+	CashInAccount = "system:cash-in"
+	// CashOutAccount recebe a contrapartida dos saques
+	CashOutAccount = "system:cash-out"
+	// TransfersInTransitAccount guarda valores debitados da origem e ainda não creditados no destino
+	TransfersInTransitAccount = "system:transfers-in-transit"
+	// OpeningBalanceAccount é a contrapartida dos saldos existentes antes do razão
+	OpeningBalanceAccount = "system:opening-balance"
+	// CardSettlementAccount recebe a contrapartida das capturas de reservas
+	CardSettlementAccount = "system:card-settlement"
+	// InterestExpenseAccount é a contrapartida dos juros creditados nas contas
+	InterestExpenseAccount = "system:interest-expense"
+	// FeeIncomeAccount é a contrapartida das tarifas cobradas das contas
+	FeeIncomeAccount = "system:fee-income"
+	// FXConversionAccount é a contrapartida das conversões de câmbio, com saldo em cada moeda
+	FXConversionAccount = "system:fx-conversion"
+)
+
+// Descrições dos lançamentos de depósitos e saques, os movimentos que podem ser estornados
+const (
+	DescriptionDeposit    = "deposit"
+	DescriptionWithdrawal = "withdrawal"
+)
+
+// ErrUnbalancedEntry indica que a soma dos débitos difere da soma dos créditos
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+// Direction indica se o lançamento é a débito ou a crédito
+type Direction string
+
+const (
+	DirectionDebit  Direction = "debit"
+	DirectionCredit Direction = "credit"
+)
+
+// Posting é uma perna do lançamento contábil contra uma conta
+type Posting struct {
+	AccountID string
+	Direction Direction
+	Amount    account.Money
+}
+
+// Debit cria uma perna a débito
+func Debit(accountID string, amount account.Money) Posting {
+	return Posting{AccountID: accountID, Direction: DirectionDebit, Amount: amount}
+}
+
+// Credit cria uma perna a crédito
+func Credit(accountID string, amount account.Money) Posting {
+	return Posting{AccountID: accountID, Direction: DirectionCredit, Amount: amount}
+}
+
+// JournalEntry é um lançamento imutável no razão, composto por pernas balanceadas.
+//
+// As contas de clientes são passivos: créditos aumentam o saldo e débitos o
+// reduzem, de modo que o saldo de uma conta é a soma dos créditos menos a
+// soma dos débitos.
+type JournalEntry struct {
+	ID              string
+	EventID         string // Evento de domínio que originou o lançamento
+	Description     string
+	Postings        []Posting
+	ReversesEventID string // Num estorno, o evento cujo lançamento é estornado
+	CreatedAt       time.Time
+}
+
+// NewJournalEntry cria um lançamento, garantindo que débitos e créditos se anulem em cada moeda
+func NewJournalEntry(eventID, description string, postings ...Posting) (*JournalEntry, error) {
+	if eventID == "" {
+		return nil, errors.New("journal entry must reference an event")
+	}
+	if len(postings) < 2 {
+		return nil, errors.New("journal entry needs at least two postings")
+	}
+
+	totals := make(map[string]int64)
+	for _, p := range postings {
+		if p.AccountID == "" {
+			return nil, errors.New("posting account is required")
+		}
+		if !p.Amount.IsPositive() {
+			return nil, errors.New("posting amount must be positive")
+		}
+		switch p.Direction {
+		case DirectionDebit:
+			totals[p.Amount.Currency()] -= p.Amount.MinorUnits()
+		case DirectionCredit:
+			totals[p.Amount.Currency()] += p.Amount.MinorUnits()
+		default:
+			return nil, fmt.Errorf("invalid posting direction %q", p.Direction)
+		}
+	}
+	for currency, total := range totals {
+		if total != 0 {
+			return nil, fmt.Errorf("%w: %s differs by %d minor units", ErrUnbalancedEntry, currency, total)
+		}
+	}
+
+	return &JournalEntry{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		Description: description,
+		Postings:    postings,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewDepositEntry lança um depósito: débito no caixa de entrada, crédito na conta
+func NewDepositEntry(eventID, accountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, DescriptionDeposit,
+		Debit(CashInAccount, amount),
+		Credit(accountID, amount),
+	)
+}
+
+// NewWithdrawalEntry lança um saque: débito na conta, crédito no caixa de saída
+func NewWithdrawalEntry(eventID, accountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, DescriptionWithdrawal,
+		Debit(accountID, amount),
+		Credit(CashOutAccount, amount),
+	)
+}
+
+// NewReversalEntry lança o estorno de um lançamento: as mesmas pernas com as
+// direções invertidas, referenciando o evento original
+func NewReversalEntry(eventID string, original *JournalEntry) (*JournalEntry, error) {
+	postings := make([]Posting, 0, len(original.Postings))
+	for _, p := range original.Postings {
+		if p.Direction == DirectionDebit {
+			postings = append(postings, Credit(p.AccountID, p.Amount))
+		} else {
+			postings = append(postings, Debit(p.AccountID, p.Amount))
+		}
+	}
+
+	entry, err := NewJournalEntry(eventID, "reversal of "+original.EventID, postings...)
+	if err != nil {
+		return nil, err
+	}
+	entry.ReversesEventID = original.EventID
+	return entry, nil
+}
+
+// NewTransferDebitEntry lança a saída de uma transferência da conta de origem para a conta de trânsito
+func NewTransferDebitEntry(eventID, transferID, sourceAccountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "transfer "+transferID+" debit",
+		Debit(sourceAccountID, amount),
+		Credit(TransfersInTransitAccount, amount),
+	)
+}
+
+// NewTransferCreditEntry lança a chegada de uma transferência na conta de destino
+func NewTransferCreditEntry(eventID, transferID, destinationAccountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "transfer "+transferID+" credit",
+		Debit(TransfersInTransitAccount, amount),
+		Credit(destinationAccountID, amount),
+	)
+}
+
+// NewConvertedTransferCreditEntry lança a chegada de uma transferência entre moedas:
+// o valor de origem sai da conta de trânsito para a conta de câmbio, que entrega o
+// valor convertido ao destino. Cada moeda fecha separadamente.
+func NewConvertedTransferCreditEntry(eventID, transferID, destinationAccountID string, amount, converted account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "transfer "+transferID+" credit",
+		Debit(TransfersInTransitAccount, amount),
+		Credit(FXConversionAccount, amount),
+		Debit(FXConversionAccount, converted),
+		Credit(destinationAccountID, converted),
+	)
+}
+
+// NewTransferRefundEntry lança a devolução à origem de uma transferência que falhou
+func NewTransferRefundEntry(eventID, transferID, sourceAccountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "transfer "+transferID+" refund",
+		Debit(TransfersInTransitAccount, amount),
+		Credit(sourceAccountID, amount),
+	)
+}
+
+// NewInterestEntry lança o crédito de juros: débito na despesa de juros, crédito na conta
+func NewInterestEntry(eventID, accountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "interest",
+		Debit(InterestExpenseAccount, amount),
+		Credit(accountID, amount),
+	)
+}
+
+// NewFeeEntry lança a cobrança de uma tarifa: débito na conta, crédito na receita de tarifas
+func NewFeeEntry(eventID, accountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "fee",
+		Debit(accountID, amount),
+		Credit(FeeIncomeAccount, amount),
+	)
+}
+
+// NewHoldCaptureEntry lança a captura de uma reserva: débito na conta, crédito na liquidação de cartões.
+// A reserva em si não gera lançamento, pois não movimenta o saldo contábil.
+func NewHoldCaptureEntry(eventID, holdID, accountID string, amount account.Money) (*JournalEntry, error) {
+	return NewJournalEntry(eventID, "hold "+holdID+" capture",
+		Debit(accountID, amount),
+		Credit(CardSettlementAccount, amount),
+	)
+}
@@ -0,0 +1,37 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Discrepancy descreve uma conta cujo saldo armazenado difere do saldo do razão
+type Discrepancy struct {
+	AccountID     string
+	StoredBalance account.Money
+	LedgerBalance account.Money
+}
+
+// Repository define a interface para persistência do razão. Lançamentos são
+// imutáveis: não há operações de alteração ou remoção.
+type Repository interface {
+	// Append grava um lançamento e suas pernas
+	Append(entry *JournalEntry) error
+
+	// FindByEventID busca o lançamento originado pelo evento; retorna nil se não existir
+	FindByEventID(eventID string) (*JournalEntry, error)
+
+	// Reversed indica se o lançamento originado pelo evento já foi estornado
+	Reversed(eventID string) (bool, error)
+
+	// Balance retorna o saldo de uma conta segundo o razão (créditos menos débitos)
+	Balance(accountID, currency string) (account.Money, error)
+
+	// BalanceAt retorna o saldo de uma conta segundo o razão considerando apenas
+	// os lançamentos anteriores a at
+	BalanceAt(accountID, currency string, at time.Time) (account.Money, error)
+
+	// Reconcile compara accounts.balance com o razão e retorna as divergências
+	Reconcile() ([]Discrepancy, error)
+}
@@ -0,0 +1,194 @@
+package limit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Níveis (tiers) de conta com políticas de limite predefinidas
+const (
+	TierStandard = "standard"
+	TierPremium  = "premium"
+)
+
+// Limites verificados em cada saque
+const (
+	PerTransaction = "per_transaction"
+	Daily          = "daily"
+	Monthly        = "monthly"
+)
+
+// Janelas móveis sobre as quais o uso diário e mensal é somado
+const (
+	DailyWindow   = 24 * time.Hour
+	MonthlyWindow = 30 * 24 * time.Hour
+)
+
+// ErrLimitExceeded indica que o saque ultrapassa um dos limites da conta.
+// O erro concreto é um *ExceededError, com o saldo restante de cada limite.
+var ErrLimitExceeded = errors.New("withdrawal limit exceeded")
+
+// Policy reúne os limites de saque de uma conta, na moeda da conta.
+// Um limite nil não é aplicado.
+type Policy struct {
+	Tier           string
+	PerTransaction *account.Money
+	Daily          *account.Money
+	Monthly        *account.Money
+}
+
+// caps são os limites por transação, diário e mensal, em unidades inteiras
+// da moeda (reais, dólares, ienes)
+type caps struct {
+	perTransaction int64
+	daily          int64
+	monthly        int64
+}
+
+// standardCaps são os limites do nível padrão em cada moeda suportada. Os
+// valores equivalem aproximadamente aos de BRL, arredondados na própria moeda,
+// para que nenhuma conta fique sem limites por estar em outra moeda.
+var standardCaps = map[string]caps{
+	"BRL": {5_000, 10_000, 50_000},
+	"USD": {1_000, 2_000, 10_000},
+	"EUR": {1_000, 2_000, 10_000},
+	"GBP": {800, 1_600, 8_000},
+	"CHF": {900, 1_800, 9_000},
+	"CAD": {1_300, 2_600, 13_000},
+	"AUD": {1_500, 3_000, 15_000},
+	"MXN": {17_000, 34_000, 170_000},
+	"ARS": {900_000, 1_800_000, 9_000_000},
+	"JPY": {150_000, 300_000, 1_500_000},
+	"KRW": {1_300_000, 2_600_000, 13_000_000},
+	"CLP": {900_000, 1_800_000, 9_000_000},
+	"BHD": {375, 750, 3_750},
+	"KWD": {300, 600, 3_000},
+	"JOD": {700, 1_400, 7_000},
+	"OMR": {385, 770, 3_850},
+}
+
+// tierMultipliers multiplicam os limites do nível padrão em cada nível
+var tierMultipliers = map[string]caps{
+	TierStandard: {1, 1, 1},
+	TierPremium:  {4, 5, 4},
+}
+
+// ForTier retorna a política do nível para uma conta na moeda informada.
+// Retorna false para níveis ou moedas sem limites definidos.
+func ForTier(tier, currency string) (Policy, bool) {
+	multiplier, ok := tierMultipliers[tier]
+	if !ok {
+		return Policy{}, false
+	}
+	base, ok := standardCaps[currency]
+	if !ok {
+		return Policy{}, false
+	}
+	return Policy{
+		Tier:           tier,
+		PerTransaction: majorUnits(base.perTransaction*multiplier.perTransaction, currency),
+		Daily:          majorUnits(base.daily*multiplier.daily, currency),
+		Monthly:        majorUnits(base.monthly*multiplier.monthly, currency),
+	}, true
+}
+
+// majorUnits converte um valor em unidades inteiras da moeda para Money
+func majorUnits(amount int64, currency string) *account.Money {
+	minor := amount
+	for i := 0; i < account.CurrencyExponent(currency); i++ {
+		minor *= 10
+	}
+	m := account.NewMoney(minor, currency)
+	return &m
+}
+
+// Usage é o total sacado pela conta nas janelas móveis diária e mensal
+type Usage struct {
+	Daily   account.Money
+	Monthly account.Money
+}
+
+// Allowance é quanto ainda pode ser sacado sob cada limite; nil quando o
+// limite não se aplica à conta
+type Allowance struct {
+	PerTransaction *account.Money `json:"per_transaction"`
+	Daily          *account.Money `json:"daily"`
+	Monthly        *account.Money `json:"monthly"`
+}
+
+// ExceededError descreve o limite ultrapassado e o saldo restante de cada limite
+type ExceededError struct {
+	Limit     string
+	Remaining Allowance
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit", ErrLimitExceeded, e.Limit)
+}
+
+// Is faz errors.Is(err, ErrLimitExceeded) reconhecer o erro
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// Remaining calcula quanto ainda pode ser sacado, dado o uso atual
+func (p Policy) Remaining(usage Usage) (Allowance, error) {
+	daily, err := remaining(p.Daily, usage.Daily)
+	if err != nil {
+		return Allowance{}, err
+	}
+	monthly, err := remaining(p.Monthly, usage.Monthly)
+	if err != nil {
+		return Allowance{}, err
+	}
+	return Allowance{PerTransaction: p.PerTransaction, Daily: daily, Monthly: monthly}, nil
+}
+
+// Check verifica se um saque de amount cabe nos limites, dado o uso atual.
+// Retorna um *ExceededError com o primeiro limite ultrapassado.
+func (p Policy) Check(amount account.Money, usage Usage) error {
+	allowance, err := p.Remaining(usage)
+	if err != nil {
+		return err
+	}
+
+	checks := []struct {
+		limit string
+		left  *account.Money
+	}{
+		{PerTransaction, allowance.PerTransaction},
+		{Daily, allowance.Daily},
+		{Monthly, allowance.Monthly},
+	}
+	for _, c := range checks {
+		if c.left == nil {
+			continue
+		}
+		cmp, err := amount.Cmp(*c.left)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			return &ExceededError{Limit: c.limit, Remaining: allowance}
+		}
+	}
+	return nil
+}
+
+// remaining retorna o que resta do limite, nunca menos que zero
+func remaining(ceiling *account.Money, used account.Money) (*account.Money, error) {
+	if ceiling == nil {
+		return nil, nil
+	}
+	left, err := ceiling.Sub(used)
+	if err != nil {
+		return nil, err
+	}
+	if left.IsNegative() {
+		left = account.Zero(ceiling.Currency())
+	}
+	return &left, nil
+}
@@ -0,0 +1,165 @@
+package limit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// brl cria um valor em BRL a partir de centavos
+func brl(cents int64) account.Money {
+	return account.NewMoney(cents, "BRL")
+}
+
+// brlPtr cria um limite em BRL a partir de centavos
+func brlPtr(cents int64) *account.Money {
+	m := brl(cents)
+	return &m
+}
+
+// testPolicy limita a R$ 100,00 por saque, R$ 300,00 por dia e R$ 1.000,00 por mês
+var testPolicy = Policy{
+	Tier:           TierStandard,
+	PerTransaction: brlPtr(10000),
+	Daily:          brlPtr(30000),
+	Monthly:        brlPtr(100000),
+}
+
+func TestForTier(t *testing.T) {
+	tests := []struct {
+		name           string
+		tier           string
+		currency       string
+		ok             bool
+		perTransaction account.Money
+		daily          account.Money
+		monthly        account.Money
+	}{
+		{"padrão em BRL", TierStandard, "BRL", true, brl(500000), brl(1000000), brl(5000000)},
+		{"premium em BRL", TierPremium, "BRL", true, brl(2000000), brl(5000000), brl(20000000)},
+		{"padrão em JPY, sem casas decimais", TierStandard, "JPY", true, account.NewMoney(150000, "JPY"), account.NewMoney(300000, "JPY"), account.NewMoney(1500000, "JPY")},
+		{"padrão em BHD, com três casas", TierStandard, "BHD", true, account.NewMoney(375000, "BHD"), account.NewMoney(750000, "BHD"), account.NewMoney(3750000, "BHD")},
+		{"nível desconhecido", "gold", "BRL", false, account.Money{}, account.Money{}, account.Money{}},
+		{"moeda sem limites", TierStandard, "XYZ", false, account.Money{}, account.Money{}, account.Money{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			policy, ok := ForTier(tt.tier, tt.currency)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				return
+			}
+			assert.Equal(t, tt.tier, policy.Tier)
+			assert.Equal(t, tt.perTransaction, *policy.PerTransaction)
+			assert.Equal(t, tt.daily, *policy.Daily)
+			assert.Equal(t, tt.monthly, *policy.Monthly)
+		})
+	}
+}
+
+func TestForTier_EverySupportedCurrency(t *testing.T) {
+	for currency := range standardCaps {
+		// Act
+		policy, ok := ForTier(TierStandard, currency)
+
+		// Assert: todas as moedas aceitas nas contas têm limites na própria moeda
+		assert.NoError(t, account.ValidateCurrency(currency))
+		assert.True(t, ok, currency)
+		assert.Equal(t, currency, policy.PerTransaction.Currency())
+		assert.True(t, policy.PerTransaction.IsPositive(), currency)
+	}
+}
+
+func TestPolicy_Remaining(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		usage   Usage
+		daily   *account.Money
+		monthly *account.Money
+	}{
+		{"sem uso", testPolicy, Usage{Daily: brl(0), Monthly: brl(0)}, brlPtr(30000), brlPtr(100000)},
+		{"uso parcial", testPolicy, Usage{Daily: brl(12000), Monthly: brl(40000)}, brlPtr(18000), brlPtr(60000)},
+		{"uso acima do limite não fica negativo", testPolicy, Usage{Daily: brl(35000), Monthly: brl(100000)}, brlPtr(0), brlPtr(0)},
+		{"limites nil não se aplicam", Policy{Tier: TierStandard}, Usage{Daily: brl(50000), Monthly: brl(50000)}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			allowance, err := tt.policy.Remaining(tt.usage)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.policy.PerTransaction, allowance.PerTransaction)
+			assert.Equal(t, tt.daily, allowance.Daily)
+			assert.Equal(t, tt.monthly, allowance.Monthly)
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		amount   account.Money
+		usage    Usage
+		exceeded string // Limite ultrapassado; vazio se o saque couber
+	}{
+		{"dentro dos limites", testPolicy, brl(5000), Usage{Daily: brl(0), Monthly: brl(0)}, ""},
+		{"exatamente no limite por transação", testPolicy, brl(10000), Usage{Daily: brl(0), Monthly: brl(0)}, ""},
+		{"acima do limite por transação", testPolicy, brl(10001), Usage{Daily: brl(0), Monthly: brl(0)}, PerTransaction},
+		{"esgota o limite diário", testPolicy, brl(5000), Usage{Daily: brl(25000), Monthly: brl(25000)}, ""},
+		{"acima do limite diário", testPolicy, brl(5001), Usage{Daily: brl(25000), Monthly: brl(25000)}, Daily},
+		{"acima do limite mensal", testPolicy, brl(5000), Usage{Daily: brl(0), Monthly: brl(96000)}, Monthly},
+		{"por transação é verificado primeiro", testPolicy, brl(20000), Usage{Daily: brl(30000), Monthly: brl(100000)}, PerTransaction},
+		{"sem limites", Policy{Tier: TierStandard}, brl(1_000_000_00), Usage{Daily: brl(0), Monthly: brl(0)}, ""},
+		{"só o limite diário", Policy{Daily: brlPtr(30000)}, brl(30001), Usage{Daily: brl(0), Monthly: brl(0)}, Daily},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.policy.Check(tt.amount, tt.usage)
+
+			// Assert
+			if tt.exceeded == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrLimitExceeded)
+			var exceeded *ExceededError
+			assert.True(t, errors.As(err, &exceeded))
+			assert.Equal(t, tt.exceeded, exceeded.Limit)
+		})
+	}
+}
+
+func TestPolicy_Check_ReportsRemaining(t *testing.T) {
+	// Act
+	err := testPolicy.Check(brl(10000), Usage{Daily: brl(25000), Monthly: brl(40000)})
+
+	// Assert: o erro traz quanto ainda resta de cada limite
+	var exceeded *ExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, Daily, exceeded.Limit)
+	assert.Equal(t, brlPtr(10000), exceeded.Remaining.PerTransaction)
+	assert.Equal(t, brlPtr(5000), exceeded.Remaining.Daily)
+	assert.Equal(t, brlPtr(60000), exceeded.Remaining.Monthly)
+	assert.Equal(t, "withdrawal limit exceeded: daily limit", err.Error())
+}
+
+func TestPolicy_Check_CurrencyMismatch(t *testing.T) {
+	// Act
+	err := testPolicy.Check(account.NewMoney(100, "USD"), Usage{Daily: brl(0), Monthly: brl(0)})
+
+	// Assert
+	assert.ErrorIs(t, err, account.ErrCurrencyMismatch)
+}
@@ -0,0 +1,25 @@
+package limit
+
+import (
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Repository define a interface para persistência dos limites e do uso de saques
+type Repository interface {
+	// FindPolicy retorna a política de limites da conta, na moeda da conta;
+	// sem limites próprios, vale a política do nível padrão
+	FindPolicy(accountID, currency string) (Policy, error)
+
+	// Usage soma os saques da conta nas janelas móveis que terminam em now
+	Usage(accountID, currency string, now time.Time) (Usage, error)
+
+	// Record registra um saque no uso da conta. eventID identifica o saque,
+	// de modo que o mesmo evento não é contado duas vezes.
+	Record(accountID, eventID string, amount account.Money, at time.Time) error
+
+	// Release retira do uso da conta o saque identificado por eventID, quando
+	// ele é desfeito; um saque que não está no uso é ignorado
+	Release(accountID, eventID string) error
+}
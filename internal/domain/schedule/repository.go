@@ -0,0 +1,19 @@
+package schedule
+
+import "time"
+
+// Repository define a interface para persistência dos agendamentos
+type Repository interface {
+	Save(s *Schedule) error
+	FindByID(id string) (*Schedule, error)
+
+	// FindByAccount retorna os agendamentos em que a conta é a origem, dos mais recentes aos mais antigos
+	FindByAccount(accountID string) ([]*Schedule, error)
+
+	// FindDue retorna até limit agendamentos ativos com ocorrência a executar em now
+	FindDue(now time.Time, limit int) ([]*Schedule, error)
+
+	// Update persiste o agendamento se a versão ainda for a lida, incrementando-a;
+	// caso contrário falha com ErrVersionConflict
+	Update(s *Schedule) error
+}
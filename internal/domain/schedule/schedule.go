@@ -0,0 +1,259 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// Frequency é a periodicidade de um agendamento
+type Frequency string
+
+const (
+	FrequencyOnce    Frequency = "once"    // Uma única vez, na data inicial
+	FrequencyDaily   Frequency = "daily"   // Todos os dias
+	FrequencyWeekly  Frequency = "weekly"  // No mesmo dia da semana da data inicial
+	FrequencyMonthly Frequency = "monthly" // No mesmo dia do mês da data inicial
+)
+
+// Políticas para uma ocorrência sem saldo suficiente
+const (
+	OnInsufficientFundsRetry = "retry" // Tenta de novo depois do intervalo de retentativa
+	OnInsufficientFundsSkip  = "skip"  // Pula a ocorrência e aguarda a próxima
+)
+
+// Limites de tentativas de uma ocorrência
+const (
+	DefaultMaxAttempts = 3
+	MaxAttemptsLimit   = 10
+)
+
+// Status é o estado de um agendamento
+type Status string
+
+const (
+	StatusActive    Status = "active"    // Aguardando a próxima ocorrência
+	StatusCompleted Status = "completed" // Todas as ocorrências foram processadas
+	StatusCancelled Status = "cancelled" // Cancelado pelo cliente
+)
+
+// Erros de domínio de agendamentos
+var (
+	ErrInvalidFrequency = errors.New("invalid schedule frequency")
+	ErrInvalidPolicy    = errors.New("invalid insufficient funds policy")
+	ErrInvalidAttempts  = errors.New("max attempts must be between 1 and 10")
+	ErrInvalidStartDate = errors.New("start date must not be in the past")
+	ErrInvalidEndDate   = errors.New("end date must not be before the start date")
+	ErrNotActive        = errors.New("schedule is not active")
+	ErrVersionConflict  = errors.New("schedule was modified concurrently")
+)
+
+// DateLayout é o formato das datas dos agendamentos
+const DateLayout = "2006-01-02"
+
+// Schedule é uma transferência agendada, única ou recorrente. Cada ocorrência
+// é identificada pela sua data (NextRunAt) e gera uma transferência com ID
+// derivado do agendamento e da data, o que garante uma única transferência
+// por ocorrência mesmo que ela seja processada mais de uma vez.
+type Schedule struct {
+	ID                   string
+	SourceAccountID      string
+	DestinationAccountID string
+	Amount               account.Money
+	Frequency            Frequency
+	StartDate            time.Time
+	EndDate              *time.Time
+	OnInsufficientFunds  string
+	MaxAttempts          int
+	Status               Status
+	NextRunAt            time.Time  // Data da próxima ocorrência
+	Attempts             int        // Tentativas sem saldo da ocorrência atual
+	RetryAt              *time.Time // Quando a ocorrência atual será tentada de novo
+	LastTransferID       string
+	LastError            string
+	Version              int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// NewSchedule cria um agendamento ativo cuja primeira ocorrência é startDate
+func NewSchedule(sourceAccountID, destinationAccountID string, amount account.Money, frequency Frequency, startDate time.Time, endDate *time.Time, onInsufficientFunds string, maxAttempts int, now time.Time) (*Schedule, error) {
+	if sourceAccountID == destinationAccountID {
+		return nil, errors.New("source and destination accounts must be different")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("schedule amount must be positive")
+	}
+	if err := validateFrequency(frequency); err != nil {
+		return nil, err
+	}
+	startDate = Day(startDate)
+	if startDate.Before(Day(now)) {
+		return nil, ErrInvalidStartDate
+	}
+
+	s := &Schedule{
+		ID:                   uuid.New().String(),
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Frequency:            frequency,
+		StartDate:            startDate,
+		Status:               StatusActive,
+		NextRunAt:            startDate,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if err := s.Change(amount, endDate, onInsufficientFunds, maxAttempts, now); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Change altera o valor, a data final e a política de saldo insuficiente de um
+// agendamento ativo. Valores vazios assumem os padrões: retry e 3 tentativas.
+func (s *Schedule) Change(amount account.Money, endDate *time.Time, onInsufficientFunds string, maxAttempts int, now time.Time) error {
+	if s.Status != StatusActive {
+		return ErrNotActive
+	}
+	if !amount.IsPositive() {
+		return errors.New("schedule amount must be positive")
+	}
+	if onInsufficientFunds == "" {
+		onInsufficientFunds = OnInsufficientFundsRetry
+	}
+	if onInsufficientFunds != OnInsufficientFundsRetry && onInsufficientFunds != OnInsufficientFundsSkip {
+		return fmt.Errorf("%w: %q", ErrInvalidPolicy, onInsufficientFunds)
+	}
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if maxAttempts < 1 || maxAttempts > MaxAttemptsLimit {
+		return ErrInvalidAttempts
+	}
+	if endDate != nil {
+		end := Day(*endDate)
+		if end.Before(s.StartDate) {
+			return ErrInvalidEndDate
+		}
+		endDate = &end
+	}
+
+	s.Amount = amount
+	s.EndDate = endDate
+	s.OnInsufficientFunds = onInsufficientFunds
+	s.MaxAttempts = maxAttempts
+	s.UpdatedAt = now
+	if s.EndDate != nil && s.NextRunAt.After(*s.EndDate) {
+		s.Status = StatusCompleted
+	}
+	return nil
+}
+
+// Cancel cancela o agendamento; ocorrências futuras não são executadas
+func (s *Schedule) Cancel(now time.Time) error {
+	if s.Status != StatusActive {
+		return ErrNotActive
+	}
+	s.Status = StatusCancelled
+	s.RetryAt = nil
+	s.UpdatedAt = now
+	return nil
+}
+
+// TransferID retorna o ID da transferência da ocorrência atual
+func (s *Schedule) TransferID() string {
+	name := "schedule:" + s.ID + ":" + s.NextRunAt.Format(DateLayout)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+// Due indica se a ocorrência atual deve ser executada em now
+func (s *Schedule) Due(now time.Time) bool {
+	if s.Status != StatusActive {
+		return false
+	}
+	if s.RetryAt != nil {
+		return !now.Before(*s.RetryAt)
+	}
+	return !now.Before(s.NextRunAt)
+}
+
+// Executed registra a transferência da ocorrência atual e avança para a próxima
+func (s *Schedule) Executed(transferID string, now time.Time) {
+	s.LastTransferID = transferID
+	s.LastError = ""
+	s.advance(now)
+}
+
+// Skip pula a ocorrência atual, registrando o motivo, e avança para a próxima
+func (s *Schedule) Skip(reason string, now time.Time) {
+	s.LastError = reason
+	s.advance(now)
+}
+
+// InsufficientFunds registra uma tentativa sem saldo. Com a política retry, a
+// ocorrência é tentada de novo em now + retryInterval até esgotar MaxAttempts;
+// depois disso, ou com a política skip, ela é pulada. Retorna se foi pulada.
+func (s *Schedule) InsufficientFunds(reason string, now time.Time, retryInterval time.Duration) bool {
+	s.Attempts++
+	if s.OnInsufficientFunds == OnInsufficientFundsRetry && s.Attempts < s.MaxAttempts {
+		retryAt := now.Add(retryInterval)
+		s.RetryAt = &retryAt
+		s.LastError = reason
+		s.UpdatedAt = now
+		return false
+	}
+	s.Skip(reason, now)
+	return true
+}
+
+// advance passa para a próxima ocorrência ou conclui o agendamento
+func (s *Schedule) advance(now time.Time) {
+	s.Attempts = 0
+	s.RetryAt = nil
+	s.UpdatedAt = now
+
+	next, ok := s.next()
+	if !ok || (s.EndDate != nil && next.After(*s.EndDate)) {
+		s.Status = StatusCompleted
+		return
+	}
+	s.NextRunAt = next
+}
+
+// next calcula a data da ocorrência seguinte à atual. Nas mensais, meses sem o
+// dia da data inicial usam o último dia do mês (31 vira 30 ou 28, por exemplo).
+func (s *Schedule) next() (time.Time, bool) {
+	switch s.Frequency {
+	case FrequencyDaily:
+		return s.NextRunAt.AddDate(0, 0, 1), true
+	case FrequencyWeekly:
+		return s.NextRunAt.AddDate(0, 0, 7), true
+	case FrequencyMonthly:
+		firstOfNext := time.Date(s.NextRunAt.Year(), s.NextRunAt.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+		day := s.StartDate.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return firstOfNext.AddDate(0, 0, day-1), true
+	}
+	return time.Time{}, false
+}
+
+func validateFrequency(frequency Frequency) error {
+	switch frequency {
+	case FrequencyOnce, FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidFrequency, frequency)
+}
+
+// Day trunca t para o início do dia em UTC
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,231 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// testNow é o instante de criação dos agendamentos: 31/01/2026, 9h (UTC)
+var testNow = time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+// date cria a data informada, em UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// newTestSchedule cria um agendamento de R$ 30,00 com primeira ocorrência em 31/01/2026
+func newTestSchedule(t *testing.T, frequency Frequency, endDate *time.Time, policy string, maxAttempts int) *Schedule {
+	s, err := NewSchedule("source", "destination", account.NewMoney(3000, "BRL"), frequency, testNow, endDate, policy, maxAttempts, testNow)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestNewSchedule_Validation(t *testing.T) {
+	before := date(2026, time.January, 30)
+
+	tests := []struct {
+		name        string
+		destination string
+		amount      account.Money
+		frequency   Frequency
+		start       time.Time
+		end         *time.Time
+		policy      string
+		maxAttempts int
+		err         error // Erro esperado; nil apenas confere que houve erro
+		valid       bool
+	}{
+		{"válido", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, nil, "", 0, nil, true},
+		{"início hoje, mais cedo que agora", "destination", account.NewMoney(3000, "BRL"), FrequencyDaily, date(2026, time.January, 31), nil, "", 0, nil, true},
+		{"mesma conta", "source", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, nil, "", 0, nil, false},
+		{"valor zero", "destination", account.Zero("BRL"), FrequencyMonthly, testNow, nil, "", 0, nil, false},
+		{"frequência inválida", "destination", account.NewMoney(3000, "BRL"), "yearly", testNow, nil, "", 0, ErrInvalidFrequency, false},
+		{"início no passado", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, before, nil, "", 0, ErrInvalidStartDate, false},
+		{"fim antes do início", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, &before, "", 0, ErrInvalidEndDate, false},
+		{"política inválida", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, nil, "wait", 0, ErrInvalidPolicy, false},
+		{"tentativas acima do máximo", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, nil, "", MaxAttemptsLimit + 1, ErrInvalidAttempts, false},
+		{"tentativas negativas", "destination", account.NewMoney(3000, "BRL"), FrequencyMonthly, testNow, nil, "", -1, ErrInvalidAttempts, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			s, err := NewSchedule("source", tt.destination, tt.amount, tt.frequency, tt.start, tt.end, tt.policy, tt.maxAttempts, testNow)
+
+			// Assert
+			if !tt.valid {
+				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+				}
+				assert.Nil(t, s)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, StatusActive, s.Status)
+			assert.Equal(t, Day(tt.start), s.NextRunAt)
+			assert.Equal(t, OnInsufficientFundsRetry, s.OnInsufficientFunds)
+			assert.Equal(t, DefaultMaxAttempts, s.MaxAttempts)
+		})
+	}
+}
+
+func TestSchedule_Executed_Advances(t *testing.T) {
+	end := date(2026, time.February, 1)
+
+	tests := []struct {
+		name      string
+		frequency Frequency
+		end       *time.Time
+		runs      int
+		expected  []string // Próxima ocorrência depois de cada execução
+		status    Status
+	}{
+		{"única", FrequencyOnce, nil, 1, []string{"2026-01-31"}, StatusCompleted},
+		{"diária", FrequencyDaily, nil, 2, []string{"2026-02-01", "2026-02-02"}, StatusActive},
+		{"semanal", FrequencyWeekly, nil, 2, []string{"2026-02-07", "2026-02-14"}, StatusActive},
+		{"mensal no dia 31 usa o último dia do mês", FrequencyMonthly, nil, 3, []string{"2026-02-28", "2026-03-31", "2026-04-30"}, StatusActive},
+		{"conclui depois da data final", FrequencyDaily, &end, 2, []string{"2026-02-01", "2026-02-01"}, StatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := newTestSchedule(t, tt.frequency, tt.end, "", 0)
+
+			for i := 0; i < tt.runs; i++ {
+				// Act
+				s.Executed("transfer", testNow)
+
+				// Assert
+				assert.Equal(t, tt.expected[i], s.NextRunAt.Format(DateLayout))
+			}
+			assert.Equal(t, tt.status, s.Status)
+			assert.Equal(t, "transfer", s.LastTransferID)
+			assert.Empty(t, s.LastError)
+		})
+	}
+}
+
+func TestSchedule_InsufficientFunds(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		maxAttempts int
+		attempts    int    // Tentativas sem saldo registradas
+		skipped     bool   // Se a última tentativa pulou a ocorrência
+		next        string // Ocorrência atual depois das tentativas
+	}{
+		{"retry tenta de novo", OnInsufficientFundsRetry, 3, 1, false, "2026-01-31"},
+		{"retry esgota as tentativas", OnInsufficientFundsRetry, 3, 3, true, "2026-02-28"},
+		{"retry com uma tentativa pula na primeira", OnInsufficientFundsRetry, 1, 1, true, "2026-02-28"},
+		{"skip pula na primeira", OnInsufficientFundsSkip, 3, 1, true, "2026-02-28"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := newTestSchedule(t, FrequencyMonthly, nil, tt.policy, tt.maxAttempts)
+
+			// Act
+			var skipped bool
+			for i := 0; i < tt.attempts; i++ {
+				skipped = s.InsufficientFunds("insufficient funds", testNow, time.Hour)
+			}
+
+			// Assert
+			assert.Equal(t, tt.skipped, skipped)
+			assert.Equal(t, tt.next, s.NextRunAt.Format(DateLayout))
+			assert.Equal(t, "insufficient funds", s.LastError)
+			if tt.skipped {
+				assert.Equal(t, 0, s.Attempts)
+				assert.Nil(t, s.RetryAt)
+				return
+			}
+			assert.Equal(t, tt.attempts, s.Attempts)
+			assert.Equal(t, testNow.Add(time.Hour), *s.RetryAt)
+		})
+	}
+}
+
+func TestSchedule_Due(t *testing.T) {
+	retryAt := testNow.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		prepare  func(s *Schedule)
+		now      time.Time
+		expected bool
+	}{
+		{"antes da ocorrência", func(s *Schedule) {}, date(2026, time.January, 30), false},
+		{"no dia da ocorrência", func(s *Schedule) {}, date(2026, time.January, 31), true},
+		{"antes da nova tentativa", func(s *Schedule) { s.RetryAt = &retryAt }, testNow, false},
+		{"na nova tentativa", func(s *Schedule) { s.RetryAt = &retryAt }, retryAt, true},
+		{"cancelado", func(s *Schedule) { s.Status = StatusCancelled }, testNow, false},
+		{"concluído", func(s *Schedule) { s.Status = StatusCompleted }, testNow, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := newTestSchedule(t, FrequencyMonthly, nil, "", 0)
+			tt.prepare(s)
+
+			// Act & Assert
+			assert.Equal(t, tt.expected, s.Due(tt.now))
+		})
+	}
+}
+
+func TestSchedule_Cancel(t *testing.T) {
+	// Arrange
+	s := newTestSchedule(t, FrequencyMonthly, nil, "", 0)
+	s.InsufficientFunds("insufficient funds", testNow, time.Hour)
+
+	// Act
+	err := s.Cancel(testNow)
+
+	// Assert: a nova tentativa pendente é descartada
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCancelled, s.Status)
+	assert.Nil(t, s.RetryAt)
+
+	// Act: só agendamentos ativos podem ser cancelados ou alterados
+	err = s.Cancel(testNow)
+	changeErr := s.Change(account.NewMoney(5000, "BRL"), nil, "", 0, testNow)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotActive)
+	assert.ErrorIs(t, changeErr, ErrNotActive)
+}
+
+func TestSchedule_Change_EndDateBeforeNextRunCompletes(t *testing.T) {
+	// Arrange: a próxima ocorrência é 28/02/2026
+	s := newTestSchedule(t, FrequencyMonthly, nil, "", 0)
+	s.Executed("transfer", testNow)
+	end := date(2026, time.February, 15)
+
+	// Act
+	err := s.Change(account.NewMoney(5000, "BRL"), &end, OnInsufficientFundsSkip, 0, testNow)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, s.Status)
+	assert.Equal(t, account.NewMoney(5000, "BRL"), s.Amount)
+	assert.Equal(t, OnInsufficientFundsSkip, s.OnInsufficientFunds)
+}
+
+func TestSchedule_TransferID(t *testing.T) {
+	// Arrange
+	s := newTestSchedule(t, FrequencyDaily, nil, "", 0)
+	first := s.TransferID()
+
+	// Act & Assert: a mesma ocorrência sempre gera o mesmo ID, e a seguinte, outro
+	assert.Equal(t, first, s.TransferID())
+	s.Executed(first, testNow)
+	assert.NotEqual(t, first, s.TransferID())
+}
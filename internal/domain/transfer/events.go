@@ -0,0 +1,41 @@
+package transfer
+
+import "github.com/viniciuslima/account-EDA/internal/domain/account"
+
+// TransferInitiatedEvent é emitido quando o valor é debitado da conta de origem.
+// Nos eventos de transferência, Amount é o valor debitado da origem e
+// DestinationAmount o valor creditado no destino; diferem apenas nas
+// transferências entre moedas, que registram também a taxa aplicada
+// (ExchangeRate) e a cotação usada (QuoteID).
+type TransferInitiatedEvent struct {
+	account.BaseEvent
+	SourceAccountID      string        `json:"source_account_id"`
+	DestinationAccountID string        `json:"destination_account_id"`
+	Amount               account.Money `json:"amount"`
+	DestinationAmount    account.Money `json:"destination_amount"`
+	ExchangeRate         string        `json:"exchange_rate,omitempty"`
+	QuoteID              string        `json:"quote_id,omitempty"`
+}
+
+// TransferCompletedEvent é emitido quando o valor é creditado na conta de destino
+type TransferCompletedEvent struct {
+	account.BaseEvent
+	SourceAccountID      string        `json:"source_account_id"`
+	DestinationAccountID string        `json:"destination_account_id"`
+	Amount               account.Money `json:"amount"`
+	DestinationAmount    account.Money `json:"destination_amount"`
+	ExchangeRate         string        `json:"exchange_rate,omitempty"`
+	QuoteID              string        `json:"quote_id,omitempty"`
+}
+
+// TransferFailedEvent é emitido quando o crédito falha e o valor é devolvido à origem
+type TransferFailedEvent struct {
+	account.BaseEvent
+	SourceAccountID      string        `json:"source_account_id"`
+	DestinationAccountID string        `json:"destination_account_id"`
+	Amount               account.Money `json:"amount"`
+	DestinationAmount    account.Money `json:"destination_amount"`
+	ExchangeRate         string        `json:"exchange_rate,omitempty"`
+	QuoteID              string        `json:"quote_id,omitempty"`
+	Reason               string        `json:"reason"`
+}
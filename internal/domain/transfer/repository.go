@@ -0,0 +1,12 @@
+package transfer
+
+// Repository define a interface para operações de persistência com a entidade Transfer
+type Repository interface {
+	Save(transfer *Transfer) error
+	FindByID(id string) (*Transfer, error)
+	// Update persiste a finalização de uma transferência. Deve falhar com
+	// ErrAlreadyFinalized se outra transação já a tiver finalizado.
+	Update(transfer *Transfer) error
+	// HasPendingFrom indica se a conta é origem de alguma transferência pendente
+	HasPendingFrom(accountID string) (bool, error)
+}
@@ -0,0 +1,111 @@
+package transfer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+)
+
+// Erros de domínio de transferências
+var (
+	ErrSameAccount      = errors.New("source and destination accounts must be different")
+	ErrAlreadyFinalized = errors.New("transfer is already finalized")
+)
+
+// Status é o tipo para o status da transferência
+type Status string
+
+const (
+	// StatusPending indica que o valor já foi debitado da origem e aguarda crédito no destino
+	StatusPending Status = "pending"
+	// StatusCompleted indica que o valor foi creditado no destino
+	StatusCompleted Status = "completed"
+	// StatusFailed indica que o crédito falhou e o valor foi devolvido à origem
+	StatusFailed Status = "failed"
+)
+
+// Transfer representa a entidade de domínio Transferência entre contas
+type Transfer struct {
+	ID                   string
+	SourceAccountID      string
+	DestinationAccountID string
+	Amount               account.Money // Valor debitado da origem
+	DestinationAmount    account.Money // Valor creditado no destino, na moeda do destino
+	ExchangeRate         string        // Taxa aplicada na conversão; vazio se não houve conversão
+	QuoteID              string        // Cotação usada na conversão, se houver
+	DebitEventID         string        // Evento do débito na origem; vazio nas transferências anteriores ao registro
+	Status               Status
+	FailureReason        string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// NewTransfer cria uma transferência pendente
+func NewTransfer(sourceAccountID, destinationAccountID string, amount account.Money) (*Transfer, error) {
+	if sourceAccountID == "" || destinationAccountID == "" {
+		return nil, errors.New("source and destination accounts are required")
+	}
+	if sourceAccountID == destinationAccountID {
+		return nil, ErrSameAccount
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("transfer amount must be positive")
+	}
+
+	now := time.Now()
+	return &Transfer{
+		ID:                   uuid.New().String(),
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               amount,
+		DestinationAmount:    amount,
+		Status:               StatusPending,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}, nil
+}
+
+// Convert define a conversão da transferência para a moeda do destino, com a
+// taxa aplicada e a cotação que a fixou, se houver
+func (t *Transfer) Convert(rate fx.Rate, converted account.Money, quoteID string) error {
+	if rate.From != t.Amount.Currency() || rate.To != converted.Currency() {
+		return account.ErrCurrencyMismatch
+	}
+	if !converted.IsPositive() {
+		return fx.ErrAmountTooSmall
+	}
+	t.DestinationAmount = converted
+	t.ExchangeRate = rate.String()
+	t.QuoteID = quoteID
+	return nil
+}
+
+// Converted indica se a transferência converte o valor entre moedas
+func (t *Transfer) Converted() bool {
+	return t.ExchangeRate != ""
+}
+
+// Complete marca a transferência como concluída
+func (t *Transfer) Complete() error {
+	if t.Status != StatusPending {
+		return ErrAlreadyFinalized
+	}
+	t.Status = StatusCompleted
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Fail marca a transferência como falha, registrando o motivo
+func (t *Transfer) Fail(reason string) error {
+	if t.Status != StatusPending {
+		return ErrAlreadyFinalized
+	}
+	t.Status = StatusFailed
+	t.FailureReason = reason
+	t.UpdatedAt = time.Now()
+	return nil
+}
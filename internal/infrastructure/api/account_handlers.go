@@ -1,11 +1,17 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/viniciuslima/account-EDA/internal/application/command"
 	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
 )
 
 // AccountHandler gerencia requisições HTTP relacionadas a contas
@@ -13,6 +19,10 @@ type AccountHandler struct {
 	createAccountHandler *command.CreateAccountHandler
 	depositHandler       *command.DepositHandler
 	withdrawHandler      *command.WithdrawHandler
+	blockHandler         *command.BlockAccountHandler
+	activateHandler      *command.ActivateAccountHandler
+	closeHandler         *command.CloseAccountHandler
+	overdraftHandler     *command.SetOverdraftLimitHandler
 	accountQuery         query.AccountQuery
 }
 
@@ -21,12 +31,20 @@ func NewAccountHandler(
 	createAccountHandler *command.CreateAccountHandler,
 	depositHandler *command.DepositHandler,
 	withdrawHandler *command.WithdrawHandler,
+	blockHandler *command.BlockAccountHandler,
+	activateHandler *command.ActivateAccountHandler,
+	closeHandler *command.CloseAccountHandler,
+	overdraftHandler *command.SetOverdraftLimitHandler,
 	accountQuery query.AccountQuery,
 ) *AccountHandler {
 	return &AccountHandler{
 		createAccountHandler: createAccountHandler,
 		depositHandler:       depositHandler,
 		withdrawHandler:      withdrawHandler,
+		blockHandler:         blockHandler,
+		activateHandler:      activateHandler,
+		closeHandler:         closeHandler,
+		overdraftHandler:     overdraftHandler,
 		accountQuery:         accountQuery,
 	}
 }
@@ -43,6 +61,9 @@ func (h *AccountHandler) CreateAccount(c echo.Context) error {
 		if err == command.ErrEmailAlreadyExists {
 			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, account.ErrUnsupportedCurrency) || errors.Is(err, account.ErrUnsupportedAccountType) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -64,14 +85,83 @@ func (h *AccountHandler) GetAccount(c echo.Context) error {
 	return c.JSON(http.StatusOK, account)
 }
 
-// GetAccounts manipula requisições para listar todas as contas
+// GetAccounts manipula requisições para listar as contas, uma página por vez.
+//
+// Parâmetros opcionais: status, email e name (trechos), currency,
+// created_from e created_to (RFC 3339, inclusivos), min_balance e
+// max_balance, sort (campo, prefixado com "-" para ordem decrescente), limit
+// e cursor (valor de next_cursor da página anterior).
 func (h *AccountHandler) GetAccounts(c echo.Context) error {
-	accounts, err := h.accountQuery.GetAll()
+	params := query.AccountListParams{
+		Status:     c.QueryParam("status"),
+		Email:      c.QueryParam("email"),
+		Name:       c.QueryParam("name"),
+		Currency:   c.QueryParam("currency"),
+		MinBalance: c.QueryParam("min_balance"),
+		MaxBalance: c.QueryParam("max_balance"),
+		Sort:       c.QueryParam("sort"),
+		Cursor:     c.QueryParam("cursor"),
+	}
+
+	var err error
+	if params.CreatedFrom, err = parseTimeParam(c, "created_from"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if params.CreatedTo, err = parseTimeParam(c, "created_to"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil || params.Limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+		}
+	}
+
+	page, err := h.accountQuery.GetAll(params)
 	if err != nil {
+		if isInvalidListing(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, accounts)
+	return c.JSON(http.StatusOK, page)
+}
+
+// isInvalidListing indica se os parâmetros da listagem de contas foram recusados
+func isInvalidListing(err error) bool {
+	return errors.Is(err, query.ErrInvalidCursor) ||
+		errors.Is(err, query.ErrInvalidStatusFilter) ||
+		errors.Is(err, query.ErrInvalidBalanceRange) ||
+		errors.Is(err, query.ErrInvalidCreatedRange) ||
+		errors.Is(err, account.ErrInvalidSortField) ||
+		errors.Is(err, account.ErrInvalidMoney) ||
+		errors.Is(err, account.ErrUnsupportedCurrency)
+}
+
+// GetBalance manipula requisições para obter o saldo de uma conta em um
+// instante passado, informado em at (RFC 3339, obrigatório)
+func (h *AccountHandler) GetBalance(c echo.Context) error {
+	at, err := parseTimeParam(c, "at")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if at == nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "at is required"})
+	}
+
+	balance, err := h.accountQuery.GetBalanceAt(c.Param("id"), *at)
+	if err != nil {
+		if err == query.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == query.ErrBalanceAtInFuture {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, balance)
 }
 
 // Deposit manipula requisições para depositar em uma conta
@@ -89,14 +179,20 @@ func (h *AccountHandler) Deposit(c echo.Context) error {
 		if err == command.ErrAccountNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
 		}
-		if err == command.ErrInvalidAmount {
+		if err == command.ErrConcurrentUpdate {
+			return h.conflict(c, id, err)
+		}
+		if isInvalidAmount(err) {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
+		if isAccountStatusError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	// Busca a conta atualizada
-	account, err := h.accountQuery.GetByID(id)
+	account, err := h.accountQuery.GetCurrent(id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -119,17 +215,177 @@ func (h *AccountHandler) Withdraw(c echo.Context) error {
 		if err == command.ErrAccountNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
 		}
-		if err == command.ErrInvalidAmount || err == command.ErrInsufficientFunds {
+		if err == command.ErrConcurrentUpdate {
+			return h.conflict(c, id, err)
+		}
+		if isInvalidAmount(err) || err == command.ErrInsufficientFunds {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
+		var exceeded *limit.ExceededError
+		if errors.As(err, &exceeded) {
+			return limitExceeded(c, exceeded)
+		}
+		if isAccountStatusError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	// Busca a conta atualizada
-	account, err := h.accountQuery.GetByID(id)
+	account, err := h.accountQuery.GetCurrent(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+// BlockAccountRequest representa o corpo da requisição de bloqueio
+type BlockAccountRequest struct {
+	Reason string `json:"reason"`
+}
+
+// BlockAccount manipula requisições para bloquear uma conta
+func (h *AccountHandler) BlockAccount(c echo.Context) error {
+	id := c.Param("id")
+
+	var req BlockAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	err := h.blockHandler.Handle(command.BlockAccountCommand{AccountID: id, Reason: req.Reason})
+	if err == command.ErrReasonRequired {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return h.accountChanged(c, id, err)
+}
+
+// ActivateAccount manipula requisições para reativar uma conta bloqueada
+func (h *AccountHandler) ActivateAccount(c echo.Context) error {
+	id := c.Param("id")
+
+	err := h.activateHandler.Handle(command.ActivateAccountCommand{AccountID: id})
+	return h.accountChanged(c, id, err)
+}
+
+// CloseAccountRequest representa o corpo da requisição de encerramento
+type CloseAccountRequest struct {
+	PayoutAccountID string `json:"payout_account_id"`
+}
+
+// CloseAccount manipula requisições para encerrar uma conta, transferindo o
+// saldo restante para a conta de destino quando ela é informada
+func (h *AccountHandler) CloseAccount(c echo.Context) error {
+	id := c.Param("id")
+
+	var req CloseAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	err := h.closeHandler.Handle(command.CloseAccountCommand{AccountID: id, PayoutAccountID: req.PayoutAccountID})
+	if err == command.ErrSameAccount {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err == command.ErrPayoutAccountNotFound {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return h.accountChanged(c, id, err)
+}
+
+// SetOverdraftLimitRequest representa o corpo da requisição de limite de cheque especial
+type SetOverdraftLimitRequest struct {
+	Limit    json.Number `json:"limit"`
+	Currency string      `json:"currency"`
+}
+
+// SetOverdraftLimit manipula requisições para definir o limite de cheque especial
+func (h *AccountHandler) SetOverdraftLimit(c echo.Context) error {
+	id := c.Param("id")
+
+	var req SetOverdraftLimitRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	err := h.overdraftHandler.Handle(command.SetOverdraftLimitCommand{AccountID: id, Limit: req.Limit, Currency: req.Currency})
+	if isInvalidAmount(err) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, account.ErrOverdraftLimitBelowUse) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return h.accountChanged(c, id, err)
+}
+
+// accountChanged responde ao resultado de uma alteração da conta,
+// devolvendo a conta atualizada em caso de sucesso
+func (h *AccountHandler) accountChanged(c echo.Context, id string, err error) error {
+	if err != nil {
+		if err == command.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == command.ErrConcurrentUpdate {
+			return h.conflict(c, id, err)
+		}
+		if isAccountStatusError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	// Busca a conta atualizada
+	account, err := h.accountQuery.GetCurrent(id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, account)
 }
+
+// isInvalidAmount indica se o valor ou a moeda informados na requisição são
+// inválidos, inclusive um valor pequeno demais para ser convertido
+func isInvalidAmount(err error) bool {
+	return errors.Is(err, command.ErrInvalidAmount) ||
+		errors.Is(err, account.ErrUnsupportedCurrency) ||
+		errors.Is(err, fx.ErrAmountTooSmall)
+}
+
+// isAccountStatusError indica se a operação foi recusada pelo estado atual da
+// conta: status, saldo, reservas, transferências pendentes ou moeda
+func isAccountStatusError(err error) bool {
+	return errors.Is(err, account.ErrAccountNotActive) ||
+		errors.Is(err, account.ErrAccountAlreadyBlocked) ||
+		errors.Is(err, account.ErrAccountAlreadyActive) ||
+		errors.Is(err, account.ErrAccountAlreadyClosed) ||
+		errors.Is(err, account.ErrAccountClosed) ||
+		errors.Is(err, account.ErrAccountHasBalance) ||
+		errors.Is(err, account.ErrAccountHasHolds) ||
+		errors.Is(err, account.ErrAccountHasTransfers) ||
+		errors.Is(err, account.ErrCurrencyMismatch)
+}
+
+// limitExceeded responde 422 informando o limite ultrapassado e quanto ainda
+// pode ser sacado sob cada limite
+func limitExceeded(c echo.Context, err *limit.ExceededError) error {
+	return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+		"error":     err.Error(),
+		"limit":     err.Limit,
+		"remaining": err.Remaining,
+	})
+}
+
+// conflict responde 409 com o estado atual da conta, para que o cliente decida
+// se repete a operação depois que as tentativas automáticas se esgotaram
+func (h *AccountHandler) conflict(c echo.Context, id string, err error) error {
+	current, queryErr := h.accountQuery.GetCurrent(id)
+	if queryErr != nil {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusConflict, map[string]interface{}{
+		"error":   err.Error(),
+		"account": current,
+	})
+}
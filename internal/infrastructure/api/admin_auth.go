@@ -0,0 +1,31 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminAuth restringe as rotas administrativas a quem apresenta o token do
+// suporte no cabeçalho "Authorization: Bearer <token>". Requisições sem o
+// cabeçalho recebem 401; com outro token, 403. Sem token configurado, as rotas
+// administrativas ficam indisponíveis e toda requisição recebe 403.
+func AdminAuth(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Authorization required"})
+			}
+
+			provided, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "Forbidden"})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminRoutes são as rotas administrativas, exercitadas sem manipuladores
+var adminRoutes = []struct{ method, path string }{
+	{http.MethodPost, "/admin/transactions/event-123/reversal"},
+	{http.MethodPut, "/admin/accounts/account-123/overdraft-limit"},
+	{http.MethodPut, "/admin/accounts/account-123/fee-waivers/maintenance"},
+	{http.MethodDelete, "/admin/accounts/account-123/fee-waivers/maintenance"},
+}
+
+func TestAdminRoutes_RequireToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		configured    string
+		authorization string
+		expected      int
+	}{
+		{"sem cabeçalho", "secret", "", http.StatusUnauthorized},
+		{"token inválido", "secret", "Bearer wrong", http.StatusForbidden},
+		{"esquema diferente de Bearer", "secret", "Basic secret", http.StatusForbidden},
+		{"sem token configurado", "", "Bearer ", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: as operações nunca são executadas, então os manipuladores não são necessários
+			e := SetupRoutes(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, tt.configured)
+
+			for _, route := range adminRoutes {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if tt.authorization != "" {
+					req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+				}
+				rec := httptest.NewRecorder()
+
+				// Act
+				e.ServeHTTP(rec, req)
+
+				// Assert
+				assert.Equal(t, tt.expected, rec.Code, route.method+" "+route.path)
+			}
+		})
+	}
+}
+
+func TestAdminRoutes_NotExposedOutsideAdmin(t *testing.T) {
+	// Arrange
+	e := SetupRoutes(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "secret")
+
+	for _, route := range adminRoutes {
+		path := strings.TrimPrefix(route.path, "/admin")
+		req := httptest.NewRequest(route.method, path, nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, req)
+
+		// Assert: sem o prefixo /admin, a rota não existe
+		assert.Equal(t, http.StatusNotFound, rec.Code, route.method+" "+path)
+	}
+}
+
+func TestAdminAuth_ValidToken(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/transactions/event-123/reversal", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	called := false
+	next := func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusCreated)
+	}
+
+	// Act
+	err := AdminAuth("secret")(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, called)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// AdminHandler gerencia as requisições HTTP das operações administrativas,
+// executadas pelo suporte e não pelo cliente
+type AdminHandler struct {
+	reverseTransactionHandler *command.ReverseTransactionHandler
+}
+
+// NewAdminHandler cria um novo manipulador de operações administrativas
+func NewAdminHandler(reverseTransactionHandler *command.ReverseTransactionHandler) *AdminHandler {
+	return &AdminHandler{
+		reverseTransactionHandler: reverseTransactionHandler,
+	}
+}
+
+// ReverseTransactionRequest representa o corpo da requisição de estorno
+type ReverseTransactionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReverseTransaction manipula requisições para estornar um depósito ou saque
+func (h *AdminHandler) ReverseTransaction(c echo.Context) error {
+	var req ReverseTransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	reversal, err := h.reverseTransactionHandler.Handle(command.ReverseTransactionCommand{
+		EventID: c.Param("event_id"),
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		if err == command.ErrTransactionNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Transaction not found"})
+		}
+		if err == command.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == command.ErrReasonRequired || err == command.ErrInsufficientFunds {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if err == command.ErrAlreadyReversed || err == command.ErrConcurrentUpdate {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		if isAccountStatusError(err) || errors.Is(err, account.ErrNotReversible) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, reversal)
+}
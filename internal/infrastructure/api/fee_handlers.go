@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+)
+
+// FeeHandler gerencia requisições HTTP relacionadas às isenções de tarifas
+type FeeHandler struct {
+	setWaiverHandler    *command.SetFeeWaiverHandler
+	removeWaiverHandler *command.RemoveFeeWaiverHandler
+}
+
+// NewFeeHandler cria um novo manipulador de isenções de tarifas
+func NewFeeHandler(setWaiverHandler *command.SetFeeWaiverHandler, removeWaiverHandler *command.RemoveFeeWaiverHandler) *FeeHandler {
+	return &FeeHandler{
+		setWaiverHandler:    setWaiverHandler,
+		removeWaiverHandler: removeWaiverHandler,
+	}
+}
+
+// SetFeeWaiverRequest representa o corpo da requisição de isenção de tarifa
+type SetFeeWaiverRequest struct {
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// SetWaiver manipula requisições para isentar uma conta de um tipo de tarifa
+func (h *FeeHandler) SetWaiver(c echo.Context) error {
+	var req SetFeeWaiverRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	waiver, err := h.setWaiverHandler.Handle(command.SetFeeWaiverCommand{
+		AccountID: c.Param("id"),
+		Kind:      c.Param("kind"),
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if err == command.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if errors.Is(err, fee.ErrUnknownKind) || err == command.ErrReasonRequired || err == command.ErrInvalidExpiration {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, waiver)
+}
+
+// RemoveWaiver manipula requisições para remover uma isenção de tarifa
+func (h *FeeHandler) RemoveWaiver(c echo.Context) error {
+	err := h.removeWaiverHandler.Handle(command.RemoveFeeWaiverCommand{
+		AccountID: c.Param("id"),
+		Kind:      c.Param("kind"),
+	})
+	if err != nil {
+		if err == command.ErrWaiverNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+)
+
+// FXHandler gerencia requisições HTTP relacionadas a câmbio
+type FXHandler struct {
+	createQuoteHandler *command.CreateQuoteHandler
+}
+
+// NewFXHandler cria um novo manipulador de câmbio
+func NewFXHandler(createQuoteHandler *command.CreateQuoteHandler) *FXHandler {
+	return &FXHandler{createQuoteHandler: createQuoteHandler}
+}
+
+// QuoteResponse representa uma cotação de câmbio na resposta da API
+type QuoteResponse struct {
+	ID                string        `json:"id"`
+	Rate              string        `json:"rate"`
+	SourceAmount      account.Money `json:"source_amount"`
+	DestinationAmount account.Money `json:"destination_amount"`
+	ExpiresAt         time.Time     `json:"expires_at"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+// CreateQuote manipula requisições para cotar a conversão de um valor. O ID da
+// cotação é informado na transferência para garantir a taxa cotada.
+func (h *FXHandler) CreateQuote(c echo.Context) error {
+	var cmd command.CreateQuoteCommand
+	if err := c.Bind(&cmd); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	quote, err := h.createQuoteHandler.Handle(cmd)
+	if err != nil {
+		if isInvalidAmount(err) || errors.Is(err, fx.ErrSameCurrency) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, QuoteResponse{
+		ID:                quote.ID,
+		Rate:              quote.Rate.String(),
+		SourceAmount:      quote.SourceAmount,
+		DestinationAmount: quote.DestinationAmount,
+		ExpiresAt:         quote.ExpiresAt,
+		CreatedAt:         quote.CreatedAt,
+	})
+}
+
+// isExchangeError indica se a conversão entre moedas foi recusada: sem taxa
+// disponível ou com uma cotação que não pode ser usada na transferência
+func isExchangeError(err error) bool {
+	return errors.Is(err, fx.ErrRateNotFound) ||
+		errors.Is(err, fx.ErrQuoteExpired) ||
+		errors.Is(err, fx.ErrQuoteAlreadyUsed) ||
+		errors.Is(err, command.ErrQuoteMismatch)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+)
+
+// HoldHandler gerencia requisições HTTP relacionadas a reservas de saldo
+type HoldHandler struct {
+	placeHandler   *command.PlaceHoldHandler
+	captureHandler *command.CaptureHoldHandler
+	releaseHandler *command.ReleaseHoldHandler
+	accountQuery   query.AccountQuery
+}
+
+// NewHoldHandler cria um novo manipulador de reservas
+func NewHoldHandler(
+	placeHandler *command.PlaceHoldHandler,
+	captureHandler *command.CaptureHoldHandler,
+	releaseHandler *command.ReleaseHoldHandler,
+	accountQuery query.AccountQuery,
+) *HoldHandler {
+	return &HoldHandler{
+		placeHandler:   placeHandler,
+		captureHandler: captureHandler,
+		releaseHandler: releaseHandler,
+		accountQuery:   accountQuery,
+	}
+}
+
+// PlaceHold manipula requisições para reservar parte do saldo de uma conta
+func (h *HoldHandler) PlaceHold(c echo.Context) error {
+	id := c.Param("id")
+
+	var cmd command.PlaceHoldCommand
+	if err := c.Bind(&cmd); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	cmd.AccountID = id
+
+	holdID, err := h.placeHandler.Handle(cmd)
+	if err != nil {
+		return holdError(c, err)
+	}
+
+	// Busca a reserva criada
+	acc, err := h.accountQuery.GetCurrent(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	for _, hold := range acc.Holds {
+		if hold.ID == holdID {
+			return c.JSON(http.StatusCreated, hold)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{"id": holdID})
+}
+
+// CaptureHold manipula requisições para capturar uma reserva, total ou parcialmente
+func (h *HoldHandler) CaptureHold(c echo.Context) error {
+	id := c.Param("id")
+
+	var cmd command.CaptureHoldCommand
+	if err := c.Bind(&cmd); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	cmd.AccountID = id
+	cmd.HoldID = c.Param("hold_id")
+
+	if err := h.captureHandler.Handle(cmd); err != nil {
+		return holdError(c, err)
+	}
+
+	return h.account(c, id)
+}
+
+// ReleaseHold manipula requisições para liberar uma reserva sem capturá-la
+func (h *HoldHandler) ReleaseHold(c echo.Context) error {
+	id := c.Param("id")
+
+	err := h.releaseHandler.Handle(command.ReleaseHoldCommand{AccountID: id, HoldID: c.Param("hold_id")})
+	if err != nil {
+		return holdError(c, err)
+	}
+
+	return h.account(c, id)
+}
+
+// account responde com o estado atualizado da conta
+func (h *HoldHandler) account(c echo.Context, id string) error {
+	acc, err := h.accountQuery.GetCurrent(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, acc)
+}
+
+// holdError converte os erros dos comandos de reserva em respostas HTTP
+func holdError(c echo.Context, err error) error {
+	if err == command.ErrAccountNotFound {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+	}
+	if errors.Is(err, account.ErrHoldNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Hold not found"})
+	}
+	if err == command.ErrConcurrentUpdate {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if isInvalidAmount(err) || err == command.ErrInsufficientFunds || errors.Is(err, account.ErrInvalidHoldExpiry) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	var exceeded *limit.ExceededError
+	if errors.As(err, &exceeded) {
+		return limitExceeded(c, exceeded)
+	}
+	if isAccountStatusError(err) || errors.Is(err, account.ErrHoldExpired) || errors.Is(err, account.ErrCaptureExceedsHold) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
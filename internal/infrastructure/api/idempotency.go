@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
+)
+
+const (
+	// IdempotencyKeyHeader é o cabeçalho com a chave de idempotência enviada pelo cliente
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader marca respostas devolvidas a partir de uma chave já usada
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyKeyTTL é por quanto tempo uma chave continua valendo
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// IdempotencyLease é por quanto tempo uma requisição em andamento mantém a
+	// chave reservada; deve ser maior que a duração da requisição mais lenta
+	IdempotencyLease = time.Minute
+
+	maxIdempotencyKeyLength = 255
+)
+
+// Idempotency torna idempotentes as requisições que enviam o cabeçalho
+// Idempotency-Key. A primeira requisição com a chave é executada e sua resposta
+// é gravada; repetições com o mesmo conteúdo recebem a resposta original sem
+// executar a operação de novo. Regras:
+//   - a mesma chave com método, caminho ou corpo diferentes é rejeitada com 422;
+//   - enquanto a primeira requisição não termina, repetições recebem 409; se
+//     ela for interrompida sem liberar a chave, uma repetição com o mesmo
+//     conteúdo assume a chave depois de IdempotencyLease;
+//   - respostas 409 e 5xx não são gravadas, pois repetir a operação é seguro e
+//     pode ter outro resultado; a chave é liberada para uma nova tentativa.
+func Idempotency(store persistence.IdempotencyRepositoryInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Idempotency-Key must have at most 255 characters"})
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			requestHash := hashRequest(c.Request().Method, c.Request().URL.Path, body)
+
+			existing, err := store.Acquire(key, requestHash, IdempotencyKeyTTL, IdempotencyLease)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			}
+			if existing != nil {
+				if existing.RequestHash != requestHash {
+					return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Idempotency-Key was already used with a different request"})
+				}
+				if existing.Status != persistence.IdempotencyCompleted {
+					return c.JSON(http.StatusConflict, map[string]string{"error": "a request with this Idempotency-Key is still being processed"})
+				}
+				c.Response().Header().Set(IdempotentReplayedHeader, "true")
+				return c.JSONBlob(existing.ResponseStatus, existing.ResponseBody)
+			}
+
+			// Executa a requisição guardando uma cópia da resposta
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			defer func() {
+				if p := recover(); p != nil {
+					release(store, key)
+					panic(p)
+				}
+			}()
+
+			if err := next(c); err != nil {
+				release(store, key)
+				return err
+			}
+
+			status := c.Response().Status
+			if status == http.StatusConflict || status >= http.StatusInternalServerError {
+				release(store, key)
+				return nil
+			}
+			if err := store.Complete(key, status, recorder.body.Bytes()); err != nil {
+				// A operação já foi executada; a chave fica reservada e repetições
+				// recebem 409 até o fim da reserva
+				log.Printf("Erro ao gravar resposta da chave de idempotência %s: %v", key, err)
+			}
+			return nil
+		}
+	}
+}
+
+// hashRequest identifica o conteúdo da requisição associada a uma chave
+func hashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// release libera a chave para que a requisição possa ser repetida
+func release(store persistence.IdempotencyRepositoryInterface, key string) {
+	if err := store.Release(key); err != nil {
+		log.Printf("Erro ao liberar chave de idempotência %s: %v", key, err)
+	}
+}
+
+// responseRecorder repassa a resposta ao cliente e guarda uma cópia do corpo
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
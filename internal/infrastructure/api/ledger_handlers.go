@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+)
+
+// LedgerHandler gerencia requisições HTTP relacionadas ao razão contábil
+type LedgerHandler struct {
+	ledgerQuery query.LedgerQuery
+}
+
+// NewLedgerHandler cria um novo manipulador do razão
+func NewLedgerHandler(ledgerQuery query.LedgerQuery) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerQuery: ledgerQuery,
+	}
+}
+
+// Reconcile manipula requisições para conciliar os saldos das contas com o razão
+func (h *LedgerHandler) Reconcile(c echo.Context) error {
+	result, err := h.ledgerQuery.Reconcile()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -3,20 +3,67 @@ package api
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
-func SetupRoutes(accountHandler *AccountHandler) *echo.Echo {
+func SetupRoutes(
+	accountHandler *AccountHandler,
+	transactionHandler *TransactionHandler,
+	statementHandler *StatementHandler,
+	holdHandler *HoldHandler,
+	feeHandler *FeeHandler,
+	scheduleHandler *ScheduleHandler,
+	transferHandler *TransferHandler,
+	fxHandler *FXHandler,
+	ledgerHandler *LedgerHandler,
+	adminHandler *AdminHandler,
+	idempotencyStore persistence.IdempotencyRepositoryInterface,
+	adminToken string,
+) *echo.Echo {
 	e := echo.New()
 
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
 
-	e.POST("/accounts", accountHandler.CreateAccount)
+	// Operações que alteram estado aceitam o cabeçalho Idempotency-Key
+	idempotent := Idempotency(idempotencyStore)
+
+	e.POST("/accounts", accountHandler.CreateAccount, idempotent)
 	e.GET("/accounts", accountHandler.GetAccounts)
 	e.GET("/accounts/:id", accountHandler.GetAccount)
-	e.POST("/accounts/:id/deposit", accountHandler.Deposit)
-	e.POST("/accounts/:id/withdraw", accountHandler.Withdraw)
+	e.GET("/accounts/:id/balance", accountHandler.GetBalance)
+	e.POST("/accounts/:id/deposit", accountHandler.Deposit, idempotent)
+	e.POST("/accounts/:id/withdraw", accountHandler.Withdraw, idempotent)
+	e.POST("/accounts/:id/block", accountHandler.BlockAccount, idempotent)
+	e.POST("/accounts/:id/activate", accountHandler.ActivateAccount, idempotent)
+	e.POST("/accounts/:id/close", accountHandler.CloseAccount, idempotent)
+	e.GET("/accounts/:id/transactions", transactionHandler.GetTransactions)
+	e.GET("/accounts/:id/statements", statementHandler.GetStatement)
+
+	e.POST("/accounts/:id/holds", holdHandler.PlaceHold, idempotent)
+	e.POST("/accounts/:id/holds/:hold_id/capture", holdHandler.CaptureHold, idempotent)
+	e.POST("/accounts/:id/holds/:hold_id/release", holdHandler.ReleaseHold, idempotent)
+
+	e.POST("/accounts/:id/schedules", scheduleHandler.CreateSchedule, idempotent)
+	e.GET("/accounts/:id/schedules", scheduleHandler.GetSchedules)
+	e.GET("/accounts/:id/schedules/:schedule_id", scheduleHandler.GetSchedule)
+	e.PUT("/accounts/:id/schedules/:schedule_id", scheduleHandler.UpdateSchedule, idempotent)
+	e.DELETE("/accounts/:id/schedules/:schedule_id", scheduleHandler.CancelSchedule)
+
+	e.POST("/transfers", transferHandler.CreateTransfer, idempotent)
+	e.GET("/transfers/:id", transferHandler.GetTransfer)
+
+	e.POST("/fx/quotes", fxHandler.CreateQuote, idempotent)
+
+	e.GET("/ledger/reconciliation", ledgerHandler.Reconcile)
+
+	// Operações do suporte; exigem o token administrativo
+	admin := e.Group("/admin", AdminAuth(adminToken))
+	admin.POST("/transactions/:event_id/reversal", adminHandler.ReverseTransaction, idempotent)
+	admin.PUT("/accounts/:id/overdraft-limit", accountHandler.SetOverdraftLimit, idempotent)
+	admin.PUT("/accounts/:id/fee-waivers/:kind", feeHandler.SetWaiver, idempotent)
+	admin.DELETE("/accounts/:id/fee-waivers/:kind", feeHandler.RemoveWaiver)
 
 	return e
 }
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+)
+
+// ScheduleHandler gerencia requisições HTTP relacionadas às transferências agendadas
+type ScheduleHandler struct {
+	createScheduleHandler *command.CreateScheduleHandler
+	updateScheduleHandler *command.UpdateScheduleHandler
+	cancelScheduleHandler *command.CancelScheduleHandler
+	scheduleQuery         query.ScheduleQuery
+}
+
+// NewScheduleHandler cria um novo manipulador de transferências agendadas
+func NewScheduleHandler(
+	createScheduleHandler *command.CreateScheduleHandler,
+	updateScheduleHandler *command.UpdateScheduleHandler,
+	cancelScheduleHandler *command.CancelScheduleHandler,
+	scheduleQuery query.ScheduleQuery,
+) *ScheduleHandler {
+	return &ScheduleHandler{
+		createScheduleHandler: createScheduleHandler,
+		updateScheduleHandler: updateScheduleHandler,
+		cancelScheduleHandler: cancelScheduleHandler,
+		scheduleQuery:         scheduleQuery,
+	}
+}
+
+// CreateScheduleRequest representa o corpo da requisição de agendamento
+type CreateScheduleRequest struct {
+	DestinationAccountID string      `json:"destination_account_id"`
+	Amount               json.Number `json:"amount"`
+	Currency             string      `json:"currency"`
+	Frequency            string      `json:"frequency"`
+	StartDate            string      `json:"start_date"`
+	EndDate              string      `json:"end_date"`
+	OnInsufficientFunds  string      `json:"on_insufficient_funds"`
+	MaxAttempts          int         `json:"max_attempts"`
+}
+
+// UpdateScheduleRequest representa o corpo da requisição de alteração de agendamento
+type UpdateScheduleRequest struct {
+	Amount              json.Number `json:"amount"`
+	Currency            string      `json:"currency"`
+	EndDate             string      `json:"end_date"`
+	OnInsufficientFunds string      `json:"on_insufficient_funds"`
+	MaxAttempts         int         `json:"max_attempts"`
+}
+
+// CreateSchedule manipula requisições para agendar uma transferência
+func (h *ScheduleHandler) CreateSchedule(c echo.Context) error {
+	var req CreateScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	accountID := c.Param("id")
+	scheduleID, err := h.createScheduleHandler.Handle(command.CreateScheduleCommand{
+		AccountID:            accountID,
+		DestinationAccountID: req.DestinationAccountID,
+		Amount:               req.Amount,
+		Currency:             req.Currency,
+		Frequency:            req.Frequency,
+		StartDate:            req.StartDate,
+		EndDate:              req.EndDate,
+		OnInsufficientFunds:  req.OnInsufficientFunds,
+		MaxAttempts:          req.MaxAttempts,
+	})
+	if err != nil {
+		return scheduleError(c, err)
+	}
+
+	s, err := h.scheduleQuery.GetByID(accountID, scheduleID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, s)
+}
+
+// GetSchedules manipula requisições para listar os agendamentos de uma conta
+func (h *ScheduleHandler) GetSchedules(c echo.Context) error {
+	schedules, err := h.scheduleQuery.GetByAccount(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, schedules)
+}
+
+// GetSchedule manipula requisições para buscar um agendamento pelo ID
+func (h *ScheduleHandler) GetSchedule(c echo.Context) error {
+	s, err := h.scheduleQuery.GetByID(c.Param("id"), c.Param("schedule_id"))
+	if err != nil {
+		if err == query.ErrScheduleNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Schedule not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, s)
+}
+
+// UpdateSchedule manipula requisições para alterar um agendamento ativo
+func (h *ScheduleHandler) UpdateSchedule(c echo.Context) error {
+	var req UpdateScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	accountID := c.Param("id")
+	scheduleID := c.Param("schedule_id")
+	err := h.updateScheduleHandler.Handle(command.UpdateScheduleCommand{
+		AccountID:           accountID,
+		ScheduleID:          scheduleID,
+		Amount:              req.Amount,
+		Currency:            req.Currency,
+		EndDate:             req.EndDate,
+		OnInsufficientFunds: req.OnInsufficientFunds,
+		MaxAttempts:         req.MaxAttempts,
+	})
+	if err != nil {
+		return scheduleError(c, err)
+	}
+
+	s, err := h.scheduleQuery.GetByID(accountID, scheduleID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, s)
+}
+
+// CancelSchedule manipula requisições para cancelar um agendamento
+func (h *ScheduleHandler) CancelSchedule(c echo.Context) error {
+	err := h.cancelScheduleHandler.Handle(command.CancelScheduleCommand{
+		AccountID:  c.Param("id"),
+		ScheduleID: c.Param("schedule_id"),
+	})
+	if err != nil {
+		return scheduleError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// scheduleError converte os erros dos comandos de agendamento em respostas HTTP
+func scheduleError(c echo.Context, err error) error {
+	if err == command.ErrAccountNotFound {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+	}
+	if err == command.ErrScheduleNotFound {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Schedule not found"})
+	}
+	if isInvalidAmount(err) || isInvalidSchedule(err) || err == command.ErrSameAccount {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err == command.ErrConcurrentUpdate {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if isAccountStatusError(err) || errors.Is(err, schedule.ErrNotActive) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
+// isInvalidSchedule indica se o agendamento foi recusado por dados inválidos
+func isInvalidSchedule(err error) bool {
+	return errors.Is(err, command.ErrInvalidDate) ||
+		errors.Is(err, schedule.ErrInvalidFrequency) ||
+		errors.Is(err, schedule.ErrInvalidPolicy) ||
+		errors.Is(err, schedule.ErrInvalidAttempts) ||
+		errors.Is(err, schedule.ErrInvalidStartDate) ||
+		errors.Is(err, schedule.ErrInvalidEndDate)
+}
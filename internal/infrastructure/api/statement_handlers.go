@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+)
+
+// StatementHandler gerencia requisições HTTP dos extratos de conta
+type StatementHandler struct {
+	statementQuery query.StatementQuery
+}
+
+// NewStatementHandler cria um novo manipulador de extratos
+func NewStatementHandler(statementQuery query.StatementQuery) *StatementHandler {
+	return &StatementHandler{
+		statementQuery: statementQuery,
+	}
+}
+
+// GetStatement manipula requisições para obter o extrato de uma conta.
+//
+// Parâmetros obrigatórios: from e to (RFC 3339, intervalo [from, to)).
+// O formato é JSON, ou CSV com format=csv ou o cabeçalho Accept: text/csv.
+func (h *StatementHandler) GetStatement(c echo.Context) error {
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if from == nil || to == nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "from and to are required"})
+	}
+
+	format := c.QueryParam("format")
+	if format == "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), "text/csv") {
+		format = "csv"
+	}
+	if format != "" && format != "json" && format != "csv" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "format must be json or csv"})
+	}
+
+	statement, err := h.statementQuery.GetStatement(c.Param("id"), *from, *to)
+	if err != nil {
+		if err == query.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == query.ErrInvalidTimeRange || err == query.ErrStatementPeriodTooLong {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if err == query.ErrStatementNotSettled {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if format == "csv" {
+		return writeStatementCSV(c, statement)
+	}
+	return c.JSON(http.StatusOK, statement)
+}
+
+// writeStatementCSV responde o extrato em CSV: uma linha de saldo de abertura,
+// uma por movimentação, com o valor com sinal, e uma de saldo de fechamento
+func writeStatementCSV(c echo.Context, statement *query.StatementDTO) error {
+	filename := fmt.Sprintf("statement-%s-%s-%s.csv", statement.AccountID,
+		statement.From.UTC().Format("20060102"), statement.To.UTC().Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	rows := [][]string{
+		{"occurred_at", "transaction_id", "type", "amount", "balance_after", "currency", "transfer_id"},
+		{statement.From.UTC().Format(time.RFC3339), "", "opening_balance", "", statement.OpeningBalance.String(), statement.Currency, ""},
+	}
+	for _, line := range statement.Transactions {
+		rows = append(rows, []string{
+			line.OccurredAt.UTC().Format(time.RFC3339),
+			line.ID,
+			line.Type,
+			line.Amount.String(),
+			line.BalanceAfter.String(),
+			statement.Currency,
+			line.TransferID,
+		})
+	}
+	rows = append(rows, []string{statement.To.UTC().Format(time.RFC3339), "", "closing_balance", "", statement.ClosingBalance.String(), statement.Currency, ""})
+
+	return w.WriteAll(rows)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+)
+
+// TransactionHandler gerencia requisições HTTP do histórico de transações
+type TransactionHandler struct {
+	transactionQuery query.TransactionHistoryQuery
+}
+
+// NewTransactionHandler cria um novo manipulador do histórico de transações
+func NewTransactionHandler(transactionQuery query.TransactionHistoryQuery) *TransactionHandler {
+	return &TransactionHandler{
+		transactionQuery: transactionQuery,
+	}
+}
+
+// GetTransactions manipula requisições para listar o histórico de uma conta.
+//
+// Parâmetros opcionais: from e to (RFC 3339, intervalo [from, to)), limit e
+// cursor (valor de next_cursor da página anterior).
+func (h *TransactionHandler) GetTransactions(c echo.Context) error {
+	params := query.TransactionHistoryParams{
+		AccountID: c.Param("id"),
+		Cursor:    c.QueryParam("cursor"),
+	}
+
+	var err error
+	if params.From, err = parseTimeParam(c, "from"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if params.To, err = parseTimeParam(c, "to"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil || params.Limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+		}
+	}
+
+	page, err := h.transactionQuery.GetByAccount(params)
+	if err != nil {
+		if err == query.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == query.ErrInvalidCursor || err == query.ErrInvalidTimeRange {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// parseTimeParam lê um parâmetro de consulta opcional no formato RFC 3339
+func parseTimeParam(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+	}
+	return &t, nil
+}
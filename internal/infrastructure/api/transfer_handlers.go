@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/viniciuslima/account-EDA/internal/application/command"
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// TransferHandler gerencia requisições HTTP relacionadas a transferências
+type TransferHandler struct {
+	transferHandler *command.TransferHandler
+	transferQuery   query.TransferQuery
+}
+
+// NewTransferHandler cria um novo manipulador de transferências
+func NewTransferHandler(
+	transferHandler *command.TransferHandler,
+	transferQuery query.TransferQuery,
+) *TransferHandler {
+	return &TransferHandler{
+		transferHandler: transferHandler,
+		transferQuery:   transferQuery,
+	}
+}
+
+// CreateTransfer manipula requisições para transferir valores entre contas
+func (h *TransferHandler) CreateTransfer(c echo.Context) error {
+	var cmd command.TransferCommand
+	if err := c.Bind(&cmd); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	transferID, err := h.transferHandler.Handle(cmd)
+	if err != nil {
+		if err == command.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == command.ErrQuoteNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Quote not found"})
+		}
+		if isInvalidAmount(err) || err == command.ErrInsufficientFunds || err == command.ErrSameAccount {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if err == command.ErrConcurrentUpdate {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		var exceeded *limit.ExceededError
+		if errors.As(err, &exceeded) {
+			return limitExceeded(c, exceeded)
+		}
+		if isAccountStatusError(err) || isExchangeError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	// Busca o estado atual da transferência
+	t, err := h.transferQuery.GetByID(transferID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	// Transferências ainda pendentes serão concluídas pelo worker
+	if t.Status == string(transfer.StatusPending) {
+		return c.JSON(http.StatusAccepted, t)
+	}
+	return c.JSON(http.StatusCreated, t)
+}
+
+// GetTransfer manipula requisições para buscar uma transferência pelo ID
+func (h *TransferHandler) GetTransfer(c echo.Context) error {
+	id := c.Param("id")
+
+	t, err := h.transferQuery.GetByID(id)
+	if err != nil {
+		if err == query.ErrTransferNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Transfer not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, t)
+}
@@ -0,0 +1,72 @@
+package fxrates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+)
+
+// rateFile é o formato do arquivo de taxas:
+//
+//	{"rates": [{"from": "USD", "to": "BRL", "rate": "5.0125"}]}
+type rateFile struct {
+	Rates []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Rate string `json:"rate"`
+	} `json:"rates"`
+}
+
+// FileProvider fornece taxas de câmbio carregadas de um arquivo JSON, para uso
+// sem acesso a um serviço de cotações. As taxas são fixas até o arquivo ser
+// recarregado com o reinício do serviço.
+type FileProvider struct {
+	rates map[string]fx.Rate
+}
+
+// NewFileProvider carrega as taxas do arquivo informado. Quando o arquivo
+// traz apenas um sentido de um par, o sentido oposto usa a taxa inversa.
+func NewFileProvider(path string) (*FileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate file %s: %w", path, err)
+	}
+
+	p := &FileProvider{rates: make(map[string]fx.Rate)}
+	for _, r := range file.Rates {
+		rate, err := fx.ParseRate(r.From, r.To, r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate file %s: %w", path, err)
+		}
+		p.rates[pair(rate.From, rate.To)] = rate
+	}
+
+	// Os sentidos informados no arquivo têm precedência sobre as inversas
+	for _, rate := range p.rates {
+		inverse := rate.Inverse()
+		if _, ok := p.rates[pair(inverse.From, inverse.To)]; !ok && !inverse.IsZero() {
+			p.rates[pair(inverse.From, inverse.To)] = inverse
+		}
+	}
+	return p, nil
+}
+
+// Rate retorna a taxa de conversão de from para to
+func (p *FileProvider) Rate(from, to string) (fx.Rate, error) {
+	rate, ok := p.rates[pair(from, to)]
+	if !ok {
+		return fx.Rate{}, fmt.Errorf("%w: %s to %s", fx.ErrRateNotFound, from, to)
+	}
+	return rate, nil
+}
+
+func pair(from, to string) string {
+	return from + "/" + to
+}
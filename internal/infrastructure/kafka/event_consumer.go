@@ -20,7 +20,7 @@ type EventHandler interface {
 // EventConsumer consome eventos do Kafka e os processa
 type EventConsumer struct {
 	reader   *kafka.Reader
-	handlers map[string]EventHandler
+	handlers map[string][]EventHandler
 	stopCh   chan struct{}
 }
 
@@ -40,14 +40,15 @@ func NewEventConsumer(brokers []string, groupID string, topic string) *EventCons
 
 	return &EventConsumer{
 		reader:   reader,
-		handlers: make(map[string]EventHandler),
+		handlers: make(map[string][]EventHandler),
 		stopCh:   make(chan struct{}),
 	}
 }
 
-// RegisterHandler registra um manipulador para um tipo específico de evento
+// RegisterHandler registra um manipulador para um tipo específico de evento.
+// Um mesmo tipo pode ter vários manipuladores, executados na ordem de registro.
 func (c *EventConsumer) RegisterHandler(handler EventHandler) {
-	c.handlers[handler.EventType()] = handler
+	c.handlers[handler.EventType()] = append(c.handlers[handler.EventType()], handler)
 	log.Printf("Registrado handler para evento: %s", handler.EventType())
 }
 
@@ -93,40 +94,46 @@ func (c *EventConsumer) Stop() error {
 
 // processMessage processa uma mensagem individual
 func (c *EventConsumer) processMessage(ctx context.Context, msg kafka.Message) error {
-	// Extrai o tipo de evento dos headers
-	var eventType string
-	for _, header := range msg.Headers {
-		if header.Key == "event_type" {
-			eventType = string(header.Value)
-			break
-		}
-	}
-
-	if eventType == "" {
-		// Tenta extrair do JSON se não estiver no header
-		var baseEvent account.BaseEvent
-		if err := json.Unmarshal(msg.Value, &baseEvent); err != nil {
-			return fmt.Errorf("erro ao extrair tipo de evento: %w", err)
-		}
-		eventType = baseEvent.EventType
+	eventType, err := messageEventType(msg)
+	if err != nil {
+		return err
 	}
 
 	log.Printf("Processando evento: %s (offset: %d, partition: %d)",
 		eventType, msg.Offset, msg.Partition)
 
-	// Busca o handler apropriado
-	handler, exists := c.handlers[eventType]
+	// Busca os handlers apropriados
+	handlers, exists := c.handlers[eventType]
 	if !exists {
 		log.Printf("Nenhum handler registrado para evento: %s", eventType)
 		// Retorna nil para não bloquear o consumo
 		return nil
 	}
 
-	// Processa o evento com o handler específico
-	if err := handler.Handle(ctx, msg.Value); err != nil {
-		return fmt.Errorf("erro no handler do evento %s: %w", eventType, err)
+	// Processa o evento com cada handler. Se algum falhar, o offset não é
+	// commitado e todos reprocessam o evento, por isso devem ser idempotentes.
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, msg.Value); err != nil {
+			return fmt.Errorf("erro no handler do evento %s: %w", eventType, err)
+		}
 	}
 
 	log.Printf("Evento %s processado com sucesso", eventType)
 	return nil
 }
+
+// messageEventType extrai o tipo de evento dos headers da mensagem ou, se não
+// estiver neles, do JSON do evento
+func messageEventType(msg kafka.Message) (string, error) {
+	for _, header := range msg.Headers {
+		if header.Key == "event_type" {
+			return string(header.Value), nil
+		}
+	}
+
+	var baseEvent account.BaseEvent
+	if err := json.Unmarshal(msg.Value, &baseEvent); err != nil {
+		return "", fmt.Errorf("erro ao extrair tipo de evento: %w", err)
+	}
+	return baseEvent.EventType, nil
+}
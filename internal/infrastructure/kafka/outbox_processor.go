@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
 	"github.com/viniciuslima/account-EDA/internal/infrastructure/persistence"
 )
 
@@ -138,40 +139,18 @@ func (p *OutboxProcessor) processNextBatch() error {
 
 // publishEvent publica um evento do outbox no Kafka
 func (p *OutboxProcessor) publishEvent(event persistence.OutboxEvent) error {
-	// Dependendo do tipo de evento, criar o objeto de domínio apropriado
-	var domainEvent account.Event
-
-	switch event.EventType {
-	case "AccountCreated":
-		var e account.AccountCreatedEvent
-		if err := json.Unmarshal(event.Payload, &e); err != nil {
-			return err
-		}
-		domainEvent = e
-
-	case "AccountDeposited":
-		var e account.AccountDepositedEvent
-		if err := json.Unmarshal(event.Payload, &e); err != nil {
-			return err
-		}
-		domainEvent = e
-
-	case "AccountWithdrawn":
-		var e account.AccountWithdrawnEvent
-		if err := json.Unmarshal(event.Payload, &e); err != nil {
-			return err
-		}
-		domainEvent = e
-
-	// Adicionar outros tipos de eventos conforme necessário
-
-	default:
+	domainEvent, err := decodeOutboxEvent(event)
+	if err != nil {
+		log.Printf("Erro ao deserializar evento %s: %v", event.ID, err)
+		return err
+	}
+	if domainEvent == nil {
 		log.Printf("Tipo de evento desconhecido: %s", event.EventType)
 		return nil
 	}
 
 	// Publicar o evento
-	err := p.publisher.Publish(domainEvent)
+	err = p.publisher.Publish(domainEvent)
 
 	if err != nil {
 		// Verifica se o erro é de infraestrutura (broker indisponível ou tópico inexistente)
@@ -233,19 +212,12 @@ func isKafkaInfrastructureError(err error) bool {
 // handleFailedEvent lida com eventos que falharam muitas vezes
 func (p *OutboxProcessor) handleFailedEvent(event persistence.OutboxEvent) {
 	// Tentar enviar para a DLQ
-	var domainEvent account.Event
-	switch event.EventType {
-	case "AccountCreated":
-		var e account.AccountCreatedEvent
-		if err := json.Unmarshal(event.Payload, &e); err != nil {
-			log.Printf("Erro ao deserializar evento %s: %v", event.ID, err)
-			return
-		}
-		domainEvent = e
-
-	// Adicionar outros tipos conforme necessário
-
-	default:
+	domainEvent, err := decodeOutboxEvent(event)
+	if err != nil {
+		log.Printf("Erro ao deserializar evento %s: %v", event.ID, err)
+		return
+	}
+	if domainEvent == nil {
 		log.Printf("Tipo de evento desconhecido para DLQ: %s", event.EventType)
 		return
 	}
@@ -261,3 +233,44 @@ func (p *OutboxProcessor) handleFailedEvent(event persistence.OutboxEvent) {
 		log.Printf("Erro ao marcar evento enviado para DLQ como processado: %v", err)
 	}
 }
+
+// outboxEventDecoders mapeia cada tipo de evento para a função que reconstrói o evento de domínio
+var outboxEventDecoders = map[string]func(payload []byte) (account.Event, error){
+	"AccountCreated":   decodeEvent[account.AccountCreatedEvent],
+	"AccountDeposited": decodeEvent[account.AccountDepositedEvent],
+	"AccountWithdrawn": decodeEvent[account.AccountWithdrawnEvent],
+	"AccountBlocked":   decodeEvent[account.AccountBlockedEvent],
+	"AccountActivated": decodeEvent[account.AccountActivatedEvent],
+	"AccountClosed":    decodeEvent[account.AccountClosedEvent],
+	"HoldPlaced":       decodeEvent[account.HoldPlacedEvent],
+	"HoldCaptured":     decodeEvent[account.HoldCapturedEvent],
+	"HoldReleased":     decodeEvent[account.HoldReleasedEvent],
+
+	"OverdraftLimitChanged": decodeEvent[account.OverdraftLimitChangedEvent],
+	"InterestCredited":      decodeEvent[account.InterestCreditedEvent],
+	"FeeCharged":            decodeEvent[account.FeeChargedEvent],
+	"TransactionReversed":   decodeEvent[account.TransactionReversedEvent],
+
+	"TransferInitiated": decodeEvent[transfer.TransferInitiatedEvent],
+	"TransferCompleted": decodeEvent[transfer.TransferCompletedEvent],
+	"TransferFailed":    decodeEvent[transfer.TransferFailedEvent],
+}
+
+// decodeOutboxEvent reconstrói o evento de domínio armazenado no outbox.
+// Retorna nil, sem erro, para tipos de evento desconhecidos.
+func decodeOutboxEvent(event persistence.OutboxEvent) (account.Event, error) {
+	decode, ok := outboxEventDecoders[event.EventType]
+	if !ok {
+		return nil, nil
+	}
+	return decode(event.Payload)
+}
+
+// decodeEvent deserializa o payload JSON no tipo de evento E
+func decodeEvent[E account.Event](payload []byte) (account.Event, error) {
+	var e E
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/viniciuslima/account-EDA/internal/application/projection"
+)
+
+// projectionRetryDelay é o intervalo entre as tentativas de projetar uma mensagem
+const projectionRetryDelay = 2 * time.Second
+
+// ProjectionConsumer consome o tópico de eventos em um grupo próprio e entrega
+// cada mensagem ao projetor. Uma mensagem que falha é tentada de novo até ser
+// projetada, pois pulá-la deixaria a visão desatualizada até o próximo evento
+// da mesma conta.
+type ProjectionConsumer struct {
+	reader    *kafka.Reader
+	projector *projection.Projector
+	stopCh    chan struct{}
+}
+
+// NewProjectionConsumer cria um novo consumidor das projeções
+func NewProjectionConsumer(brokers []string, groupID string, topic string, projector *projection.Projector) *ProjectionConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       10e3, // 10KB
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+		StartOffset:    kafka.FirstOffset,
+		Logger:         kafka.LoggerFunc(log.Printf),
+		ErrorLogger:    kafka.LoggerFunc(log.Printf),
+	})
+
+	return &ProjectionConsumer{
+		reader:    reader,
+		projector: projector,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start inicia o consumo de mensagens
+func (c *ProjectionConsumer) Start(ctx context.Context) error {
+	log.Println("Iniciando consumidor das projeções...")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.stopCh:
+			return nil
+		default:
+			msg, err := c.reader.FetchMessage(ctx)
+			if err != nil {
+				log.Printf("Erro ao buscar mensagem: %v", err)
+				time.Sleep(time.Second)
+				continue
+			}
+
+			if !c.project(ctx, msg) {
+				continue
+			}
+
+			// Os checkpoints já registram a mensagem; o offset do grupo apenas
+			// evita reler mensagens projetadas ao reiniciar
+			if err := c.reader.CommitMessages(ctx, msg); err != nil {
+				log.Printf("Erro ao commitar mensagem: %v", err)
+			}
+		}
+	}
+}
+
+// Stop para o consumidor
+func (c *ProjectionConsumer) Stop() error {
+	close(c.stopCh)
+	return c.reader.Close()
+}
+
+// project entrega a mensagem ao projetor, tentando de novo enquanto falhar.
+// Retorna false se o consumidor foi parado antes de a mensagem ser projetada.
+func (c *ProjectionConsumer) project(ctx context.Context, msg kafka.Message) bool {
+	eventType, err := messageEventType(msg)
+	if err != nil {
+		// Uma mensagem ilegível não será projetada em nenhuma tentativa
+		log.Printf("Mensagem ignorada pelas projeções (offset: %d, partition: %d): %v", msg.Offset, msg.Partition, err)
+		return true
+	}
+
+	message := projection.Message{
+		EventType: eventType,
+		Payload:   msg.Value,
+		Position:  projection.Position{Topic: msg.Topic, Partition: msg.Partition, Offset: msg.Offset},
+	}
+	for {
+		err := c.projector.Project(ctx, message)
+		if err == nil {
+			return true
+		}
+		log.Printf("Erro ao projetar evento %s (offset: %d, partition: %d): %v", eventType, msg.Offset, msg.Partition, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.stopCh:
+			return false
+		case <-time.After(projectionRetryDelay):
+		}
+	}
+}
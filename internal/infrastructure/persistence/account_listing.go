@@ -0,0 +1,174 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// accountSortColumns mapeia os campos de ordenação da listagem para as colunas de accounts.
+// Saldos de moedas diferentes não são comparáveis: a ordenação por saldo agrupa
+// as contas por moeda e ordena os saldos dentro de cada moeda.
+var accountSortColumns = map[account.SortField]string{
+	account.SortByCreatedAt: "created_at",
+	account.SortByName:      "name",
+	account.SortByEmail:     "email",
+	account.SortByBalance:   "balance",
+}
+
+// accountFilter traduz os filtros dos critérios para as condições da cláusula
+// WHERE e seus argumentos, sobre accounts ou sobre a visão de contas, que têm as
+// mesmas colunas
+func accountFilter(c account.Criteria) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if c.Status != "" {
+		add("status = $%d", string(c.Status))
+	}
+	if c.Email != "" {
+		add("email ILIKE '%%' || $%d || '%%'", escapeLike(c.Email))
+	}
+	if c.Name != "" {
+		add("name ILIKE '%%' || $%d || '%%'", escapeLike(c.Name))
+	}
+	if c.Currency != "" {
+		add("currency = $%d", c.Currency)
+	}
+	if c.CreatedFrom != nil {
+		add("created_at >= $%d", *c.CreatedFrom)
+	}
+	if c.CreatedTo != nil {
+		add("created_at <= $%d", *c.CreatedTo)
+	}
+	if c.MinBalance != nil {
+		add("balance >= $%d", c.MinBalance.String())
+		add("currency = $%d", c.MinBalance.Currency())
+	}
+	if c.MaxBalance != nil {
+		add("balance <= $%d", c.MaxBalance.String())
+		add("currency = $%d", c.MaxBalance.Currency())
+	}
+
+	return conditions, args
+}
+
+// accountPageQuery monta a consulta de uma página da listagem sobre table,
+// selecionando columns seguidas do valor textual da coluna de ordenação, que
+// forma o cursor. Busca uma conta além do limite para saber se existe próxima página.
+func accountPageQuery(table, columns string, c account.Criteria) (string, []interface{}) {
+	conditions, args := accountFilter(c)
+
+	column, ok := accountSortColumns[c.SortBy]
+	if !ok {
+		column = accountSortColumns[account.SortByCreatedAt]
+	}
+	op, order := ">", "ASC"
+	if c.Descending {
+		op, order = "<", "DESC"
+	}
+
+	// Paginação por chave: o ID desempata contas com o mesmo valor ordenado
+	keys := []string{column, "id"}
+	var after []interface{}
+	if c.After != nil {
+		after = []interface{}{c.After.Value, c.After.ID}
+	}
+	if c.SortBy == account.SortByBalance {
+		keys = []string{"currency", column, "id"}
+		if c.After != nil {
+			after = []interface{}{c.After.Currency, c.After.Value, c.After.ID}
+		}
+	}
+	if c.After != nil {
+		placeholders := make([]string, len(after))
+		for i, value := range after {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s) %s (%s)", strings.Join(keys, ", "), op, strings.Join(placeholders, ", ")))
+	}
+	args = append(args, c.Limit+1)
+
+	orderBy := make([]string, len(keys))
+	for i, key := range keys {
+		orderBy[i] = key + " " + order
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s::text
+		FROM %s%s
+		ORDER BY %s
+		LIMIT $%d
+	`, columns, column, table, whereClause(conditions), strings.Join(orderBy, ", "), len(args))
+	return query, args
+}
+
+// newAccountPage monta a página a partir das posições lidas por uma consulta de
+// accountPageQuery e das contas correspondentes, que podem omitir a excedente
+func newAccountPage(db DBTX, table string, c account.Criteria, accounts []*account.Account, cursors []account.Cursor) (*account.Page, error) {
+	page := &account.Page{Accounts: accounts}
+	if len(cursors) > c.Limit {
+		if len(accounts) > c.Limit {
+			page.Accounts = accounts[:c.Limit]
+		}
+		page.Next = &cursors[c.Limit-1]
+	}
+
+	// Uma listagem que cabe em uma única página tem o total exato
+	if c.After == nil && page.Next == nil {
+		page.TotalEstimate = int64(len(page.Accounts))
+		return page, nil
+	}
+
+	estimate, err := estimateAccounts(db, table, c)
+	if err != nil {
+		return nil, err
+	}
+	page.TotalEstimate = estimate
+	return page, nil
+}
+
+// estimateAccounts estima, pelo planejador do PostgreSQL, quantas contas atendem
+// aos filtros em table, sem percorrê-las como um COUNT(*) faria
+func estimateAccounts(db DBTX, table string, c account.Criteria) (int64, error) {
+	conditions, args := accountFilter(c)
+
+	var plan []byte
+	query := "EXPLAIN (FORMAT JSON) SELECT 1 FROM " + table + whereClause(conditions)
+	if err := db.QueryRow(query, args...).Scan(&plan); err != nil {
+		return 0, err
+	}
+
+	var result []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return int64(result[0].Plan.Rows), nil
+}
+
+// whereClause junta as condições em uma cláusula WHERE; sem condições, retorna vazio
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+}
+
+// escapeLike escapa os curingas de LIKE para que o trecho seja buscado literalmente
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
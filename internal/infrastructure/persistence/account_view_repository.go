@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// accountViewsTable é a tabela do modelo de leitura das contas
+const accountViewsTable = "read_model.accounts"
+
+// accountViewColumns são as colunas lidas da visão de uma conta
+const accountViewColumns = "id, name, email, account_type, balance, currency, overdraft_limit, holds, status, version, created_at, updated_at"
+
+// PostgresAccountViewRepository implementa query.AccountViewRepository sobre a
+// tabela read_model.accounts, mantida pela projeção de contas. As reservas
+// ativas ficam na própria linha, como JSON, para que a consulta não precise de
+// outra tabela.
+type PostgresAccountViewRepository struct {
+	db DBTX
+}
+
+// NewPostgresAccountViewRepository cria um novo repositório de visões de contas
+func NewPostgresAccountViewRepository(db *sql.DB) *PostgresAccountViewRepository {
+	return &PostgresAccountViewRepository{db: db}
+}
+
+// Save grava a visão da conta. Uma visão gravada a partir de uma versão igual ou
+// mais recente da conta é mantida, então eventos repetidos ou atrasados não
+// fazem a visão voltar no tempo.
+func (r *PostgresAccountViewRepository) Save(acc *account.Account) error {
+	holds := acc.Holds
+	if holds == nil {
+		holds = []account.Hold{}
+	}
+	content, err := json.Marshal(holds)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO read_model.accounts (id, name, email, account_type, balance, currency, overdraft_limit, holds, status, version, created_at, updated_at, projected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			email = EXCLUDED.email,
+			account_type = EXCLUDED.account_type,
+			balance = EXCLUDED.balance,
+			currency = EXCLUDED.currency,
+			overdraft_limit = EXCLUDED.overdraft_limit,
+			holds = EXCLUDED.holds,
+			status = EXCLUDED.status,
+			version = EXCLUDED.version,
+			updated_at = EXCLUDED.updated_at,
+			projected_at = EXCLUDED.projected_at
+		WHERE read_model.accounts.version < EXCLUDED.version
+	`,
+		acc.ID,
+		acc.Name,
+		acc.Email,
+		acc.Type,
+		acc.Balance.String(),
+		acc.Currency(),
+		acc.OverdraftLimit.String(),
+		content,
+		acc.Status,
+		acc.Version,
+		acc.CreatedAt,
+		acc.UpdatedAt,
+		time.Now().UTC(),
+	)
+	return err
+}
+
+// FindByID busca a visão da conta pelo ID
+func (r *PostgresAccountViewRepository) FindByID(id string) (*account.Account, error) {
+	row := r.db.QueryRow("SELECT "+accountViewColumns+" FROM read_model.accounts WHERE id = $1", id)
+	return noAccountView(scanAccountView(row))
+}
+
+// FindByEmail busca a visão da conta pelo e-mail
+func (r *PostgresAccountViewRepository) FindByEmail(email string) (*account.Account, error) {
+	row := r.db.QueryRow("SELECT "+accountViewColumns+" FROM read_model.accounts WHERE email = $1", email)
+	return noAccountView(scanAccountView(row))
+}
+
+// Find busca uma página das visões de contas que atendem aos critérios
+func (r *PostgresAccountViewRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	query, args := accountPageQuery(accountViewsTable, accountViewColumns, criteria)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []*account.Account
+	var cursors []account.Cursor
+	for rows.Next() {
+		var sortValue string
+		acc, err := scanAccountView(rows, &sortValue)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+		cursors = append(cursors, account.Cursor{Value: sortValue, Currency: acc.Currency(), ID: acc.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return newAccountPage(r.db, accountViewsTable, criteria, accounts, cursors)
+}
+
+// noAccountView converte a ausência da visão em uma conta nil, sem erro
+func noAccountView(acc *account.Account, err error) (*account.Account, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return acc, err
+}
+
+// scanAccountView lê a visão de uma conta selecionada com accountViewColumns.
+// As colunas selecionadas além dessas são lidas em extra.
+func scanAccountView(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*account.Account, error) {
+	var acc account.Account
+	var accountType, balance, currency, overdraftLimit, status string
+	var holds []byte
+
+	dest := []interface{}{
+		&acc.ID,
+		&acc.Name,
+		&acc.Email,
+		&accountType,
+		&balance,
+		&currency,
+		&overdraftLimit,
+		&holds,
+		&status,
+		&acc.Version,
+		&acc.CreatedAt,
+		&acc.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if acc.Balance, err = account.ParseMoney(balance, currency); err != nil {
+		return nil, err
+	}
+	if acc.OverdraftLimit, err = account.ParseMoney(overdraftLimit, currency); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(holds, &acc.Holds); err != nil {
+		return nil, err
+	}
+	if len(acc.Holds) == 0 {
+		acc.Holds = nil
+	}
+
+	acc.Type = account.AccountType(accountType)
+	acc.Status = account.AccountStatus(status)
+	return &acc, nil
+}
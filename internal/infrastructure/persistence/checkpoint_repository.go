@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"database/sql"
+
+	"github.com/viniciuslima/account-EDA/internal/application/projection"
+)
+
+// PostgresCheckpointRepository implementa projection.CheckpointRepository sobre
+// a tabela read_model.projection_checkpoints. Os checkpoints ficam no mesmo banco
+// dos modelos de leitura, para que apagar o modelo de leitura também descarte
+// os checkpoints e leve à sua reconstrução.
+type PostgresCheckpointRepository struct {
+	db DBTX
+}
+
+// NewPostgresCheckpointRepository cria um novo repositório de checkpoints
+func NewPostgresCheckpointRepository(db *sql.DB) *PostgresCheckpointRepository {
+	return &PostgresCheckpointRepository{db: db}
+}
+
+// Load busca o offset da última mensagem processada pela projeção na partição
+func (r *PostgresCheckpointRepository) Load(name, topic string, partition int) (int64, bool, error) {
+	var offset int64
+	err := r.db.QueryRow(`
+		SELECT "offset" FROM read_model.projection_checkpoints
+		WHERE projection = $1 AND topic = $2 AND partition = $3
+	`, name, topic, partition).Scan(&offset)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return offset, true, nil
+}
+
+// Save registra a posição processada, sem recuar um checkpoint mais adiantado
+func (r *PostgresCheckpointRepository) Save(name string, position projection.Position) error {
+	_, err := r.db.Exec(`
+		INSERT INTO read_model.projection_checkpoints (projection, topic, partition, "offset", updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (projection, topic, partition) DO UPDATE SET
+			"offset" = GREATEST(read_model.projection_checkpoints."offset", EXCLUDED."offset"),
+			updated_at = EXCLUDED.updated_at
+	`, name, position.Topic, position.Partition, position.Offset)
+	return err
+}
+
+// Exists indica se a projeção tem algum checkpoint gravado
+func (r *PostgresCheckpointRepository) Exists(name string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM read_model.projection_checkpoints WHERE projection = $1)
+	`, name).Scan(&exists)
+	return exists, err
+}
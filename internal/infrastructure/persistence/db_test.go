@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// newTestDB conecta ao PostgreSQL indicado em TEST_DATABASE_URL (no formato
+// "host=... user=... dbname=..."), cria um schema exclusivo para o teste e executa
+// as migrações nele. Sem a variável, o teste é ignorado.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	connStr := os.Getenv("TEST_DATABASE_URL")
+	if connStr == "" {
+		t.Skip("TEST_DATABASE_URL não definida; teste de integração ignorado")
+	}
+
+	admin, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := "test_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", connStr+" search_path="+schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,488 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// EventSourcedRepository implementa account.Repository usando um event store no
+// PostgreSQL: os eventos de cada conta são gravados no fluxo account_events com
+// números de sequência por agregado, e a conta é reconstruída pela reprodução deles.
+//
+// A tabela accounts continua sendo mantida, na mesma transação, como projeção do
+// estado atual. Ela atende buscas por e-mail, listagens e a conciliação do razão.
+//
+// Com snapshotEvery > 0, um snapshot da conta é gravado em account_snapshots a
+// cada snapshotEvery eventos, e a carga parte do snapshot mais recente,
+// reproduzindo apenas os eventos posteriores a ele.
+type EventSourcedRepository struct {
+	db            DBTX
+	snapshotEvery int64
+}
+
+// DefaultSnapshotEvery é o intervalo padrão, em eventos, entre snapshots de uma conta
+const DefaultSnapshotEvery = 100
+
+// NewEventSourcedRepository cria um novo repositório baseado em eventos.
+// snapshotEvery igual a zero desativa os snapshots.
+func NewEventSourcedRepository(db *sql.DB, snapshotEvery int64) *EventSourcedRepository {
+	return &EventSourcedRepository{db: db, snapshotEvery: snapshotEvery}
+}
+
+// Save inicia o fluxo de eventos de uma nova conta
+func (r *EventSourcedRepository) Save(acc *account.Account) error {
+	changes := acc.Changes()
+	if len(changes) == 0 {
+		return fmt.Errorf("account %s has no events to persist", acc.ID)
+	}
+
+	if err := r.appendEvents(acc.ID, acc.Version, changes); err != nil {
+		return err
+	}
+	version := acc.Version + int64(len(changes))
+
+	query := `
+		INSERT INTO accounts (id, name, email, account_type, balance, currency, status, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(
+		query,
+		acc.ID,
+		acc.Name,
+		acc.Email,
+		acc.Type,
+		acc.Balance.String(),
+		acc.Currency(),
+		acc.Status,
+		version,
+		acc.CreatedAt,
+		acc.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.committed(acc, version)
+}
+
+// FindByID reconstrói uma conta a partir do snapshot mais recente e dos eventos
+// gravados depois dele (ou do fluxo completo, se não houver snapshot)
+func (r *EventSourcedRepository) FindByID(id string) (*account.Account, error) {
+	snapshot, err := r.latestSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	var fromVersion int64
+	if snapshot != nil {
+		fromVersion = snapshot.Version
+	}
+
+	query := `
+		SELECT sequence, event_type, payload
+		FROM account_events
+		WHERE aggregate_id = $1 AND sequence > $2
+		ORDER BY sequence
+	`
+	rows, err := r.db.Query(query, id, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []account.Event
+	version := fromVersion
+	for rows.Next() {
+		event, sequence, err := scanAccountEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+		version = sequence
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var acc *account.Account
+	if snapshot != nil {
+		acc, err = account.RehydrateFromSnapshot(*snapshot, events)
+	} else {
+		acc, err = account.Rehydrate(events)
+	}
+	if err != nil || acc == nil {
+		return acc, err
+	}
+	// O fluxo de uma conta importada não começa na sequência 1, então a versão é
+	// a sequência do último evento, e não o número de eventos reproduzidos
+	acc.Version = version
+	return acc, nil
+}
+
+// FindByEmail localiza a conta pela projeção e a reconstrói pelo fluxo de eventos
+func (r *EventSourcedRepository) FindByEmail(email string) (*account.Account, error) {
+	var id string
+	err := r.db.QueryRow(`SELECT id FROM accounts WHERE email = $1`, email).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Nenhuma conta encontrada
+		}
+		return nil, err
+	}
+
+	return r.FindByID(id)
+}
+
+// Find seleciona na projeção accounts a página de contas que atendem aos
+// critérios e reconstrói cada uma a partir de seu fluxo de eventos
+func (r *EventSourcedRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	query, args := accountPageQuery("accounts", "id, currency", criteria)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cursors []account.Cursor
+	for rows.Next() {
+		var cursor account.Cursor
+		if err := rows.Scan(&cursor.ID, &cursor.Currency, &cursor.Value); err != nil {
+			return nil, err
+		}
+		cursors = append(cursors, cursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// A conta excedente indica apenas que existe próxima página e não é reconstruída
+	visible := cursors
+	if len(visible) > criteria.Limit {
+		visible = visible[:criteria.Limit]
+	}
+	accounts := make([]*account.Account, 0, len(visible))
+	for _, cursor := range visible {
+		acc, err := r.FindByID(cursor.ID)
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			return nil, fmt.Errorf("account %s has no events", cursor.ID)
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return newAccountPage(r.db, "accounts", criteria, accounts, cursors)
+}
+
+// Update acrescenta ao fluxo os eventos pendentes da conta. A chave primária
+// (aggregate_id, sequence) garante que apenas uma transação consiga gravar a
+// próxima sequência; a perdedora recebe account.ErrVersionConflict.
+func (r *EventSourcedRepository) Update(acc *account.Account) error {
+	changes := acc.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := r.appendEvents(acc.ID, acc.Version, changes); err != nil {
+		return err
+	}
+	version := acc.Version + int64(len(changes))
+
+	query := `
+		UPDATE accounts
+		SET name = $1, email = $2, balance = $3, overdraft_limit = $4, status = $5, updated_at = $6, version = $7
+		WHERE id = $8
+	`
+	_, err := r.db.Exec(
+		query,
+		acc.Name,
+		acc.Email,
+		acc.Balance.String(),
+		acc.OverdraftLimit.String(),
+		acc.Status,
+		acc.UpdatedAt,
+		version,
+		acc.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if err := saveHolds(r.db, acc); err != nil {
+		return err
+	}
+
+	return r.committed(acc, version)
+}
+
+// committed atualiza a conta após a gravação dos eventos e grava um snapshot se
+// o fluxo cruzou um múltiplo de snapshotEvery
+func (r *EventSourcedRepository) committed(acc *account.Account, version int64) error {
+	previous := acc.Version
+	acc.Version = version
+	acc.ClearChanges()
+
+	if r.snapshotEvery <= 0 || previous/r.snapshotEvery == version/r.snapshotEvery {
+		return nil
+	}
+
+	snapshot, err := acc.Snapshot()
+	if err != nil {
+		return err
+	}
+	return r.saveSnapshot(snapshot)
+}
+
+// saveSnapshot grava o snapshot na mesma transação dos eventos que ele resume
+func (r *EventSourcedRepository) saveSnapshot(snapshot account.Snapshot) error {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO account_snapshots (aggregate_id, version, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (aggregate_id, version) DO NOTHING
+	`
+	_, err = r.db.Exec(query, snapshot.AccountID, snapshot.Version, payload, time.Now())
+	return err
+}
+
+// latestSnapshot busca o snapshot mais recente da conta. Um snapshot que não pode
+// ser lido é ignorado, e a conta é reconstruída a partir do fluxo completo.
+func (r *EventSourcedRepository) latestSnapshot(id string) (*account.Snapshot, error) {
+	query := `
+		SELECT payload
+		FROM account_snapshots
+		WHERE aggregate_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	var payload []byte
+	if err := r.db.QueryRow(query, id).Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var snapshot account.Snapshot
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		log.Printf("Snapshot da conta %s ignorado, reproduzindo o fluxo completo: %v", id, err)
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+// appendEvents grava os eventos no fluxo da conta a partir da versão esperada
+func (r *EventSourcedRepository) appendEvents(aggregateID string, expectedVersion int64, events []account.Event) error {
+	query := `
+		INSERT INTO account_events (aggregate_id, sequence, event_id, event_type, payload, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(
+			query,
+			aggregateID,
+			expectedVersion+int64(i)+1,
+			event.EventID(),
+			event.EventName(),
+			payload,
+			event.OccurredAt(),
+		)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "account_events_pkey" {
+				return account.ErrVersionConflict
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportAccountStreams cria o fluxo de eventos das contas gravadas antes do event
+// store, para que possam ser carregadas pelo EventSourcedRepository. Cada conta
+// recebe um evento de criação, um depósito com o saldo atual e, se for o caso,
+// o bloqueio ou o encerramento, numerados a partir da versão atual da conta.
+// Contas que já possuem fluxo são ignoradas. Retorna quantas contas foram importadas.
+func ImportAccountStreams(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, name, email, account_type, balance, currency, overdraft_limit, status, version, created_at, updated_at
+		FROM accounts a
+		WHERE NOT EXISTS (SELECT 1 FROM account_events e WHERE e.aggregate_id = a.id)
+		FOR UPDATE
+	`
+	rows, err := tx.Query(query)
+	if err != nil {
+		return 0, err
+	}
+
+	crud := &PostgresRepository{db: tx}
+	var accounts []*account.Account
+	for rows.Next() {
+		acc, err := crud.scanAccountFromRows(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		accounts = append(accounts, acc)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if err := loadHolds(tx, accounts...); err != nil {
+		return 0, err
+	}
+
+	// O fluxo continua a partir da versão gravada em accounts, para que a versão
+	// da conta nunca diminua: o modelo de leitura ignora versões menores que a
+	// que já projetou e deixaria de acompanhar a conta
+	store := &EventSourcedRepository{db: tx}
+	for _, acc := range accounts {
+		events := importedAccountEvents(acc)
+		if err := store.appendEvents(acc.ID, acc.Version, events); err != nil {
+			return 0, err
+		}
+		version := acc.Version + int64(len(events))
+		if _, err := tx.Exec(`UPDATE accounts SET version = $1 WHERE id = $2`, version, acc.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(accounts), nil
+}
+
+// importedAccountEvents gera o histórico mínimo que reproduz o estado atual de
+// uma conta, incluindo o limite de cheque especial e as reservas ativas
+func importedAccountEvents(acc *account.Account) []account.Event {
+	base := func(eventType string) account.BaseEvent {
+		return account.BaseEvent{
+			ID:        uuid.New().String(),
+			AccountID: acc.ID,
+			EventType: eventType,
+			Timestamp: acc.UpdatedAt,
+			AggrID:    acc.ID,
+		}
+	}
+
+	created := base("AccountCreated")
+	created.Timestamp = acc.CreatedAt
+	events := []account.Event{
+		account.AccountCreatedEvent{BaseEvent: created, Name: acc.Name, Email: acc.Email, Currency: acc.Currency(), AccountType: acc.Type},
+	}
+	if acc.OverdraftLimit.IsPositive() {
+		events = append(events, account.OverdraftLimitChangedEvent{
+			BaseEvent:     base("OverdraftLimitChanged"),
+			Limit:         acc.OverdraftLimit,
+			PreviousLimit: account.Zero(acc.OverdraftLimit.Currency()),
+		})
+	}
+	if acc.Balance.IsPositive() {
+		events = append(events, account.AccountDepositedEvent{
+			BaseEvent:      base("AccountDeposited"),
+			Amount:         acc.Balance,
+			CurrentBalance: acc.Balance,
+		})
+	} else if acc.Balance.IsNegative() {
+		events = append(events, account.AccountWithdrawnEvent{
+			BaseEvent:      base("AccountWithdrawn"),
+			Amount:         acc.Balance.Neg(),
+			CurrentBalance: acc.Balance,
+		})
+	}
+	available := acc.Balance
+	for _, h := range acc.Holds {
+		available, _ = available.Sub(h.Amount)
+		placed := base("HoldPlaced")
+		placed.Timestamp = h.CreatedAt
+		events = append(events, account.HoldPlacedEvent{
+			BaseEvent:        placed,
+			HoldID:           h.ID,
+			Amount:           h.Amount,
+			ExpiresAt:        h.ExpiresAt,
+			AvailableBalance: available,
+		})
+	}
+	switch acc.Status {
+	case account.StatusBlocked:
+		events = append(events, account.AccountBlockedEvent{BaseEvent: base("AccountBlocked")})
+	case account.StatusClosed:
+		events = append(events, account.AccountClosedEvent{BaseEvent: base("AccountClosed")})
+	}
+	return events
+}
+
+// accountEventDecoders mapeia cada tipo de evento do fluxo para a função que o reconstrói
+var accountEventDecoders = map[string]func(payload []byte) (account.Event, error){
+	"AccountCreated":   decodeStoredEvent[account.AccountCreatedEvent],
+	"AccountDeposited": decodeStoredEvent[account.AccountDepositedEvent],
+	"AccountWithdrawn": decodeStoredEvent[account.AccountWithdrawnEvent],
+	"AccountBlocked":   decodeStoredEvent[account.AccountBlockedEvent],
+	"AccountActivated": decodeStoredEvent[account.AccountActivatedEvent],
+	"AccountClosed":    decodeStoredEvent[account.AccountClosedEvent],
+	"HoldPlaced":       decodeStoredEvent[account.HoldPlacedEvent],
+	"HoldCaptured":     decodeStoredEvent[account.HoldCapturedEvent],
+	"HoldReleased":     decodeStoredEvent[account.HoldReleasedEvent],
+
+	"OverdraftLimitChanged": decodeStoredEvent[account.OverdraftLimitChangedEvent],
+	"InterestCredited":      decodeStoredEvent[account.InterestCreditedEvent],
+	"FeeCharged":            decodeStoredEvent[account.FeeChargedEvent],
+	"TransactionReversed":   decodeStoredEvent[account.TransactionReversedEvent],
+}
+
+// decodeAccountEvent reconstrói um evento gravado no fluxo de uma conta
+func decodeAccountEvent(eventType string, payload []byte) (account.Event, error) {
+	decode, ok := accountEventDecoders[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown account event type %q", eventType)
+	}
+	return decode(payload)
+}
+
+// decodeStoredEvent deserializa o payload JSON no tipo de evento E
+func decodeStoredEvent[E account.Event](payload []byte) (account.Event, error) {
+	var e E
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// scanAccountEvent lê um evento do fluxo e sua sequência a partir de uma linha
+// (sequence, event_type, payload)
+func scanAccountEvent(rows *sql.Rows) (account.Event, int64, error) {
+	var sequence int64
+	var eventType string
+	var payload []byte
+	if err := rows.Scan(&sequence, &eventType, &payload); err != nil {
+		return nil, 0, err
+	}
+	event, err := decodeAccountEvent(eventType, payload)
+	return event, sequence, err
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+func TestEventSourcedRepository_Find_PagesByBalanceAcrossCurrencies(t *testing.T) {
+	// Arrange: saldos iguais em moedas diferentes, que só a moeda do cursor separa
+	db := newTestDB(t)
+	repo := NewEventSourcedRepository(db, 0)
+
+	balances := []account.Money{
+		account.NewMoney(5000, "USD"),
+		account.NewMoney(1000, "BRL"),
+		account.NewMoney(1000, "USD"),
+		account.NewMoney(5000, "BRL"),
+	}
+	for i, balance := range balances {
+		acc, err := account.NewAccount("Conta", fmt.Sprintf("conta%d@example.com", i), balance.Currency(), account.TypeChecking)
+		assert.NoError(t, err)
+		assert.NoError(t, acc.Deposit(balance))
+		assert.NoError(t, repo.Save(acc))
+	}
+
+	// Act: percorre a listagem uma conta por página
+	criteria := account.Criteria{SortBy: account.SortByBalance, Limit: 1}
+	var visited []account.Money
+	for pages := 0; pages <= len(balances); pages++ {
+		page, err := repo.Find(criteria)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, acc := range page.Accounts {
+			visited = append(visited, acc.Balance)
+		}
+		if page.Next == nil {
+			break
+		}
+		assert.NotEmpty(t, page.Next.Currency)
+		criteria.After = page.Next
+	}
+
+	// Assert: as contas vêm agrupadas por moeda, cada uma uma única vez
+	assert.Equal(t, []account.Money{
+		account.NewMoney(1000, "BRL"),
+		account.NewMoney(5000, "BRL"),
+		account.NewMoney(1000, "USD"),
+		account.NewMoney(5000, "USD"),
+	}, visited)
+}
+
+func TestImportAccountStreams_KeepsVersionIncreasing(t *testing.T) {
+	// Arrange: uma conta alterada cinco vezes antes do event store, na versão 5
+	db := newTestDB(t)
+	crud := &PostgresRepository{db: db}
+	acc, err := account.NewAccount("Conta", "conta@example.com", "BRL", account.TypeChecking)
+	assert.NoError(t, err)
+	assert.NoError(t, crud.Save(acc))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, acc.Deposit(account.NewMoney(1000, "BRL")))
+		assert.NoError(t, crud.Update(acc))
+	}
+	assert.Equal(t, int64(5), acc.Version)
+
+	// Act
+	imported, err := ImportAccountStreams(db)
+
+	// Assert: o fluxo continua a partir da versão 5 (criação e depósito do saldo)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, imported)
+
+	store := NewEventSourcedRepository(db, 0)
+	loaded, err := store.FindByID(acc.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(7), loaded.Version)
+	assert.Equal(t, account.NewMoney(5000, "BRL"), loaded.Balance)
+
+	// Act: uma nova operação pelo event store
+	assert.NoError(t, loaded.Deposit(account.NewMoney(1000, "BRL")))
+	assert.NoError(t, store.Update(loaded))
+
+	// Assert: a projeção accounts acompanha a versão, que só cresce
+	var version int64
+	assert.NoError(t, db.QueryRow(`SELECT version FROM accounts WHERE id = $1`, acc.ID).Scan(&version))
+	assert.Equal(t, int64(8), version)
+	assert.Equal(t, int64(8), loaded.Version)
+}
@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+)
+
+// PostgresFeeRepository implementa fee.Repository usando PostgreSQL
+type PostgresFeeRepository struct {
+	db DBTX
+}
+
+// NewPostgresFeeRepository cria um novo repositório de tarifas
+func NewPostgresFeeRepository(db *sql.DB) *PostgresFeeRepository {
+	return &PostgresFeeRepository{db: db}
+}
+
+// FindAccounts retorna, em ordem de ID, até limit contas com ID maior que
+// afterID, permitindo percorrer todas as contas em lotes
+func (r *PostgresFeeRepository) FindAccounts(afterID string, limit int) ([]string, error) {
+	query := `
+		SELECT id
+		FROM accounts
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.Query(query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// FindSchedule retorna as tarifas de fee_schedules do tipo de conta e da
+// moeda informados, descontadas as isenções da conta vigentes em at
+func (r *PostgresFeeRepository) FindSchedule(accountID string, accountType account.AccountType, currency string, at time.Time) (fee.Schedule, error) {
+	query := `
+		SELECT s.kind, s.amount
+		FROM fee_schedules s
+		WHERE s.account_type = $2 AND s.currency = $3
+		AND NOT EXISTS (
+			SELECT 1 FROM fee_waivers w
+			WHERE w.account_id = $1 AND w.kind IN (s.kind, $4)
+			AND (w.expires_at IS NULL OR w.expires_at > $5)
+		)
+	`
+	rows, err := r.db.Query(query, accountID, string(accountType), currency, fee.KindAll, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedule := make(fee.Schedule)
+	for rows.Next() {
+		var kind, amount string
+		if err := rows.Scan(&kind, &amount); err != nil {
+			return nil, err
+		}
+		if schedule[kind], err = account.ParseMoney(amount, currency); err != nil {
+			return nil, err
+		}
+	}
+	return schedule, rows.Err()
+}
+
+// SaveWaiver grava a isenção, substituindo a do mesmo tipo
+func (r *PostgresFeeRepository) SaveWaiver(w fee.Waiver) error {
+	query := `
+		INSERT INTO fee_waivers (account_id, kind, reason, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id, kind) DO UPDATE
+		SET reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+	`
+	_, err := r.db.Exec(query, w.AccountID, w.Kind, w.Reason, w.ExpiresAt, w.CreatedAt)
+	return err
+}
+
+// DeleteWaiver remove a isenção, informando se ela existia
+func (r *PostgresFeeRepository) DeleteWaiver(accountID, kind string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM fee_waivers WHERE account_id = $1 AND kind = $2`, accountID, kind)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// Record registra uma tarifa cobrada; o índice único de fee_charges recusa uma
+// segunda tarifa de manutenção no mesmo período
+func (r *PostgresFeeRepository) Record(c fee.Charge) error {
+	query := `
+		INSERT INTO fee_charges (event_id, account_id, kind, amount, currency, reference, period, charged_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8)
+	`
+	_, err := r.db.Exec(
+		query,
+		c.EventID,
+		c.AccountID,
+		c.Kind,
+		c.Amount.String(),
+		c.Amount.Currency(),
+		c.Reference,
+		c.Period,
+		c.ChargedAt,
+	)
+	return err
+}
+
+// Charged indica se a conta já foi tarifada no período pelo tipo informado
+func (r *PostgresFeeRepository) Charged(accountID, kind, period string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM fee_charges WHERE account_id = $1 AND kind = $2 AND period = $3)`
+
+	var charged bool
+	err := r.db.QueryRow(query, accountID, kind, period).Scan(&charged)
+	return charged, err
+}
+
+// FindCharge busca a tarifa cobrada da conta pela operação reference
+func (r *PostgresFeeRepository) FindCharge(accountID, kind, reference string) (*fee.Charge, error) {
+	query := `
+		SELECT event_id, amount, currency, COALESCE(period, ''), charged_at
+		FROM fee_charges
+		WHERE account_id = $1 AND kind = $2 AND reference = $3
+	`
+	c := fee.Charge{AccountID: accountID, Kind: kind, Reference: reference}
+	var amount, currency string
+	err := r.db.QueryRow(query, accountID, kind, reference).Scan(&c.EventID, &amount, &currency, &c.Period, &c.ChargedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Amount, err = account.ParseMoney(amount, currency); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// PostgresHoldRepository consulta as reservas ativas gravadas em account_holds.
+// A tabela é mantida pelos repositórios de contas na mesma transação da conta.
+type PostgresHoldRepository struct {
+	db DBTX
+}
+
+// NewPostgresHoldRepository cria um novo repositório de reservas
+func NewPostgresHoldRepository(db *sql.DB) *PostgresHoldRepository {
+	return &PostgresHoldRepository{db: db}
+}
+
+// FindAccountsWithExpiredHolds retorna até limit contas com reservas vencidas até now
+func (r *PostgresHoldRepository) FindAccountsWithExpiredHolds(now time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT account_id
+		FROM account_holds
+		WHERE expires_at <= $1
+		GROUP BY account_id
+		ORDER BY MIN(expires_at)
+		LIMIT $2
+	`
+	rows, err := r.db.Query(query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accountIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		accountIDs = append(accountIDs, id)
+	}
+
+	return accountIDs, rows.Err()
+}
+
+// saveHolds substitui as reservas gravadas da conta pelas reservas ativas do agregado
+func saveHolds(db DBTX, acc *account.Account) error {
+	if _, err := db.Exec(`DELETE FROM account_holds WHERE account_id = $1`, acc.ID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO account_holds (id, account_id, amount, currency, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, h := range acc.Holds {
+		_, err := db.Exec(query, h.ID, acc.ID, h.Amount.String(), h.Amount.Currency(), h.ExpiresAt, h.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadHolds preenche as reservas ativas das contas informadas
+func loadHolds(db DBTX, accounts ...*account.Account) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*account.Account, len(accounts))
+	ids := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+		ids = append(ids, acc.ID)
+	}
+
+	query := `
+		SELECT id, account_id, amount, currency, expires_at, created_at
+		FROM account_holds
+		WHERE account_id = ANY($1)
+		ORDER BY created_at, id
+	`
+	rows, err := db.Query(query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h account.Hold
+		var accountID, amount, currency string
+		if err := rows.Scan(&h.ID, &accountID, &amount, &currency, &h.ExpiresAt, &h.CreatedAt); err != nil {
+			return err
+		}
+		if h.Amount, err = account.ParseMoney(amount, currency); err != nil {
+			return err
+		}
+		acc := byID[accountID]
+		acc.Holds = append(acc.Holds, h)
+	}
+
+	return rows.Err()
+}
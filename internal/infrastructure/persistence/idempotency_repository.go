@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Status de uma chave de idempotência
+const (
+	IdempotencyInProgress = "in_progress"
+	IdempotencyCompleted  = "completed"
+)
+
+// IdempotencyRecord é a requisição registrada sob uma chave de idempotência
+type IdempotencyRecord struct {
+	Key            string
+	RequestHash    string
+	Status         string
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+}
+
+// IdempotencyRepositoryInterface define a interface para o repositório de chaves de idempotência
+type IdempotencyRepositoryInterface interface {
+	// Acquire reserva a chave para a requisição por até lease. Retorna nil se a
+	// reserva foi feita (chave nova, expirada ou com a reserva da mesma
+	// requisição vencida); caso contrário, retorna o registro existente.
+	Acquire(key, requestHash string, ttl, lease time.Duration) (*IdempotencyRecord, error)
+
+	// Complete grava a resposta da requisição que reservou a chave
+	Complete(key string, responseStatus int, responseBody []byte) error
+
+	// Release libera uma chave reservada cuja requisição pode ser repetida
+	Release(key string) error
+}
+
+// IdempotencyRepository implementa IdempotencyRepositoryInterface usando PostgreSQL
+type IdempotencyRepository struct {
+	db DBTX
+}
+
+// NewIdempotencyRepository cria um novo repositório de chaves de idempotência
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Acquire reserva a chave para a requisição. A inserção condicional garante que,
+// entre requisições concorrentes com a mesma chave, apenas uma a reserve.
+//
+// A reserva vale até locked_until: uma chave em andamento cuja reserva venceu,
+// deixada por uma requisição interrompida, é assumida por uma repetição com o
+// mesmo conteúdo, que executa a operação de novo.
+func (r *IdempotencyRepository) Acquire(key, requestHash string, ttl, lease time.Duration) (*IdempotencyRecord, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash, status, locked_until, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $5), NOW(), NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status = EXCLUDED.status,
+			response_status = NULL, response_body = NULL, locked_until = EXCLUDED.locked_until,
+			created_at = NOW(), updated_at = NOW()
+		WHERE idempotency_keys.created_at < NOW() - make_interval(secs => $4)
+			OR (idempotency_keys.status = $3
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash
+				AND idempotency_keys.locked_until < NOW())
+		RETURNING key
+	`
+	var acquired string
+	err := r.db.QueryRow(query, key, requestHash, IdempotencyInProgress, ttl.Seconds(), lease.Seconds()).Scan(&acquired)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// A chave já existe e ainda é válida
+	query = `
+		SELECT key, request_hash, status, COALESCE(response_status, 0), response_body, created_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+	var record IdempotencyRecord
+	err = r.db.QueryRow(query, key).Scan(
+		&record.Key,
+		&record.RequestHash,
+		&record.Status,
+		&record.ResponseStatus,
+		&record.ResponseBody,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Complete grava a resposta da requisição que reservou a chave
+func (r *IdempotencyRepository) Complete(key string, responseStatus int, responseBody []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $1, response_status = $2, response_body = $3, updated_at = NOW()
+		WHERE key = $4
+	`
+	_, err := r.db.Exec(query, IdempotencyCompleted, responseStatus, responseBody, key)
+	return err
+}
+
+// Release remove a reserva de uma chave ainda em andamento
+func (r *IdempotencyRepository) Release(key string) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND status = $2`
+	_, err := r.db.Exec(query, key, IdempotencyInProgress)
+	return err
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository_Acquire_TakesOverExpiredReservation(t *testing.T) {
+	// Arrange: uma requisição reservou a chave e foi interrompida sem liberá-la
+	db := newTestDB(t)
+	repo := NewIdempotencyRepository(db)
+
+	existing, err := repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// Act: repetições durante a reserva encontram a chave em andamento
+	existing, err = repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, existing) {
+		assert.Equal(t, IdempotencyInProgress, existing.Status)
+	}
+
+	// Act: com a reserva vencida, outro conteúdo continua recusado e a repetição
+	// com o mesmo conteúdo assume a chave
+	_, err = db.Exec(`UPDATE idempotency_keys SET locked_until = NOW() - INTERVAL '1 second' WHERE key = $1`, "key-1")
+	assert.NoError(t, err)
+	other, otherErr := repo.Acquire("key-1", "hash-2", 24*time.Hour, time.Minute)
+	existing, err = repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(t, otherErr)
+	if assert.NotNil(t, other) {
+		assert.Equal(t, "hash-1", other.RequestHash)
+	}
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// Act: a nova reserva vale por mais um período
+	existing, err = repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, existing)
+}
+
+func TestIdempotencyRepository_Acquire_KeepsCompletedResponse(t *testing.T) {
+	// Arrange: a requisição terminou, mas a reserva já venceu
+	db := newTestDB(t)
+	repo := NewIdempotencyRepository(db)
+
+	_, err := repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Complete("key-1", 201, []byte(`{"id":"1"}`)))
+	_, err = db.Exec(`UPDATE idempotency_keys SET locked_until = NOW() - INTERVAL '1 second' WHERE key = $1`, "key-1")
+	assert.NoError(t, err)
+
+	// Act
+	existing, err := repo.Acquire("key-1", "hash-1", 24*time.Hour, time.Minute)
+
+	// Assert: o vencimento da reserva não afeta a resposta gravada
+	assert.NoError(t, err)
+	if assert.NotNil(t, existing) {
+		assert.Equal(t, IdempotencyCompleted, existing.Status)
+		assert.Equal(t, 201, existing.ResponseStatus)
+		assert.JSONEq(t, `{"id":"1"}`, string(existing.ResponseBody))
+	}
+}
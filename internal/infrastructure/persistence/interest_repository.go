@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+)
+
+// PostgresInterestRepository implementa interest.Repository usando PostgreSQL
+type PostgresInterestRepository struct {
+	db DBTX
+}
+
+// NewPostgresInterestRepository cria um novo repositório de apurações de juros
+func NewPostgresInterestRepository(db *sql.DB) *PostgresInterestRepository {
+	return &PostgresInterestRepository{db: db}
+}
+
+// FindSavingsAccounts retorna, em ordem de ID, até limit contas poupança com ID
+// maior que afterID, permitindo percorrer todas as contas em lotes
+func (r *PostgresInterestRepository) FindSavingsAccounts(afterID string, limit int) ([]string, error) {
+	query := `
+		SELECT id
+		FROM accounts
+		WHERE account_type = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	rows, err := r.db.Query(query, string(account.TypeSavings), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// LastAccrualDay retorna o último dia apurado da conta
+func (r *PostgresInterestRepository) LastAccrualDay(accountID string) (time.Time, error) {
+	query := `SELECT MAX(day) FROM interest_accruals WHERE account_id = $1`
+
+	var day sql.NullTime
+	if err := r.db.QueryRow(query, accountID).Scan(&day); err != nil {
+		return time.Time{}, err
+	}
+	if !day.Valid {
+		return time.Time{}, nil
+	}
+	return interest.Day(day.Time), nil
+}
+
+// Record grava a apuração de um dia; a chave primária ignora dias já apurados
+func (r *PostgresInterestRepository) Record(a interest.Accrual) error {
+	query := `
+		INSERT INTO interest_accruals (account_id, day, balance, currency, annual_rate, interest_micros)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (account_id, day) DO NOTHING
+	`
+	_, err := r.db.Exec(
+		query,
+		a.AccountID,
+		a.Day,
+		a.Balance.String(),
+		a.Balance.Currency(),
+		a.Rate.String(),
+		a.Interest,
+	)
+	return err
+}
+
+// Unposted retorna as apurações anteriores a before ainda não creditadas
+func (r *PostgresInterestRepository) Unposted(accountID string, before time.Time) ([]interest.Accrual, error) {
+	query := `
+		SELECT day, balance, currency, annual_rate, interest_micros
+		FROM interest_accruals
+		WHERE account_id = $1 AND day < $2 AND posted_at IS NULL
+		ORDER BY day
+	`
+	rows, err := r.db.Query(query, accountID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accruals []interest.Accrual
+	for rows.Next() {
+		a := interest.Accrual{AccountID: accountID}
+		var balance, currency, rate string
+		if err := rows.Scan(&a.Day, &balance, &currency, &rate, &a.Interest); err != nil {
+			return nil, err
+		}
+		if a.Balance, err = account.ParseMoney(balance, currency); err != nil {
+			return nil, err
+		}
+		if a.Rate, err = interest.ParseAnnualRate(rate); err != nil {
+			return nil, err
+		}
+		a.Day = interest.Day(a.Day)
+		accruals = append(accruals, a)
+	}
+	return accruals, rows.Err()
+}
+
+// MarkPosted vincula ao evento de crédito as apurações anteriores a before
+// ainda não creditadas. Um eventID vazio marca apurações sem juros a creditar.
+func (r *PostgresInterestRepository) MarkPosted(accountID string, before time.Time, eventID string) error {
+	query := `
+		UPDATE interest_accruals
+		SET posted_at = NOW(), event_id = NULLIF($3, '')
+		WHERE account_id = $1 AND day < $2 AND posted_at IS NULL
+	`
+	_, err := r.db.Exec(query, accountID, before, eventID)
+	return err
+}
@@ -0,0 +1,167 @@
+package persistence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+)
+
+// PostgresLedgerRepository implementa ledger.Repository usando PostgreSQL
+type PostgresLedgerRepository struct {
+	db DBTX
+}
+
+// NewPostgresLedgerRepository cria um novo repositório do razão
+func NewPostgresLedgerRepository(db *sql.DB) *PostgresLedgerRepository {
+	return &PostgresLedgerRepository{db: db}
+}
+
+// Append grava um lançamento e suas pernas. Deve ser chamado dentro de uma
+// transação para que o lançamento seja gravado por inteiro ou não seja gravado.
+func (r *PostgresLedgerRepository) Append(entry *ledger.JournalEntry) error {
+	_, err := r.db.Exec(`
+		INSERT INTO journal_entries (id, event_id, description, reverses_event_id, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+	`, entry.ID, entry.EventID, entry.Description, entry.ReversesEventID, entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range entry.Postings {
+		_, err := r.db.Exec(`
+			INSERT INTO journal_postings (entry_id, account_id, direction, amount, currency, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, entry.ID, p.AccountID, string(p.Direction), p.Amount.String(), p.Amount.Currency(), entry.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindByEventID busca o lançamento originado pelo evento, com suas pernas
+func (r *PostgresLedgerRepository) FindByEventID(eventID string) (*ledger.JournalEntry, error) {
+	entry := &ledger.JournalEntry{}
+	var reverses sql.NullString
+	err := r.db.QueryRow(`
+		SELECT id, event_id, description, reverses_event_id, created_at
+		FROM journal_entries
+		WHERE event_id = $1
+	`, eventID).Scan(&entry.ID, &entry.EventID, &entry.Description, &reverses, &entry.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry.ReversesEventID = reverses.String
+
+	rows, err := r.db.Query(`
+		SELECT account_id, direction, amount, currency
+		FROM journal_postings
+		WHERE entry_id = $1
+		ORDER BY id
+	`, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p ledger.Posting
+		var direction, amount, currency string
+		if err := rows.Scan(&p.AccountID, &direction, &amount, &currency); err != nil {
+			return nil, err
+		}
+		p.Direction = ledger.Direction(direction)
+		if p.Amount, err = account.ParseMoney(amount, currency); err != nil {
+			return nil, err
+		}
+		entry.Postings = append(entry.Postings, p)
+	}
+
+	return entry, rows.Err()
+}
+
+// Reversed indica se já existe um estorno do lançamento originado pelo evento
+func (r *PostgresLedgerRepository) Reversed(eventID string) (bool, error) {
+	var reversed bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM journal_entries WHERE reverses_event_id = $1)
+	`, eventID).Scan(&reversed)
+	return reversed, err
+}
+
+// Balance retorna o saldo de uma conta segundo o razão (créditos menos débitos)
+func (r *PostgresLedgerRepository) Balance(accountID, currency string) (account.Money, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0)
+		FROM journal_postings
+		WHERE account_id = $1 AND currency = $2
+	`
+
+	var balance string
+	if err := r.db.QueryRow(query, accountID, currency).Scan(&balance); err != nil {
+		return account.Money{}, err
+	}
+
+	return account.ParseMoney(balance, currency)
+}
+
+// BalanceAt retorna o saldo de uma conta segundo o razão antes do instante at
+func (r *PostgresLedgerRepository) BalanceAt(accountID, currency string, at time.Time) (account.Money, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0)
+		FROM journal_postings
+		WHERE account_id = $1 AND currency = $2 AND created_at < $3
+	`
+
+	var balance string
+	if err := r.db.QueryRow(query, accountID, currency, at).Scan(&balance); err != nil {
+		return account.Money{}, err
+	}
+
+	return account.ParseMoney(balance, currency)
+}
+
+// Reconcile compara accounts.balance com o razão e retorna as divergências
+func (r *PostgresLedgerRepository) Reconcile() ([]ledger.Discrepancy, error) {
+	query := `
+		SELECT a.id, a.currency, a.balance, COALESCE(l.balance, 0)
+		FROM accounts a
+		LEFT JOIN (
+			SELECT account_id, currency, SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END) AS balance
+			FROM journal_postings
+			GROUP BY account_id, currency
+		) l ON l.account_id = a.id AND l.currency = a.currency
+		WHERE a.balance <> COALESCE(l.balance, 0)
+		ORDER BY a.id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var discrepancies []ledger.Discrepancy
+	for rows.Next() {
+		var d ledger.Discrepancy
+		var currency, stored, fromLedger string
+		if err := rows.Scan(&d.AccountID, &currency, &stored, &fromLedger); err != nil {
+			return nil, err
+		}
+		if d.StoredBalance, err = account.ParseMoney(stored, currency); err != nil {
+			return nil, err
+		}
+		if d.LedgerBalance, err = account.ParseMoney(fromLedger, currency); err != nil {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	return discrepancies, rows.Err()
+}
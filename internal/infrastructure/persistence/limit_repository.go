@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+)
+
+// PostgresLimitRepository implementa limit.Repository usando PostgreSQL
+type PostgresLimitRepository struct {
+	db DBTX
+}
+
+// NewPostgresLimitRepository cria um novo repositório de limites
+func NewPostgresLimitRepository(db *sql.DB) *PostgresLimitRepository {
+	return &PostgresLimitRepository{db: db}
+}
+
+// FindPolicy retorna a política do nível da conta, com os limites próprios
+// gravados em account_limits, na moeda da conta, sobrepostos aos do nível
+func (r *PostgresLimitRepository) FindPolicy(accountID, currency string) (limit.Policy, error) {
+	query := `
+		SELECT tier, per_transaction, daily, monthly
+		FROM account_limits
+		WHERE account_id = $1
+	`
+
+	var tier string
+	var perTransaction, daily, monthly sql.NullString
+	err := r.db.QueryRow(query, accountID).Scan(&tier, &perTransaction, &daily, &monthly)
+	if err == sql.ErrNoRows {
+		tier = limit.TierStandard
+	} else if err != nil {
+		return limit.Policy{}, err
+	}
+
+	policy, ok := limit.ForTier(tier, currency)
+	if !ok {
+		return limit.Policy{}, fmt.Errorf("no limits for tier %q in %s for account %s", tier, currency, accountID)
+	}
+	overrides := []struct {
+		value sql.NullString
+		limit **account.Money
+	}{
+		{perTransaction, &policy.PerTransaction},
+		{daily, &policy.Daily},
+		{monthly, &policy.Monthly},
+	}
+	for _, o := range overrides {
+		if !o.value.Valid {
+			continue
+		}
+		m, err := account.ParseMoney(o.value.String, currency)
+		if err != nil {
+			return limit.Policy{}, err
+		}
+		*o.limit = &m
+	}
+
+	return policy, nil
+}
+
+// Usage soma os saques da conta nas janelas móveis diária e mensal que terminam em now
+func (r *PostgresLimitRepository) Usage(accountID, currency string, now time.Time) (limit.Usage, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE occurred_at > $3), 0),
+			COALESCE(SUM(amount), 0)
+		FROM withdrawal_usage
+		WHERE account_id = $1 AND currency = $2 AND occurred_at > $4
+	`
+
+	var daily, monthly string
+	err := r.db.QueryRow(query, accountID, currency, now.Add(-limit.DailyWindow), now.Add(-limit.MonthlyWindow)).
+		Scan(&daily, &monthly)
+	if err != nil {
+		return limit.Usage{}, err
+	}
+
+	var usage limit.Usage
+	if usage.Daily, err = account.ParseMoney(daily, currency); err != nil {
+		return limit.Usage{}, err
+	}
+	if usage.Monthly, err = account.ParseMoney(monthly, currency); err != nil {
+		return limit.Usage{}, err
+	}
+	return usage, nil
+}
+
+// Record registra um saque no uso da conta e descarta os registros da conta
+// que já saíram da janela mensal
+func (r *PostgresLimitRepository) Record(accountID, eventID string, amount account.Money, at time.Time) error {
+	_, err := r.db.Exec(`
+		INSERT INTO withdrawal_usage (event_id, account_id, amount, currency, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id) DO NOTHING
+	`, eventID, accountID, amount.String(), amount.Currency(), at)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		DELETE FROM withdrawal_usage
+		WHERE account_id = $1 AND occurred_at <= $2
+	`, accountID, at.Add(-limit.MonthlyWindow))
+	return err
+}
+
+// Release retira o saque do uso da conta
+func (r *PostgresLimitRepository) Release(accountID, eventID string) error {
+	_, err := r.db.Exec(`DELETE FROM withdrawal_usage WHERE account_id = $1 AND event_id = $2`, accountID, eventID)
+	return err
+}
@@ -2,6 +2,8 @@ package persistence
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 )
 
 // RunMigrations executa as migrações do banco de dados
@@ -10,10 +12,118 @@ func RunMigrations(db *sql.DB) error {
 		return err
 	}
 
+	if err := addAccountsVersionColumn(db); err != nil {
+		return err
+	}
+
 	if err := createOutboxTable(db); err != nil {
 		return err
 	}
 
+	if err := createTransfersTable(db); err != nil {
+		return err
+	}
+
+	if err := createLedgerTables(db); err != nil {
+		return err
+	}
+
+	if err := backfillOpeningBalances(db); err != nil {
+		return err
+	}
+
+	if err := createAccountEventsTable(db); err != nil {
+		return err
+	}
+
+	if err := createAccountSnapshotsTable(db); err != nil {
+		return err
+	}
+
+	if err := createAccountTransactionsTable(db); err != nil {
+		return err
+	}
+
+	if err := backfillAccountTransactions(db); err != nil {
+		return err
+	}
+
+	if err := createIdempotencyKeysTable(db); err != nil {
+		return err
+	}
+
+	if err := createAccountHoldsTable(db); err != nil {
+		return err
+	}
+
+	if err := addAccountsOverdraftLimitColumn(db); err != nil {
+		return err
+	}
+
+	if err := createWithdrawalLimitsTables(db); err != nil {
+		return err
+	}
+
+	if err := addAccountsCurrencyColumn(db); err != nil {
+		return err
+	}
+
+	if err := widenAmountColumns(db); err != nil {
+		return err
+	}
+
+	if err := addTransfersConversionColumns(db); err != nil {
+		return err
+	}
+
+	if err := createFXQuotesTable(db); err != nil {
+		return err
+	}
+
+	if err := addAccountsTypeColumn(db); err != nil {
+		return err
+	}
+
+	if err := createInterestAccrualsTable(db); err != nil {
+		return err
+	}
+
+	if err := createFeeTables(db); err != nil {
+		return err
+	}
+
+	if err := createTransferSchedulesTable(db); err != nil {
+		return err
+	}
+
+	if err := addJournalReversalColumn(db); err != nil {
+		return err
+	}
+
+	if err := createAccountStatementsTable(db); err != nil {
+		return err
+	}
+
+	if err := createAccountListingIndexes(db); err != nil {
+		return err
+	}
+
+	if err := createPendingTransfersIndex(db); err != nil {
+		return err
+	}
+
+	if err := convertInstantColumns(db); err != nil {
+		return err
+	}
+
+	if err := addTransfersDebitEventColumn(db); err != nil {
+		return err
+	}
+
+	if err := addIdempotencyKeysLockColumn(db); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -35,6 +145,12 @@ func createAccountsTable(db *sql.DB) error {
 }
 
 
+// addAccountsVersionColumn adiciona a coluna de versão usada no controle de concorrência otimista
+func addAccountsVersionColumn(db *sql.DB) error {
+	query := `ALTER TABLE accounts ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0`
+	_, err := db.Exec(query)
+	return err
+}
 
 // createOutboxTable cria a tabela outbox para publicação confiável de eventos
 func createOutboxTable(db *sql.DB) error {
@@ -54,3 +170,651 @@ func createOutboxTable(db *sql.DB) error {
 	_, err := db.Exec(query)
 	return err
 }
+
+// createTransfersTable cria a tabela de transferências entre contas
+func createTransfersTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS transfers (
+			id VARCHAR(36) PRIMARY KEY,
+			source_account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			destination_account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			amount DECIMAL(15, 2) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			failure_reason TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createLedgerTables cria as tabelas do razão de partidas dobradas. Os lançamentos
+// são imutáveis e cada lançamento precisa fechar (débitos = créditos por moeda)
+// ao final da transação que o gravou.
+func createLedgerTables(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS journal_entries (
+			id VARCHAR(36) PRIMARY KEY,
+			event_id VARCHAR(36) NOT NULL UNIQUE,
+			description TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS journal_postings (
+			id BIGSERIAL PRIMARY KEY,
+			entry_id VARCHAR(36) NOT NULL REFERENCES journal_entries(id),
+			account_id VARCHAR(64) NOT NULL,
+			direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+			amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+			currency CHAR(3) NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_journal_postings_account
+			ON journal_postings (account_id, created_at);
+
+		CREATE OR REPLACE FUNCTION prevent_journal_mutation() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'journal entries are immutable';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS journal_entries_immutable ON journal_entries;
+		CREATE TRIGGER journal_entries_immutable
+			BEFORE UPDATE OR DELETE ON journal_entries
+			FOR EACH ROW EXECUTE FUNCTION prevent_journal_mutation();
+
+		DROP TRIGGER IF EXISTS journal_postings_immutable ON journal_postings;
+		CREATE TRIGGER journal_postings_immutable
+			BEFORE UPDATE OR DELETE ON journal_postings
+			FOR EACH ROW EXECUTE FUNCTION prevent_journal_mutation();
+
+		CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM journal_postings
+				WHERE entry_id = NEW.entry_id
+				GROUP BY currency
+				HAVING SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END) <> 0
+			) THEN
+				RAISE EXCEPTION 'journal entry % is not balanced', NEW.entry_id;
+			END IF;
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS journal_postings_balanced ON journal_postings;
+		CREATE CONSTRAINT TRIGGER journal_postings_balanced
+			AFTER INSERT ON journal_postings
+			DEFERRABLE INITIALLY DEFERRED
+			FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// backfillOpeningBalances lança no razão os saldos de contas criadas antes dele,
+// usando a conta de sistema de saldo de abertura como contrapartida. A execução
+// é idempotente: o ID do lançamento é derivado do ID da conta.
+func backfillOpeningBalances(db *sql.DB) error {
+	query := `
+		WITH pending AS (
+			SELECT a.id AS account_id, a.balance, md5('opening:' || a.id)::uuid::text AS entry_id, NOW() AS created_at
+			FROM accounts a
+			WHERE a.balance <> 0
+			AND NOT EXISTS (SELECT 1 FROM journal_postings p WHERE p.account_id = a.id)
+		), entries AS (
+			INSERT INTO journal_entries (id, event_id, description, created_at)
+			SELECT entry_id, entry_id, 'opening balance', created_at FROM pending
+			RETURNING id
+		)
+		INSERT INTO journal_postings (entry_id, account_id, direction, amount, currency, created_at)
+		SELECT entry_id, account_id, 'credit', balance, 'BRL', created_at FROM pending
+		UNION ALL
+		SELECT entry_id, 'system:opening-balance', 'debit', balance, 'BRL', created_at FROM pending
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountEventsTable cria o event store das contas. Cada conta tem um fluxo
+// de eventos numerado; a chave primária impede duas gravações na mesma sequência.
+func createAccountEventsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_events (
+			aggregate_id VARCHAR(36) NOT NULL,
+			sequence BIGINT NOT NULL,
+			event_id VARCHAR(36) NOT NULL UNIQUE,
+			event_type VARCHAR(100) NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL,
+			recorded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			CONSTRAINT account_events_pkey PRIMARY KEY (aggregate_id, sequence)
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountSnapshotsTable cria a tabela de snapshots das contas baseadas em eventos
+func createAccountSnapshotsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_snapshots (
+			aggregate_id VARCHAR(36) NOT NULL,
+			version BIGINT NOT NULL,
+			payload JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (aggregate_id, version)
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountTransactionsTable cria o modelo de leitura do histórico de
+// transações, alimentado pelo worker a partir dos eventos de depósito e saque
+func createAccountTransactionsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_transactions (
+			event_id VARCHAR(36) PRIMARY KEY,
+			account_id VARCHAR(36) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			amount DECIMAL(15, 2) NOT NULL,
+			balance_after DECIMAL(15, 2) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			transfer_id VARCHAR(36),
+			occurred_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_account_transactions_account
+			ON account_transactions (account_id, occurred_at DESC, event_id DESC);
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// backfillAccountTransactions projeta no histórico os depósitos e saques gravados
+// no outbox antes da existência do modelo de leitura. Aceita tanto o formato atual
+// dos valores ({"amount": "10.00", "currency": "BRL"}) quanto o numérico antigo.
+func backfillAccountTransactions(db *sql.DB) error {
+	query := `
+		INSERT INTO account_transactions (event_id, account_id, type, amount, balance_after, currency, transfer_id, occurred_at)
+		SELECT
+			payload->>'id',
+			payload->>'account_id',
+			CASE event_type WHEN 'AccountDeposited' THEN 'deposit' ELSE 'withdrawal' END,
+			CASE jsonb_typeof(payload->'amount')
+				WHEN 'object' THEN (payload->'amount'->>'amount')::numeric
+				ELSE (payload->>'amount')::numeric
+			END,
+			CASE jsonb_typeof(payload->'current_balance')
+				WHEN 'object' THEN (payload->'current_balance'->>'amount')::numeric
+				ELSE (payload->>'current_balance')::numeric
+			END,
+			COALESCE(payload->'amount'->>'currency', 'BRL'),
+			NULLIF(payload->>'transfer_id', ''),
+			(payload->>'timestamp')::timestamp
+		FROM outbox_events
+		WHERE event_type IN ('AccountDeposited', 'AccountWithdrawn')
+		ON CONFLICT (event_id) DO NOTHING
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createIdempotencyKeysTable cria a tabela que guarda as respostas das requisições
+// enviadas com o cabeçalho Idempotency-Key
+func createIdempotencyKeysTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key VARCHAR(255) PRIMARY KEY,
+			request_hash CHAR(64) NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			response_status INT,
+			response_body BYTEA,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountHoldsTable cria a tabela das reservas ativas sobre o saldo das contas.
+// O índice por vencimento permite localizar as reservas a expirar.
+func createAccountHoldsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_holds (
+			id VARCHAR(36) PRIMARY KEY,
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			amount DECIMAL(15, 2) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_account_holds_account ON account_holds (account_id);
+		CREATE INDEX IF NOT EXISTS idx_account_holds_expires_at ON account_holds (expires_at)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// addAccountsOverdraftLimitColumn adiciona o limite de cheque especial das contas
+func addAccountsOverdraftLimitColumn(db *sql.DB) error {
+	query := `ALTER TABLE accounts ADD COLUMN IF NOT EXISTS overdraft_limit DECIMAL(15, 2) NOT NULL DEFAULT 0`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createWithdrawalLimitsTables cria a tabela de limites próprios das contas e a
+// tabela de uso, com um registro por saque, somada nas janelas móveis
+func createWithdrawalLimitsTables(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_limits (
+			account_id VARCHAR(36) PRIMARY KEY REFERENCES accounts(id),
+			tier VARCHAR(20) NOT NULL DEFAULT 'standard',
+			per_transaction DECIMAL(15, 2),
+			daily DECIMAL(15, 2),
+			monthly DECIMAL(15, 2),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS withdrawal_usage (
+			event_id VARCHAR(36) PRIMARY KEY,
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			amount DECIMAL(15, 2) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			occurred_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_withdrawal_usage_account ON withdrawal_usage (account_id, occurred_at)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// addAccountsCurrencyColumn adiciona a moeda das contas; as contas existentes
+// estão em BRL
+func addAccountsCurrencyColumn(db *sql.DB) error {
+	query := `ALTER TABLE accounts ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL'`
+	_, err := db.Exec(query)
+	return err
+}
+
+// amountColumns são as colunas de valores monetários
+var amountColumns = []struct{ table, column string }{
+	{"accounts", "balance"},
+	{"accounts", "overdraft_limit"},
+	{"transfers", "amount"},
+	{"journal_postings", "amount"},
+	{"account_transactions", "amount"},
+	{"account_transactions", "balance_after"},
+	{"account_holds", "amount"},
+	{"account_limits", "per_transaction"},
+	{"account_limits", "daily"},
+	{"account_limits", "monthly"},
+	{"withdrawal_usage", "amount"},
+}
+
+// addTransfersConversionColumns adiciona às transferências o valor creditado no
+// destino, a taxa de câmbio e a cotação usadas nas transferências entre moedas.
+// Nas transferências existentes as colunas ficam nulas: o destino recebeu o
+// próprio valor de origem.
+func addTransfersConversionColumns(db *sql.DB) error {
+	query := `
+		ALTER TABLE transfers ADD COLUMN IF NOT EXISTS destination_amount DECIMAL(18, 3);
+		ALTER TABLE transfers ADD COLUMN IF NOT EXISTS destination_currency CHAR(3);
+		ALTER TABLE transfers ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(20, 8);
+		ALTER TABLE transfers ADD COLUMN IF NOT EXISTS quote_id VARCHAR(36)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createFXQuotesTable cria a tabela das cotações de câmbio. transfer_id fica
+// nulo até que uma transferência use a cotação, o que só pode ocorrer uma vez.
+func createFXQuotesTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS fx_quotes (
+			id VARCHAR(36) PRIMARY KEY,
+			source_amount DECIMAL(18, 3) NOT NULL,
+			source_currency CHAR(3) NOT NULL,
+			destination_amount DECIMAL(18, 3) NOT NULL,
+			destination_currency CHAR(3) NOT NULL,
+			rate DECIMAL(20, 8) NOT NULL,
+			transfer_id VARCHAR(36) UNIQUE,
+			expires_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// widenAmountColumns amplia para três casas decimais as colunas de valores,
+// criadas com duas, para comportar moedas como BHD e KWD. Colunas já
+// ampliadas não são alteradas de novo.
+func widenAmountColumns(db *sql.DB) error {
+	for _, c := range amountColumns {
+		var scale int
+		err := db.QueryRow(`
+			SELECT numeric_scale
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+		`, c.table, c.column).Scan(&scale)
+		if err != nil {
+			return err
+		}
+		if scale >= 3 {
+			continue
+		}
+
+		query := fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE DECIMAL(18, 3)`, c.table, c.column)
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAccountsTypeColumn adiciona o tipo das contas; as contas existentes são correntes
+func addAccountsTypeColumn(db *sql.DB) error {
+	query := `ALTER TABLE accounts ADD COLUMN IF NOT EXISTS account_type VARCHAR(20) NOT NULL DEFAULT 'checking'`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createInterestAccrualsTable cria a tabela das apurações diárias de juros. Cada
+// conta tem no máximo uma apuração por dia; posted_at e event_id são preenchidos
+// quando os juros do mês são creditados.
+func createInterestAccrualsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS interest_accruals (
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			day DATE NOT NULL,
+			balance DECIMAL(18, 3) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			annual_rate DECIMAL(12, 6) NOT NULL,
+			interest_micros BIGINT NOT NULL,
+			event_id VARCHAR(36),
+			posted_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (account_id, day)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_interest_accruals_unposted
+			ON interest_accruals (account_id, day) WHERE posted_at IS NULL
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createFeeTables cria as tabelas de tarifas: a tabela de tarifas por tipo de
+// conta e moeda, preenchida com as tarifas padrão apenas se estiver vazia, as
+// isenções por conta e as tarifas cobradas. O índice único impede cobrar a
+// manutenção duas vezes no mesmo mês.
+func createFeeTables(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS fee_schedules (
+			account_type VARCHAR(20) NOT NULL,
+			kind VARCHAR(20) NOT NULL,
+			amount DECIMAL(18, 3) NOT NULL CHECK (amount >= 0),
+			currency CHAR(3) NOT NULL,
+			PRIMARY KEY (account_type, kind, currency)
+		);
+
+		INSERT INTO fee_schedules (account_type, kind, amount, currency)
+		SELECT * FROM (VALUES
+			('checking', 'withdrawal', 2.00, 'BRL'),
+			('checking', 'transfer', 1.00, 'BRL'),
+			('checking', 'maintenance', 12.90, 'BRL'),
+			('savings', 'withdrawal', 3.00, 'BRL'),
+			('savings', 'transfer', 1.00, 'BRL')
+		) AS defaults (account_type, kind, amount, currency)
+		WHERE NOT EXISTS (SELECT 1 FROM fee_schedules);
+
+		CREATE TABLE IF NOT EXISTS fee_waivers (
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			kind VARCHAR(20) NOT NULL,
+			reason TEXT NOT NULL,
+			expires_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (account_id, kind)
+		);
+
+		CREATE TABLE IF NOT EXISTS fee_charges (
+			event_id VARCHAR(36) PRIMARY KEY,
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			kind VARCHAR(20) NOT NULL,
+			amount DECIMAL(18, 3) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			reference VARCHAR(36),
+			period CHAR(7),
+			charged_at TIMESTAMP NOT NULL
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_fee_charges_maintenance
+			ON fee_charges (account_id, period) WHERE kind = 'maintenance'
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createTransferSchedulesTable cria a tabela das transferências agendadas. O
+// índice parcial localiza as ocorrências ativas a executar.
+func createTransferSchedulesTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS transfer_schedules (
+			id VARCHAR(36) PRIMARY KEY,
+			source_account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			destination_account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			amount DECIMAL(18, 3) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			frequency VARCHAR(10) NOT NULL,
+			start_date DATE NOT NULL,
+			end_date DATE,
+			on_insufficient_funds VARCHAR(10) NOT NULL,
+			max_attempts INT NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			next_run_at TIMESTAMP NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			retry_at TIMESTAMP,
+			last_transfer_id VARCHAR(36) NOT NULL DEFAULT '',
+			last_error TEXT NOT NULL DEFAULT '',
+			version BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_transfer_schedules_source
+			ON transfer_schedules (source_account_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_transfer_schedules_due
+			ON transfer_schedules ((COALESCE(retry_at, next_run_at))) WHERE status = 'active'
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// addJournalReversalColumn liga cada estorno ao lançamento estornado. O índice
+// único garante que um lançamento seja estornado no máximo uma vez.
+func addJournalReversalColumn(db *sql.DB) error {
+	query := `
+		ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS reverses_event_id VARCHAR(36);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_reverses
+			ON journal_entries (reverses_event_id) WHERE reverses_event_id IS NOT NULL
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountStatementsTable cria a tabela dos extratos mensais gerados pelo
+// worker, um por conta e mês
+func createAccountStatementsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS account_statements (
+			account_id VARCHAR(36) NOT NULL REFERENCES accounts(id),
+			period CHAR(7) NOT NULL,
+			content JSONB NOT NULL,
+			generated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (account_id, period)
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createAccountListingIndexes cria um índice por campo de ordenação da listagem
+// de contas, com o ID como desempate, para que a paginação por chave percorra
+// apenas as linhas da página. A ordenação por saldo é feita por moeda, e o
+// índice anterior, só por saldo, é removido.
+func createAccountListingIndexes(db *sql.DB) error {
+	query := `
+		CREATE INDEX IF NOT EXISTS idx_accounts_created_at ON accounts (created_at, id);
+		CREATE INDEX IF NOT EXISTS idx_accounts_name ON accounts (name, id);
+		CREATE INDEX IF NOT EXISTS idx_accounts_email ON accounts (email, id);
+		DROP INDEX IF EXISTS idx_accounts_balance;
+		CREATE INDEX IF NOT EXISTS idx_accounts_currency_balance ON accounts (currency, balance, id)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createPendingTransfersIndex cria o índice parcial das transferências pendentes
+// por origem, consultado no encerramento de contas
+func createPendingTransfersIndex(db *sql.DB) error {
+	query := `
+		CREATE INDEX IF NOT EXISTS idx_transfers_pending_source
+		ON transfers (source_account_id) WHERE status = 'pending'
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// instantColumns são as colunas de datas comparadas entre si como instantes
+// absolutos: as do razão, consultado pelo saldo num instante, e a do histórico
+// de transações, cujas movimentações o extrato confronta com o razão
+var instantColumns = []struct{ table, column string }{
+	{"journal_entries", "created_at"},
+	{"journal_postings", "created_at"},
+	{"account_transactions", "occurred_at"},
+}
+
+// convertInstantColumns passa as colunas de instantColumns para TIMESTAMPTZ. As
+// datas gravadas como TIMESTAMP estão no fuso local dos processos que as
+// gravaram, o mesmo deste processo, e são convertidas com o deslocamento atual
+// desse fuso. Colunas já convertidas não são alteradas de novo.
+func convertInstantColumns(db *sql.DB) error {
+	_, offset := time.Now().Zone()
+	for _, c := range instantColumns {
+		var dataType string
+		err := db.QueryRow(`
+			SELECT data_type
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+		`, c.table, c.column).Scan(&dataType)
+		if err != nil {
+			return err
+		}
+		if dataType == "timestamp with time zone" {
+			continue
+		}
+
+		query := fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE TIMESTAMPTZ USING %s AT TIME ZONE INTERVAL '%d seconds'`, c.table, c.column, c.column, offset)
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTransfersDebitEventColumn adiciona às transferências o evento do débito na
+// origem, usado na compensação para retirar o débito do uso dos limites. Nas
+// transferências existentes a coluna fica nula.
+func addTransfersDebitEventColumn(db *sql.DB) error {
+	query := `ALTER TABLE transfers ADD COLUMN IF NOT EXISTS debit_event_id VARCHAR(36)`
+	_, err := db.Exec(query)
+	return err
+}
+
+// addIdempotencyKeysLockColumn adiciona o vencimento da reserva das chaves de
+// idempotência. As chaves existentes recebem o instante da migração, e as que
+// ficaram em andamento podem ser assumidas logo em seguida.
+func addIdempotencyKeysLockColumn(db *sql.DB) error {
+	query := `ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NOT NULL DEFAULT NOW()`
+	_, err := db.Exec(query)
+	return err
+}
+
+// RunReadModelMigrations executa as migrações dos modelos de leitura, no schema
+// read_model, que pode ficar em um banco separado do modelo de escrita
+func RunReadModelMigrations(db *sql.DB) error {
+	if err := createReadModelSchema(db); err != nil {
+		return err
+	}
+
+	if err := createAccountViewsTable(db); err != nil {
+		return err
+	}
+
+	if err := createProjectionCheckpointsTable(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createReadModelSchema cria o schema dos modelos de leitura
+func createReadModelSchema(db *sql.DB) error {
+	_, err := db.Exec(`CREATE SCHEMA IF NOT EXISTS read_model`)
+	return err
+}
+
+// createAccountViewsTable cria a tabela das visões de contas mantida pela
+// projeção de contas, com os mesmos índices da listagem sobre accounts
+func createAccountViewsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS read_model.accounts (
+			id VARCHAR(36) PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			email VARCHAR(100) NOT NULL,
+			account_type VARCHAR(20) NOT NULL,
+			balance DECIMAL(18, 3) NOT NULL,
+			currency CHAR(3) NOT NULL,
+			overdraft_limit DECIMAL(18, 3) NOT NULL,
+			holds JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			version BIGINT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			projected_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_account_views_created_at ON read_model.accounts (created_at, id);
+		CREATE INDEX IF NOT EXISTS idx_account_views_name ON read_model.accounts (name, id);
+		CREATE INDEX IF NOT EXISTS idx_account_views_email ON read_model.accounts (email, id);
+		DROP INDEX IF EXISTS read_model.idx_account_views_balance;
+		CREATE INDEX IF NOT EXISTS idx_account_views_currency_balance ON read_model.accounts (currency, balance, id)
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// createProjectionCheckpointsTable cria a tabela dos checkpoints das projeções:
+// o offset da última mensagem processada por projeção e partição do tópico
+func createProjectionCheckpointsTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS read_model.projection_checkpoints (
+			projection VARCHAR(50) NOT NULL,
+			topic VARCHAR(100) NOT NULL,
+			partition INT NOT NULL,
+			"offset" BIGINT NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (projection, topic, partition)
+		)
+	`
+	_, err := db.Exec(query)
+	return err
+}
@@ -1,9 +1,11 @@
 package persistence
 
+import "github.com/viniciuslima/account-EDA/internal/domain/account"
+
 // OutboxRepositoryInterface define a interface para o repositório de outbox
 type OutboxRepositoryInterface interface {
-	// Save salva um evento no outbox
-	Save(eventType, aggregateID string, payload interface{}) error
+	// Save salva um evento no outbox, usando o ID do evento como ID do registro
+	Save(event account.Event) error
 
 	// GetPendingEvents retorna eventos pendentes para publicação
 	GetPendingEvents(limit int) ([]OutboxEvent, error)
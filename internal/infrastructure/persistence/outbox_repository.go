@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
 )
 
 // OutboxStatus representa o status de um evento no outbox
@@ -33,7 +33,7 @@ type OutboxEvent struct {
 
 // OutboxRepository é responsável pela persistência de eventos no outbox
 type OutboxRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewOutboxRepository cria um novo repositório para o outbox
@@ -43,10 +43,10 @@ func NewOutboxRepository(db *sql.DB) *OutboxRepository {
 	}
 }
 
-// Save salva um evento no outbox
-func (r *OutboxRepository) Save(eventType, aggregateID string, payload interface{}) error {
+// Save salva um evento no outbox, usando o ID do evento como ID do registro
+func (r *OutboxRepository) Save(event account.Event) error {
 	// Serializar o payload para JSON
-	data, err := json.Marshal(payload)
+	data, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("erro ao serializar evento para outbox: %w", err)
 	}
@@ -60,9 +60,9 @@ func (r *OutboxRepository) Save(eventType, aggregateID string, payload interface
 
 	_, err = r.db.Exec(
 		query,
-		uuid.New().String(),
-		eventType,
-		aggregateID,
+		event.EventID(),
+		event.EventName(),
+		event.AggregateID(),
 		data,
 		string(OutboxStatusPending),
 		0,
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	_ "github.com/lib/pq" // Driver PostgreSQL
@@ -12,7 +13,7 @@ import (
 
 // PostgresRepository implementa account.Repository usando PostgreSQL
 type PostgresRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewPostgresRepository cria um novo repositório PostgreSQL
@@ -30,94 +31,119 @@ func NewPostgresRepository(connectionString string) (*PostgresRepository, error)
 	return &PostgresRepository{db: db}, nil
 }
 
+// NewPostgresRepositoryFromDB cria um repositório que reutiliza uma conexão existente
+func NewPostgresRepositoryFromDB(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
 // Close fecha a conexão com o banco de dados
 func (r *PostgresRepository) Close() error {
-	return r.db.Close()
+	if closer, ok := r.db.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 // Save persiste uma conta no banco de dados
 func (r *PostgresRepository) Save(account *account.Account) error {
 	query := `
-		INSERT INTO accounts (id, name, email, balance, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO accounts (id, name, email, account_type, balance, currency, overdraft_limit, status, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 	_, err := r.db.Exec(
 		query,
 		account.ID,
 		account.Name,
 		account.Email,
-		account.Balance,
+		account.Type,
+		account.Balance.String(),
+		account.Currency(),
+		account.OverdraftLimit.String(),
 		account.Status,
+		account.Version,
 		account.CreatedAt,
 		account.UpdatedAt,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	account.ClearChanges()
+	return nil
 }
 
 // FindByID busca uma conta pelo ID
 func (r *PostgresRepository) FindByID(id string) (*account.Account, error) {
 	query := `
-		SELECT id, name, email, balance, status, created_at, updated_at
+		SELECT id, name, email, account_type, balance, currency, overdraft_limit, status, version, created_at, updated_at
 		FROM accounts
 		WHERE id = $1
 	`
 	row := r.db.QueryRow(query, id)
 
-	return r.scanAccount(row)
+	return r.withHolds(r.scanAccount(row))
 }
 
 // FindByEmail busca uma conta pelo email
 func (r *PostgresRepository) FindByEmail(email string) (*account.Account, error) {
 	query := `
-		SELECT id, name, email, balance, status, created_at, updated_at
+		SELECT id, name, email, account_type, balance, currency, overdraft_limit, status, version, created_at, updated_at
 		FROM accounts
 		WHERE email = $1
 	`
 	row := r.db.QueryRow(query, email)
 
-	return r.scanAccount(row)
+	return r.withHolds(r.scanAccount(row))
 }
 
-// FindAll busca todas as contas
-func (r *PostgresRepository) FindAll() ([]*account.Account, error) {
-	query := `
-		SELECT id, name, email, balance, status, created_at, updated_at
-		FROM accounts
-	`
-	rows, err := r.db.Query(query)
+// Find busca uma página das contas que atendem aos critérios
+func (r *PostgresRepository) Find(criteria account.Criteria) (*account.Page, error) {
+	query, args := accountPageQuery("accounts", "id, name, email, account_type, balance, currency, overdraft_limit, status, version, created_at, updated_at", criteria)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var accounts []*account.Account
+	var cursors []account.Cursor
 	for rows.Next() {
-		account, err := r.scanAccountFromRows(rows)
+		var sortValue string
+		acc, err := r.scanAccountFromRows(rows, &sortValue)
 		if err != nil {
 			return nil, err
 		}
-		accounts = append(accounts, account)
+		accounts = append(accounts, acc)
+		cursors = append(cursors, account.Cursor{Value: sortValue, Currency: acc.Currency(), ID: acc.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	rows.Close()
 
-	return accounts, rows.Err()
+	if err := loadHolds(r.db, accounts...); err != nil {
+		return nil, err
+	}
+	return newAccountPage(r.db, "accounts", criteria, accounts, cursors)
 }
 
-// Update atualiza uma conta
-func (r *PostgresRepository) Update(account *account.Account) error {
+// Update atualiza uma conta, desde que ela não tenha sido alterada desde a leitura
+func (r *PostgresRepository) Update(acc *account.Account) error {
 	query := `
 		UPDATE accounts
-		SET name = $1, email = $2, balance = $3, status = $4, updated_at = $5
-		WHERE id = $6
+		SET name = $1, email = $2, balance = $3, overdraft_limit = $4, status = $5, updated_at = $6, version = version + 1
+		WHERE id = $7 AND version = $8
 	`
 	result, err := r.db.Exec(
 		query,
-		account.Name,
-		account.Email,
-		account.Balance,
-		account.Status,
+		acc.Name,
+		acc.Email,
+		acc.Balance.String(),
+		acc.OverdraftLimit.String(),
+		acc.Status,
 		time.Now(),
-		account.ID,
+		acc.ID,
+		acc.Version,
 	)
 	if err != nil {
 		return err
@@ -129,43 +155,53 @@ func (r *PostgresRepository) Update(account *account.Account) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("account with ID %s not found", account.ID)
+		// Distingue conta inexistente de conta alterada por outra transação
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM accounts WHERE id = $1)`, acc.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return account.ErrVersionConflict
+		}
+		return fmt.Errorf("account with ID %s not found", acc.ID)
 	}
 
-	return nil
-}
-
-// Delete remove uma conta
-func (r *PostgresRepository) Delete(id string) error {
-	query := `DELETE FROM accounts WHERE id = $1`
-	result, err := r.db.Exec(query, id)
-	if err != nil {
+	if err := saveHolds(r.db, acc); err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	acc.Version++
+	acc.ClearChanges()
+	return nil
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("account with ID %s not found", id)
+// withHolds carrega as reservas ativas da conta encontrada
+func (r *PostgresRepository) withHolds(acc *account.Account, err error) (*account.Account, error) {
+	if err != nil || acc == nil {
+		return acc, err
 	}
-
-	return nil
+	if err := loadHolds(r.db, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
 }
 
 // scanAccount escaneia uma linha da consulta para uma entidade Account
 func (r *PostgresRepository) scanAccount(row *sql.Row) (*account.Account, error) {
 	var acc account.Account
+	var accountType, balance, currency, overdraftLimit string
 	var status string
 
 	err := row.Scan(
 		&acc.ID,
 		&acc.Name,
 		&acc.Email,
-		&acc.Balance,
+		&accountType,
+		&balance,
+		&currency,
+		&overdraftLimit,
 		&status,
+		&acc.Version,
 		&acc.CreatedAt,
 		&acc.UpdatedAt,
 	)
@@ -177,29 +213,52 @@ func (r *PostgresRepository) scanAccount(row *sql.Row) (*account.Account, error)
 		return nil, err
 	}
 
+	if acc.Balance, err = account.ParseMoney(balance, currency); err != nil {
+		return nil, err
+	}
+	if acc.OverdraftLimit, err = account.ParseMoney(overdraftLimit, currency); err != nil {
+		return nil, err
+	}
+
+	acc.Type = account.AccountType(accountType)
 	acc.Status = account.AccountStatus(status)
 	return &acc, nil
 }
 
-// scanAccountFromRows escaneia uma linha do resultado para uma entidade Account
-func (r *PostgresRepository) scanAccountFromRows(rows *sql.Rows) (*account.Account, error) {
+// scanAccountFromRows escaneia uma linha do resultado para uma entidade Account.
+// As colunas selecionadas além das da conta são lidas em extra.
+func (r *PostgresRepository) scanAccountFromRows(rows *sql.Rows, extra ...interface{}) (*account.Account, error) {
 	var acc account.Account
+	var accountType, balance, currency, overdraftLimit string
 	var status string
 
-	err := rows.Scan(
+	dest := []interface{}{
 		&acc.ID,
 		&acc.Name,
 		&acc.Email,
-		&acc.Balance,
+		&accountType,
+		&balance,
+		&currency,
+		&overdraftLimit,
 		&status,
+		&acc.Version,
 		&acc.CreatedAt,
 		&acc.UpdatedAt,
-	)
+	}
+	err := rows.Scan(append(dest, extra...)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if acc.Balance, err = account.ParseMoney(balance, currency); err != nil {
+		return nil, err
+	}
+	if acc.OverdraftLimit, err = account.ParseMoney(overdraftLimit, currency); err != nil {
+		return nil, err
+	}
+
+	acc.Type = account.AccountType(accountType)
 	acc.Status = account.AccountStatus(status)
 	return &acc, nil
 }
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+)
+
+// PostgresQuoteRepository implementa fx.QuoteRepository usando PostgreSQL
+type PostgresQuoteRepository struct {
+	db DBTX
+}
+
+// NewPostgresQuoteRepository cria um novo repositório de cotações
+func NewPostgresQuoteRepository(db *sql.DB) *PostgresQuoteRepository {
+	return &PostgresQuoteRepository{db: db}
+}
+
+// Save persiste uma cotação
+func (r *PostgresQuoteRepository) Save(q *fx.Quote) error {
+	query := `
+		INSERT INTO fx_quotes
+		(id, source_amount, source_currency, destination_amount, destination_currency, rate, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Exec(
+		query,
+		q.ID,
+		q.SourceAmount.String(),
+		q.SourceAmount.Currency(),
+		q.DestinationAmount.String(),
+		q.DestinationAmount.Currency(),
+		q.Rate.String(),
+		q.ExpiresAt,
+		q.CreatedAt,
+	)
+	return err
+}
+
+// FindByID busca uma cotação pelo ID
+func (r *PostgresQuoteRepository) FindByID(id string) (*fx.Quote, error) {
+	query := `
+		SELECT id, source_amount, source_currency, destination_amount, destination_currency,
+		       rate, transfer_id, expires_at, created_at
+		FROM fx_quotes
+		WHERE id = $1
+	`
+
+	var q fx.Quote
+	var sourceAmount, sourceCurrency, destinationAmount, destinationCurrency, rate string
+	var transferID sql.NullString
+
+	err := r.db.QueryRow(query, id).Scan(
+		&q.ID,
+		&sourceAmount,
+		&sourceCurrency,
+		&destinationAmount,
+		&destinationCurrency,
+		&rate,
+		&transferID,
+		&q.ExpiresAt,
+		&q.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Nenhuma cotação encontrada
+		}
+		return nil, err
+	}
+
+	if q.SourceAmount, err = account.ParseMoney(sourceAmount, sourceCurrency); err != nil {
+		return nil, err
+	}
+	if q.DestinationAmount, err = account.ParseMoney(destinationAmount, destinationCurrency); err != nil {
+		return nil, err
+	}
+	if q.Rate, err = fx.ParseRate(sourceCurrency, destinationCurrency, rate); err != nil {
+		return nil, err
+	}
+	q.TransferID = transferID.String
+	return &q, nil
+}
+
+// MarkUsed vincula a cotação à transferência. A condição sobre transfer_id
+// garante que duas transferências concorrentes não usem a mesma cotação.
+func (r *PostgresQuoteRepository) MarkUsed(id, transferID string) error {
+	query := `
+		UPDATE fx_quotes
+		SET transfer_id = $1
+		WHERE id = $2 AND transfer_id IS NULL
+	`
+	result, err := r.db.Exec(query, transferID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fx.ErrQuoteAlreadyUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package persistence
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+)
+
+// PostgresScheduleRepository implementa schedule.Repository usando PostgreSQL
+type PostgresScheduleRepository struct {
+	db DBTX
+}
+
+// NewPostgresScheduleRepository cria um novo repositório de agendamentos
+func NewPostgresScheduleRepository(db *sql.DB) *PostgresScheduleRepository {
+	return &PostgresScheduleRepository{db: db}
+}
+
+const scheduleColumns = `
+	id, source_account_id, destination_account_id, amount, currency, frequency,
+	start_date, end_date, on_insufficient_funds, max_attempts, status,
+	next_run_at, attempts, retry_at, last_transfer_id, last_error, version,
+	created_at, updated_at
+`
+
+// Save persiste um novo agendamento
+func (r *PostgresScheduleRepository) Save(s *schedule.Schedule) error {
+	query := `INSERT INTO transfer_schedules (` + scheduleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`
+	_, err := r.db.Exec(
+		query,
+		s.ID,
+		s.SourceAccountID,
+		s.DestinationAccountID,
+		s.Amount.String(),
+		s.Amount.Currency(),
+		string(s.Frequency),
+		s.StartDate,
+		s.EndDate,
+		s.OnInsufficientFunds,
+		s.MaxAttempts,
+		string(s.Status),
+		s.NextRunAt,
+		s.Attempts,
+		s.RetryAt,
+		s.LastTransferID,
+		s.LastError,
+		s.Version,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	return err
+}
+
+// FindByID busca um agendamento pelo ID
+func (r *PostgresScheduleRepository) FindByID(id string) (*schedule.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM transfer_schedules WHERE id = $1`
+
+	s, err := scanSchedule(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return s, err
+}
+
+// FindByAccount retorna os agendamentos em que a conta é a origem
+func (r *PostgresScheduleRepository) FindByAccount(accountID string) ([]*schedule.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + `
+		FROM transfer_schedules
+		WHERE source_account_id = $1
+		ORDER BY created_at DESC, id DESC`
+	return r.query(query, accountID)
+}
+
+// FindDue retorna os agendamentos ativos cuja ocorrência atual, ou a
+// retentativa dela, vence até now
+func (r *PostgresScheduleRepository) FindDue(now time.Time, limit int) ([]*schedule.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + `
+		FROM transfer_schedules
+		WHERE status = $1 AND COALESCE(retry_at, next_run_at) <= $2
+		ORDER BY COALESCE(retry_at, next_run_at), id
+		LIMIT $3`
+	return r.query(query, string(schedule.StatusActive), now, limit)
+}
+
+// Update persiste o agendamento com controle de concorrência otimista
+func (r *PostgresScheduleRepository) Update(s *schedule.Schedule) error {
+	query := `
+		UPDATE transfer_schedules
+		SET amount = $1, currency = $2, end_date = $3, on_insufficient_funds = $4,
+		    max_attempts = $5, status = $6, next_run_at = $7, attempts = $8,
+		    retry_at = $9, last_transfer_id = $10, last_error = $11,
+		    updated_at = $12, version = version + 1
+		WHERE id = $13 AND version = $14
+	`
+	result, err := r.db.Exec(
+		query,
+		s.Amount.String(),
+		s.Amount.Currency(),
+		s.EndDate,
+		s.OnInsufficientFunds,
+		s.MaxAttempts,
+		string(s.Status),
+		s.NextRunAt,
+		s.Attempts,
+		s.RetryAt,
+		s.LastTransferID,
+		s.LastError,
+		s.UpdatedAt,
+		s.ID,
+		s.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return schedule.ErrVersionConflict
+	}
+	s.Version++
+	return nil
+}
+
+func (r *PostgresScheduleRepository) query(query string, args ...any) ([]*schedule.Schedule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*schedule.Schedule
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+	return schedules, rows.Err()
+}
+
+// scanner abstrai *sql.Row e *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchedule(row scanner) (*schedule.Schedule, error) {
+	var s schedule.Schedule
+	var amount, currency, frequency, status string
+	var endDate, retryAt sql.NullTime
+	err := row.Scan(
+		&s.ID,
+		&s.SourceAccountID,
+		&s.DestinationAccountID,
+		&amount,
+		&currency,
+		&frequency,
+		&s.StartDate,
+		&endDate,
+		&s.OnInsufficientFunds,
+		&s.MaxAttempts,
+		&status,
+		&s.NextRunAt,
+		&s.Attempts,
+		&retryAt,
+		&s.LastTransferID,
+		&s.LastError,
+		&s.Version,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Amount, err = account.ParseMoney(amount, currency); err != nil {
+		return nil, err
+	}
+	s.Frequency = schedule.Frequency(frequency)
+	s.Status = schedule.Status(status)
+	s.StartDate = schedule.Day(s.StartDate)
+	s.NextRunAt = schedule.Day(s.NextRunAt)
+	if endDate.Valid {
+		end := schedule.Day(endDate.Time)
+		s.EndDate = &end
+	}
+	if retryAt.Valid {
+		s.RetryAt = &retryAt.Time
+	}
+	return &s, nil
+}
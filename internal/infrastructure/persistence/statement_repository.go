@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+)
+
+// PostgresStatementRepository implementa query.StatementRepository sobre a
+// tabela account_statements, que guarda cada extrato mensal como JSON
+type PostgresStatementRepository struct {
+	db DBTX
+}
+
+// NewPostgresStatementRepository cria um novo repositório de extratos
+func NewPostgresStatementRepository(db *sql.DB) *PostgresStatementRepository {
+	return &PostgresStatementRepository{db: db}
+}
+
+// Save grava o extrato mensal. A chave é a conta e o mês, então um extrato já
+// gerado, por outro worker por exemplo, não é substituído.
+func (r *PostgresStatementRepository) Save(statement *query.StatementDTO) error {
+	content, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO account_statements (account_id, period, content, generated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id, period) DO NOTHING
+	`, statement.AccountID, statement.Period, content, statement.GeneratedAt)
+	return err
+}
+
+// FindByPeriod busca o extrato mensal da conta
+func (r *PostgresStatementRepository) FindByPeriod(accountID, period string) (*query.StatementDTO, error) {
+	var content []byte
+	err := r.db.QueryRow(`
+		SELECT content FROM account_statements WHERE account_id = $1 AND period = $2
+	`, accountID, period).Scan(&content)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var statement query.StatementDTO
+	if err := json.Unmarshal(content, &statement); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// FindPending retorna as contas abertas antes de openedBefore sem extrato do mês
+func (r *PostgresStatementRepository) FindPending(period string, openedBefore time.Time, afterID string, limit int) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id
+		FROM accounts a
+		WHERE a.id > $1 AND a.created_at < $2
+		AND NOT EXISTS (
+			SELECT 1 FROM account_statements s WHERE s.account_id = a.id AND s.period = $3
+		)
+		ORDER BY a.id
+		LIMIT $4
+	`, afterID, openedBefore, period, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/viniciuslima/account-EDA/internal/application/query"
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+)
+
+// PostgresTransactionHistoryRepository implementa query.TransactionHistoryRepository
+// sobre a tabela account_transactions
+type PostgresTransactionHistoryRepository struct {
+	db DBTX
+}
+
+// NewPostgresTransactionHistoryRepository cria um novo repositório do histórico de transações
+func NewPostgresTransactionHistoryRepository(db *sql.DB) *PostgresTransactionHistoryRepository {
+	return &PostgresTransactionHistoryRepository{db: db}
+}
+
+// Append grava uma transação no histórico. O ID do evento é a chave primária,
+// então reprocessar um evento já projetado não tem efeito.
+func (r *PostgresTransactionHistoryRepository) Append(record query.TransactionRecord) error {
+	q := `
+		INSERT INTO account_transactions (event_id, account_id, type, amount, balance_after, currency, transfer_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+	_, err := r.db.Exec(
+		q,
+		record.EventID,
+		record.AccountID,
+		record.Type,
+		record.Amount.String(),
+		record.BalanceAfter.String(),
+		record.Amount.Currency(),
+		record.TransferID,
+		record.OccurredAt,
+	)
+	return err
+}
+
+// FindByAccount busca as transações da conta, da mais recente para a mais antiga,
+// usando paginação por chave (occurred_at, event_id)
+func (r *PostgresTransactionHistoryRepository) FindByAccount(filter query.TransactionFilter) ([]query.TransactionRecord, error) {
+	conditions := []string{"account_id = $1"}
+	args := []any{filter.AccountID}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("occurred_at < $%d", len(args)))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.OccurredAt, filter.After.EventID)
+		conditions = append(conditions, fmt.Sprintf("(occurred_at, event_id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	q := fmt.Sprintf(`
+		SELECT event_id, account_id, type, amount, balance_after, currency, COALESCE(transfer_id, ''), occurred_at
+		FROM account_transactions
+		WHERE %s
+		ORDER BY occurred_at DESC, event_id DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []query.TransactionRecord
+	for rows.Next() {
+		var record query.TransactionRecord
+		var amount, balance, currency string
+		err := rows.Scan(
+			&record.EventID,
+			&record.AccountID,
+			&record.Type,
+			&amount,
+			&balance,
+			&currency,
+			&record.TransferID,
+			&record.OccurredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if record.Amount, err = account.ParseMoney(amount, currency); err != nil {
+			return nil, err
+		}
+		if record.BalanceAfter, err = account.ParseMoney(balance, currency); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// PostgresTransferRepository implementa transfer.Repository usando PostgreSQL
+type PostgresTransferRepository struct {
+	db DBTX
+}
+
+// NewPostgresTransferRepository cria um novo repositório de transferências
+func NewPostgresTransferRepository(db *sql.DB) *PostgresTransferRepository {
+	return &PostgresTransferRepository{db: db}
+}
+
+// Save persiste uma transferência
+func (r *PostgresTransferRepository) Save(t *transfer.Transfer) error {
+	query := `
+		INSERT INTO transfers
+		(id, source_account_id, destination_account_id, amount, currency,
+		 destination_amount, destination_currency, exchange_rate, quote_id,
+		 debit_event_id, status, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err := r.db.Exec(
+		query,
+		t.ID,
+		t.SourceAccountID,
+		t.DestinationAccountID,
+		t.Amount.String(),
+		t.Amount.Currency(),
+		t.DestinationAmount.String(),
+		t.DestinationAmount.Currency(),
+		sql.NullString{String: t.ExchangeRate, Valid: t.ExchangeRate != ""},
+		sql.NullString{String: t.QuoteID, Valid: t.QuoteID != ""},
+		sql.NullString{String: t.DebitEventID, Valid: t.DebitEventID != ""},
+		string(t.Status),
+		t.FailureReason,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+
+	return err
+}
+
+// FindByID busca uma transferência pelo ID. Transferências gravadas antes da
+// conversão de moedas não têm valor de destino: ele é igual ao valor de origem.
+func (r *PostgresTransferRepository) FindByID(id string) (*transfer.Transfer, error) {
+	query := `
+		SELECT id, source_account_id, destination_account_id, amount, currency,
+		       COALESCE(destination_amount, amount), COALESCE(destination_currency, currency),
+		       exchange_rate, quote_id, debit_event_id,
+		       status, failure_reason, created_at, updated_at
+		FROM transfers
+		WHERE id = $1
+	`
+
+	var t transfer.Transfer
+	var amount, currency, destinationAmount, destinationCurrency, status string
+	var exchangeRate, quoteID, debitEventID sql.NullString
+
+	err := r.db.QueryRow(query, id).Scan(
+		&t.ID,
+		&t.SourceAccountID,
+		&t.DestinationAccountID,
+		&amount,
+		&currency,
+		&destinationAmount,
+		&destinationCurrency,
+		&exchangeRate,
+		&quoteID,
+		&debitEventID,
+		&status,
+		&t.FailureReason,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Nenhuma transferência encontrada
+		}
+		return nil, err
+	}
+
+	if t.Amount, err = account.ParseMoney(amount, currency); err != nil {
+		return nil, err
+	}
+	if t.DestinationAmount, err = account.ParseMoney(destinationAmount, destinationCurrency); err != nil {
+		return nil, err
+	}
+	if exchangeRate.Valid {
+		rate, err := fx.ParseRate(currency, destinationCurrency, exchangeRate.String)
+		if err != nil {
+			return nil, err
+		}
+		t.ExchangeRate = rate.String()
+	}
+	t.QuoteID = quoteID.String
+	t.DebitEventID = debitEventID.String
+
+	t.Status = transfer.Status(status)
+	return &t, nil
+}
+
+// Update persiste a finalização de uma transferência. A condição sobre o status
+// garante que apenas uma transação consiga finalizar uma transferência pendente,
+// mesmo quando a API e o worker processam a mesma transferência ao mesmo tempo.
+func (r *PostgresTransferRepository) Update(t *transfer.Transfer) error {
+	query := `
+		UPDATE transfers
+		SET status = $1, failure_reason = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+	result, err := r.db.Exec(
+		query,
+		string(t.Status),
+		t.FailureReason,
+		t.UpdatedAt,
+		t.ID,
+		string(transfer.StatusPending),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return transfer.ErrAlreadyFinalized
+	}
+
+	return nil
+}
+
+// HasPendingFrom indica se a conta é origem de alguma transferência ainda
+// pendente, isto é, debitada e aguardando crédito ou devolução
+func (r *PostgresTransferRepository) HasPendingFrom(accountID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM transfers
+			WHERE source_account_id = $1 AND status = $2
+		)
+	`
+	var pending bool
+	err := r.db.QueryRow(query, accountID, string(transfer.StatusPending)).Scan(&pending)
+	return pending, err
+}
@@ -0,0 +1,164 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/viniciuslima/account-EDA/internal/domain/account"
+	"github.com/viniciuslima/account-EDA/internal/domain/fee"
+	"github.com/viniciuslima/account-EDA/internal/domain/fx"
+	"github.com/viniciuslima/account-EDA/internal/domain/interest"
+	"github.com/viniciuslima/account-EDA/internal/domain/ledger"
+	"github.com/viniciuslima/account-EDA/internal/domain/limit"
+	"github.com/viniciuslima/account-EDA/internal/domain/schedule"
+	"github.com/viniciuslima/account-EDA/internal/domain/transfer"
+)
+
+// DBTX abstrai *sql.DB e *sql.Tx, permitindo que os repositórios operem
+// tanto diretamente na conexão quanto dentro de uma transação
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Transaction expõe os repositórios vinculados a uma transação em andamento
+type Transaction interface {
+	// Accounts retorna o repositório de contas da transação
+	Accounts() account.Repository
+
+	// Outbox retorna o repositório de outbox da transação
+	Outbox() OutboxRepositoryInterface
+
+	// Transfers retorna o repositório de transferências da transação
+	Transfers() transfer.Repository
+
+	// Ledger retorna o razão contábil da transação
+	Ledger() ledger.Repository
+
+	// Limits retorna os limites de saque da transação
+	Limits() limit.Repository
+
+	// Quotes retorna as cotações de câmbio da transação
+	Quotes() fx.QuoteRepository
+
+	// Interest retorna as apurações de juros da transação
+	Interest() interest.Repository
+
+	// Fees retorna as tarifas e isenções da transação
+	Fees() fee.Repository
+
+	// Schedules retorna as transferências agendadas da transação
+	Schedules() schedule.Repository
+}
+
+// UnitOfWork agrupa escritas em diferentes repositórios numa única transação
+type UnitOfWork interface {
+	// Do executa fn dentro de uma transação. O commit só acontece se fn
+	// retornar nil; qualquer erro (ou panic) desfaz todas as escritas.
+	Do(fn func(tx Transaction) error) error
+}
+
+// PostgresUnitOfWork implementa UnitOfWork usando transações do PostgreSQL
+type PostgresUnitOfWork struct {
+	db            *sql.DB
+	eventSourced  bool
+	snapshotEvery int64
+}
+
+// UnitOfWorkOption configura uma PostgresUnitOfWork
+type UnitOfWorkOption func(*PostgresUnitOfWork)
+
+// WithEventSourcedAccounts faz as transações persistirem contas no event store
+// (EventSourcedRepository) em vez da tabela accounts (PostgresRepository),
+// gravando um snapshot a cada snapshotEvery eventos (zero desativa)
+func WithEventSourcedAccounts(snapshotEvery int64) UnitOfWorkOption {
+	return func(u *PostgresUnitOfWork) {
+		u.eventSourced = true
+		u.snapshotEvery = snapshotEvery
+	}
+}
+
+// NewPostgresUnitOfWork cria uma nova unidade de trabalho PostgreSQL
+func NewPostgresUnitOfWork(db *sql.DB, opts ...UnitOfWorkOption) *PostgresUnitOfWork {
+	u := &PostgresUnitOfWork{db: db}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Do executa fn numa transação do PostgreSQL
+func (u *PostgresUnitOfWork) Do(fn func(tx Transaction) error) (err error) {
+	sqlTx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&postgresTransaction{tx: sqlTx, eventSourced: u.eventSourced, snapshotEvery: u.snapshotEvery}); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil {
+			log.Printf("Erro ao desfazer transação: %v", rbErr)
+		}
+		return err
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
+	return nil
+}
+
+// postgresTransaction vincula os repositórios a uma *sql.Tx
+type postgresTransaction struct {
+	tx            *sql.Tx
+	eventSourced  bool
+	snapshotEvery int64
+}
+
+func (t *postgresTransaction) Accounts() account.Repository {
+	if t.eventSourced {
+		return &EventSourcedRepository{db: t.tx, snapshotEvery: t.snapshotEvery}
+	}
+	return &PostgresRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Outbox() OutboxRepositoryInterface {
+	return &OutboxRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Transfers() transfer.Repository {
+	return &PostgresTransferRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Ledger() ledger.Repository {
+	return &PostgresLedgerRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Limits() limit.Repository {
+	return &PostgresLimitRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Quotes() fx.QuoteRepository {
+	return &PostgresQuoteRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Interest() interest.Repository {
+	return &PostgresInterestRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Fees() fee.Repository {
+	return &PostgresFeeRepository{db: t.tx}
+}
+
+func (t *postgresTransaction) Schedules() schedule.Repository {
+	return &PostgresScheduleRepository{db: t.tx}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Job executa uma tarefa periodicamente em segundo plano
+type Job struct {
+	name     string
+	interval time.Duration
+	run      func(now time.Time) error
+	stopCh   chan struct{}
+}
+
+// NewJob cria uma tarefa que executa run a cada interval
+func NewJob(name string, interval time.Duration, run func(now time.Time) error) *Job {
+	return &Job{
+		name:     name,
+		interval: interval,
+		run:      run,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start inicia a tarefa em uma goroutine
+func (j *Job) Start() {
+	go j.loop()
+}
+
+// Stop interrompe a tarefa
+func (j *Job) Stop() {
+	close(j.stopCh)
+}
+
+// loop executa a tarefa a cada tick até que Stop seja chamado. Erros são
+// registrados e a tarefa é executada novamente no próximo tick.
+func (j *Job) loop() {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := j.run(now); err != nil {
+				log.Printf("Erro ao executar tarefa %s: %v", j.name, err)
+			}
+		case <-j.stopCh:
+			log.Printf("Tarefa %s interrompida", j.name)
+			return
+		}
+	}
+}